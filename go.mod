@@ -4,6 +4,7 @@ go 1.12
 
 require (
 	github.com/Workiva/go-datastructures v1.0.50
+	github.com/armon/go-metrics v0.0.0-20190430140413-ec5e00d3c878
 	github.com/dustin/go-humanize v1.0.0
 	github.com/golang/protobuf v1.3.1
 	github.com/hako/durafmt v0.0.0-20190612201238-650ed9f29a84
@@ -22,4 +23,5 @@ require (
 	github.com/urfave/cli v1.20.0
 	golang.org/x/net v0.0.0-20190628185345-da137c7871d7
 	google.golang.org/grpc v1.22.0
+	gopkg.in/natefinch/lumberjack.v2 v2.0.0
 )
@@ -1,4 +1,5 @@
 //go:generate protoc -I=. -I=$GOPATH/src --gofast_out=. ./server/proto/internal.proto
+//go:generate protoc -I=. -I=$GOPATH/src --gofast_out=plugins=grpc:. ./server/proto/admin.proto
 
 package main
 
@@ -29,45 +30,15 @@ func main() {
 			return err
 		}
 
-		// Override with flags.
-		if c.IsSet("id") {
-			config.Clustering.ServerID = c.String("id")
+		// Override with flags. These are also applied when the configuration
+		// is reloaded so that flags continue to take precedence.
+		overrides := func(config *server.Config) error {
+			return applyFlags(c, config)
 		}
-		if c.IsSet("namespace") {
-			config.Clustering.Namespace = c.String("namespace")
-		}
-		if c.IsSet("port") {
-			config.Port = c.Int("port")
-		}
-		if c.IsSet("level") {
-			level, err := server.GetLogLevel(c.String("level"))
-			if err != nil {
-				return err
-			}
-			config.LogLevel = level
-		}
-		if c.IsSet("raft-bootstrap-seed") {
-			config.Clustering.RaftBootstrapSeed = c.Bool("raft-bootstrap-seed")
-		}
-		if c.IsSet("raft-bootstrap-peers") {
-			config.Clustering.RaftBootstrapPeers = c.StringSlice("raft-bootstrap-peers")
-		}
-		if c.IsSet("data-dir") {
-			config.DataDir = c.String("data-dir")
-		}
-		if c.IsSet("tls-cert") {
-			config.TLSCert = c.String("tls-cert")
-		}
-		if c.IsSet("tls-key") {
-			config.TLSKey = c.String("tls-key")
-		}
-		if c.IsSet("nats-servers") {
-			natsServers, err := normalizeNatsServers(c.StringSlice("nats-servers"))
-			if err != nil {
-				return err
-			}
-			config.NATS.Servers = natsServers
+		if err := overrides(config); err != nil {
+			return err
 		}
+		config.ReloadOverrides = overrides
 
 		server := server.New(config)
 		if err := server.Start(); err != nil {
@@ -104,6 +75,10 @@ func getFlags() []cli.Flag {
 			// where it does not replace this value with the specified values but appends them:-(
 			// Value: &cli.StringSlice{nats.DefaultURL},
 		},
+		cli.BoolFlag{
+			Name:  "embedded-nats, e",
+			Usage: "run a NATS server embedded in this process and connect to it",
+		},
 		cli.StringFlag{
 			Name:  "data-dir, d",
 			Usage: "store data in `DIR` (default: \"/tmp/liftbridge/<namespace>\")",
@@ -137,6 +112,53 @@ func getFlags() []cli.Flag {
 	}
 }
 
+// applyFlags overrides settings in the given Config with any flags which were
+// set on the command line.
+func applyFlags(c *cli.Context, config *server.Config) error {
+	if c.IsSet("id") {
+		config.Clustering.ServerID = c.String("id")
+	}
+	if c.IsSet("namespace") {
+		config.Clustering.Namespace = c.String("namespace")
+	}
+	if c.IsSet("port") {
+		config.Port = c.Int("port")
+	}
+	if c.IsSet("level") {
+		level, err := server.GetLogLevel(c.String("level"))
+		if err != nil {
+			return err
+		}
+		config.LogLevel = level
+	}
+	if c.IsSet("raft-bootstrap-seed") {
+		config.Clustering.RaftBootstrapSeed = c.Bool("raft-bootstrap-seed")
+	}
+	if c.IsSet("raft-bootstrap-peers") {
+		config.Clustering.RaftBootstrapPeers = c.StringSlice("raft-bootstrap-peers")
+	}
+	if c.IsSet("data-dir") {
+		config.DataDir = c.String("data-dir")
+	}
+	if c.IsSet("tls-cert") {
+		config.TLSCert = c.String("tls-cert")
+	}
+	if c.IsSet("tls-key") {
+		config.TLSKey = c.String("tls-key")
+	}
+	if c.IsSet("nats-servers") {
+		natsServers, err := normalizeNatsServers(c.StringSlice("nats-servers"))
+		if err != nil {
+			return err
+		}
+		config.NATS.Servers = natsServers
+	}
+	if c.IsSet("embedded-nats") {
+		config.EmbeddedNATS.Enabled = c.Bool("embedded-nats")
+	}
+	return nil
+}
+
 func normalizeNatsServers(natsServers []string) ([]string, error) {
 	if natsServers != nil {
 		// urlfave.cli has issues with *Slice flags - it doesn't yet parse
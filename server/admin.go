@@ -0,0 +1,399 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/raft"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/liftbridge-io/liftbridge/server/proto"
+)
+
+// adminServer implements the gRPC server interface operators use to manage a
+// running server.
+type adminServer struct {
+	*Server
+}
+
+// ReloadConfig re-parses the server's configuration file and applies any
+// settings which can be changed without a restart. Changed settings which
+// require a restart are reported as rejected. It returns a FailedPrecondition
+// status code if the configuration can't be reloaded.
+func (a *adminServer) ReloadConfig(ctx context.Context, req *proto.ReloadConfigRequest) (
+	*proto.ReloadConfigResponse, error) {
+
+	a.logger.Debugf("api: ReloadConfig")
+
+	applied, rejected, err := a.reloadConfig()
+	if err != nil {
+		a.logger.Errorf("api: Failed to reload configuration: %v", err)
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &proto.ReloadConfigResponse{Applied: applied, Rejected: rejected}, nil
+}
+
+// CreateMirrorStream creates a stream which continuously pulls committed
+// messages from a source stream in another cluster. It returns an
+// AlreadyExists status code if a stream with the given subject and name
+// already exists.
+func (a *adminServer) CreateMirrorStream(ctx context.Context, req *proto.CreateMirrorStreamRequest) (
+	*proto.CreateMirrorStreamResponse, error) {
+
+	if req.ReplicationFactor == 0 {
+		req.ReplicationFactor = 1
+	}
+	a.logger.Debugf("api: CreateMirrorStream [subject=%s, name=%s, replicationFactor=%d, "+
+		"sourceAddrs=%v, sourceSubject=%s, sourceName=%s]", req.Subject, req.Name,
+		req.ReplicationFactor, req.SourceAddrs, req.SourceSubject, req.SourceName)
+
+	if len(req.SourceAddrs) == 0 || req.SourceSubject == "" || req.SourceName == "" {
+		return nil, status.Error(codes.InvalidArgument,
+			"Source addresses, subject, and name are required")
+	}
+	if err := validateStreamSubject(req.Subject, a.config.Clustering.Namespace); err != nil {
+		a.logger.Errorf("api: Failed to create mirror stream: %v", err)
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid stream subject: %v", err))
+	}
+
+	stream := &proto.Stream{
+		Subject:           req.Subject,
+		Name:              req.Name,
+		ReplicationFactor: req.ReplicationFactor,
+		Mirror: &proto.StreamMirror{
+			SourceAddrs:   req.SourceAddrs,
+			SourceSubject: req.SourceSubject,
+			SourceName:    req.SourceName,
+		},
+	}
+	if err := a.metadata.CreateStream(ctx, stream); err != nil {
+		if err.Code() != codes.AlreadyExists {
+			a.logger.Errorf("api: Failed to create mirror stream: %v", err.Err())
+		}
+		return nil, err.Err()
+	}
+
+	return &proto.CreateMirrorStreamResponse{}, nil
+}
+
+// GetCleanerStatus returns the progress of cleaning each stream log on the
+// server along with the number of logs waiting to be cleaned.
+func (a *adminServer) GetCleanerStatus(ctx context.Context, req *proto.GetCleanerStatusRequest) (
+	*proto.GetCleanerStatusResponse, error) {
+
+	a.logger.Debugf("api: GetCleanerStatus")
+
+	resp := &proto.GetCleanerStatusResponse{Backlog: int32(a.cleanerPool.Backlog())}
+	for _, stream := range a.metadata.GetStreams() {
+		cleanerStatus := stream.log.CleanerStatus()
+		streamStatus := &proto.StreamCleanerStatus{
+			Subject:           stream.Subject,
+			Name:              stream.Name,
+			Queued:            cleanerStatus.Queued,
+			Cleaning:          cleanerStatus.Cleaning,
+			LastCleanDuration: int64(cleanerStatus.LastDuration),
+			BytesProcessed:    cleanerStatus.BytesProcessed,
+		}
+		if !cleanerStatus.QueuedSince.IsZero() {
+			streamStatus.QueuedTimestamp = cleanerStatus.QueuedSince.UnixNano()
+		}
+		if !cleanerStatus.LastCleaned.IsZero() {
+			streamStatus.LastCleanedTimestamp = cleanerStatus.LastCleaned.UnixNano()
+		}
+		resp.Streams = append(resp.Streams, streamStatus)
+	}
+	sort.Slice(resp.Streams, func(i, j int) bool {
+		if resp.Streams[i].Subject != resp.Streams[j].Subject {
+			return resp.Streams[i].Subject < resp.Streams[j].Subject
+		}
+		return resp.Streams[i].Name < resp.Streams[j].Name
+	})
+
+	return resp, nil
+}
+
+// PurgeStream removes all messages before the given offset or timestamp from
+// a stream's log on all of its replicas while leaving the stream intact. It
+// returns a NotFound status code if the stream doesn't exist. A timestamp is
+// resolved to an offset by the stream leader, so a purge by timestamp returns
+// FailedPrecondition if this server isn't the stream leader.
+func (a *adminServer) PurgeStream(ctx context.Context, req *proto.PurgeStreamRequest) (
+	*proto.PurgeStreamResponse, error) {
+
+	a.logger.Debugf("api: PurgeStream [subject=%s, name=%s, offset=%d, timestamp=%d]",
+		req.Subject, req.Name, req.Offset, req.Timestamp)
+
+	if req.Offset < 0 || req.Timestamp < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Offset and timestamp must not be negative")
+	}
+
+	offset := req.Offset
+	if req.Timestamp != 0 {
+		var st *status.Status
+		offset, st = a.purgeOffsetForTimestamp(req.Subject, req.Name, req.Timestamp)
+		if st != nil {
+			return nil, st.Err()
+		}
+	}
+
+	op := &proto.PurgeStreamOp{
+		Subject: req.Subject,
+		Name:    req.Name,
+		Offset:  offset,
+	}
+	if err := a.metadata.PurgeStream(ctx, op); err != nil {
+		if err.Code() != codes.NotFound {
+			a.logger.Errorf("api: Failed to purge stream: %v", err.Err())
+		}
+		return nil, err.Err()
+	}
+
+	return &proto.PurgeStreamResponse{}, nil
+}
+
+// purgeOffsetForTimestamp resolves the timestamp of a purge to the offset of
+// the first message in the stream's log at or after it. This must be done on
+// the stream leader, whose log has every committed message, so that each
+// replica purges before the same offset.
+func (a *adminServer) purgeOffsetForTimestamp(subject, name string, timestamp int64) (
+	int64, *status.Status) {
+
+	stream := a.metadata.GetStream(subject, name)
+	if stream == nil {
+		return 0, status.New(codes.NotFound, fmt.Sprintf("No such stream [subject=%s, name=%s]",
+			subject, name))
+	}
+	if leader, _ := stream.GetLeader(); leader != a.config.Clustering.ServerID {
+		return 0, status.New(codes.FailedPrecondition, "Server not stream leader")
+	}
+	offset, err := stream.OffsetForTimestamp(timestamp)
+	if err != nil {
+		a.logger.Errorf("api: Failed to resolve purge timestamp for stream %s: %v", stream, err)
+		return 0, status.New(codes.Internal, err.Error())
+	}
+	return offset, nil
+}
+
+// FetchStreamOffsets returns the earliest offset, latest offset, and HW of each
+// of the given streams along with, if a timestamp is given, the offset for the
+// timestamp. Offsets are only returned for streams led by this server. For
+// other streams, the error code is set to NotFound if the stream doesn't exist
+// or FailedPrecondition if this server isn't the stream leader.
+func (a *adminServer) FetchStreamOffsets(ctx context.Context, req *proto.FetchStreamOffsetsRequest) (
+	*proto.FetchStreamOffsetsResponse, error) {
+
+	a.logger.Debugf("api: FetchStreamOffsets %s", req.Streams)
+
+	resp := &proto.FetchStreamOffsetsResponse{
+		Streams: make([]*proto.StreamOffsets, len(req.Streams)),
+	}
+	for i, streamReq := range req.Streams {
+		offsets := &proto.StreamOffsets{Subject: streamReq.Subject, Name: streamReq.Name}
+		if st := a.fetchStreamOffsets(streamReq, offsets); st != nil {
+			offsets.ErrorCode = uint32(st.Code())
+			offsets.Error = st.Message()
+		}
+		resp.Streams[i] = offsets
+	}
+
+	return resp, nil
+}
+
+// fetchStreamOffsets populates the offsets of the requested stream. It returns
+// a status if the offsets can't be fetched.
+func (a *adminServer) fetchStreamOffsets(req *proto.StreamOffsetsRequest,
+	offsets *proto.StreamOffsets) *status.Status {
+
+	stream := a.metadata.GetStream(req.Subject, req.Name)
+	if stream == nil {
+		return status.New(codes.NotFound, "No such stream")
+	}
+	if leader, _ := stream.GetLeader(); leader != a.config.Clustering.ServerID {
+		return status.New(codes.FailedPrecondition, "Server not stream leader")
+	}
+
+	offsets.EarliestOffset = stream.log.OldestOffset()
+	offsets.LatestOffset = stream.log.NewestOffset()
+	offsets.HighWatermark = stream.log.HighWatermark()
+	if req.Timestamp != 0 {
+		offset, err := stream.OffsetForTimestamp(req.Timestamp)
+		if err != nil {
+			a.logger.Errorf("api: Failed to fetch offsets for stream %s: %v", stream, err)
+			return status.New(codes.Internal, err.Error())
+		}
+		offsets.TimestampOffset = offset
+	}
+	return nil
+}
+
+// GetConsumerLag returns the lag of each named consumer subscribed to streams
+// on the server, optionally limited to a stream subject and name.
+func (a *adminServer) GetConsumerLag(ctx context.Context, req *proto.GetConsumerLagRequest) (
+	*proto.GetConsumerLagResponse, error) {
+
+	a.logger.Debugf("api: GetConsumerLag [subject=%s, name=%s]", req.Subject, req.Name)
+
+	streams := a.metadata.GetStreams()
+	sort.Slice(streams, func(i, j int) bool {
+		if streams[i].Subject != streams[j].Subject {
+			return streams[i].Subject < streams[j].Subject
+		}
+		return streams[i].Name < streams[j].Name
+	})
+
+	resp := &proto.GetConsumerLagResponse{}
+	for _, stream := range streams {
+		if (req.Subject != "" && req.Subject != stream.Subject) ||
+			(req.Name != "" && req.Name != stream.Name) {
+			continue
+		}
+		for _, lag := range stream.consumerLags() {
+			resp.Consumers = append(resp.Consumers, &proto.ConsumerLag{
+				Subject:             stream.Subject,
+				Name:                stream.Name,
+				Consumer:            lag.Consumer,
+				Subscriptions:       int32(lag.Subscriptions),
+				Offset:              lag.Offset,
+				HighWatermark:       lag.HighWatermark,
+				Lag:                 lag.Lag,
+				TimeLag:             int64(lag.TimeLag),
+				LastActiveTimestamp: lag.LastActive.UnixNano(),
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+// RemoveServer removes a server from the metadata Raft group and reassigns the
+// replicas of the streams it hosted to other servers. The response lists the
+// streams whose replicas couldn't be reassigned. It returns a
+// FailedPrecondition status code if the server being removed is the metadata
+// leader and a NotFound status code if the server isn't in the group.
+func (a *adminServer) RemoveServer(ctx context.Context, req *proto.RemoveServerRequest) (
+	*proto.RemoveServerResponse, error) {
+
+	a.logger.Debugf("api: RemoveServer [id=%s]", req.Id)
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "Server ID is required")
+	}
+
+	resp, err := a.metadata.RemoveServer(ctx, req)
+	if err != nil {
+		if err.Code() != codes.NotFound && err.Code() != codes.FailedPrecondition {
+			a.logger.Errorf("api: Failed to remove server: %v", err.Err())
+		}
+		return nil, err.Err()
+	}
+
+	return resp, nil
+}
+
+// AddNonVoter adds a server to the metadata Raft group as a non-voter.
+func (a *adminServer) AddNonVoter(ctx context.Context, req *proto.AddNonVoterRequest) (
+	*proto.AddNonVoterResponse, error) {
+
+	a.logger.Debugf("api: AddNonVoter [id=%s]", req.Id)
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "Server ID is required")
+	}
+
+	if err := a.metadata.AddNonVoter(ctx, req); err != nil {
+		a.logger.Errorf("api: Failed to add non-voter: %v", err.Err())
+		return nil, err.Err()
+	}
+
+	return &proto.AddNonVoterResponse{}, nil
+}
+
+// PromoteServer promotes a non-voter in the metadata Raft group to a voter. It
+// returns a NotFound status code if the server isn't in the group.
+func (a *adminServer) PromoteServer(ctx context.Context, req *proto.PromoteServerRequest) (
+	*proto.PromoteServerResponse, error) {
+
+	a.logger.Debugf("api: PromoteServer [id=%s]", req.Id)
+
+	if err := a.metadata.PromoteServer(ctx, req); err != nil {
+		if err.Code() != codes.NotFound {
+			a.logger.Errorf("api: Failed to promote server: %v", err.Err())
+		}
+		return nil, err.Err()
+	}
+
+	return &proto.PromoteServerResponse{}, nil
+}
+
+// ListPeers returns the servers in the metadata Raft group, whether each is a
+// voter or cordoned, and which is the metadata leader.
+func (a *adminServer) ListPeers(ctx context.Context, req *proto.ListPeersRequest) (
+	*proto.ListPeersResponse, error) {
+
+	a.logger.Debugf("api: ListPeers")
+
+	servers, leader, err := a.metadata.GetPeers()
+	if err != nil {
+		a.logger.Errorf("api: Failed to list peers: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &proto.ListPeersResponse{Peers: make([]*proto.Peer, len(servers))}
+	for i, server := range servers {
+		resp.Peers[i] = &proto.Peer{
+			Id:       string(server.ID),
+			Voter:    server.Suffrage == raft.Voter,
+			Leader:   string(server.Address) == leader,
+			Cordoned: a.metadata.IsCordoned(string(server.ID)),
+		}
+	}
+	sort.Slice(resp.Peers, func(i, j int) bool {
+		return resp.Peers[i].Id < resp.Peers[j].Id
+	})
+
+	return resp, nil
+}
+
+// CordonServer marks a server as cordoned so that it isn't selected for new
+// stream replicas or elected stream leader. It returns a NotFound status code
+// if the server isn't in the metadata Raft group.
+func (a *adminServer) CordonServer(ctx context.Context, req *proto.CordonServerRequest) (
+	*proto.CordonServerResponse, error) {
+
+	a.logger.Debugf("api: CordonServer [id=%s]", req.Id)
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "Server ID is required")
+	}
+
+	if err := a.metadata.CordonServer(ctx, &proto.CordonServerOp{Id: req.Id, Cordoned: true}); err != nil {
+		if err.Code() != codes.NotFound {
+			a.logger.Errorf("api: Failed to cordon server: %v", err.Err())
+		}
+		return nil, err.Err()
+	}
+
+	return &proto.CordonServerResponse{}, nil
+}
+
+// UncordonServer removes the cordon from a server so that it's considered for
+// new stream replicas and stream leader elections again.
+func (a *adminServer) UncordonServer(ctx context.Context, req *proto.UncordonServerRequest) (
+	*proto.UncordonServerResponse, error) {
+
+	a.logger.Debugf("api: UncordonServer [id=%s]", req.Id)
+
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "Server ID is required")
+	}
+
+	if err := a.metadata.CordonServer(ctx, &proto.CordonServerOp{Id: req.Id}); err != nil {
+		a.logger.Errorf("api: Failed to uncordon server: %v", err.Err())
+		return nil, err.Err()
+	}
+
+	return &proto.UncordonServerResponse{}, nil
+}
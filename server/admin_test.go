@@ -0,0 +1,797 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	lift "github.com/liftbridge-io/go-liftbridge"
+	client "github.com/liftbridge-io/go-liftbridge/liftbridge-grpc"
+	natsdTest "github.com/nats-io/nats-server/v2/test"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/liftbridge-io/liftbridge/server/proto"
+)
+
+func getCleanerStatus(t *testing.T, addr string) *proto.GetCleanerStatusResponse {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	resp, err := proto.NewAdminAPIClient(conn).GetCleanerStatus(
+		context.Background(), &proto.GetCleanerStatusRequest{})
+	require.NoError(t, err)
+	return resp
+}
+
+func purgeStream(t *testing.T, addr string, req *proto.PurgeStreamRequest) error {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = proto.NewAdminAPIClient(conn).PurgeStream(context.Background(), req)
+	return err
+}
+
+// waitForOldestOffset waits until the given stream's log starts at the given
+// offset on all of the servers.
+func waitForOldestOffset(t *testing.T, timeout time.Duration, subject, name string,
+	offset int64, servers ...*Server) {
+
+	deadline := time.Now().Add(timeout)
+LOOP:
+	for time.Now().Before(deadline) {
+		for _, s := range servers {
+			stream := s.metadata.GetStream(subject, name)
+			if stream == nil || stream.log.OldestOffset() != offset {
+				time.Sleep(15 * time.Millisecond)
+				continue LOOP
+			}
+		}
+		return
+	}
+	stackFatalf(t, "Cluster did not reach oldest offset %d", offset)
+}
+
+// Ensure stream logs are cleaned by the server's cleaner pool and
+// GetCleanerStatus reports the progress of cleaning each of them.
+func TestGetCleanerStatus(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1Config.Log.SegmentMaxBytes = 1
+	s1Config.Log.Compact = true
+	s1Config.Log.CleanerInterval = 50 * time.Millisecond
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	// Wait for server to elect itself leader.
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	client, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Create streams and publish some messages with the same key.
+	for _, name := range []string{"foo", "bar"} {
+		err = client.CreateStream(context.Background(), name, name)
+		require.NoError(t, err)
+		for i := 0; i < 5; i++ {
+			_, err = client.Publish(context.Background(), name, []byte("hello"),
+				lift.Key([]byte("key")))
+			require.NoError(t, err)
+		}
+	}
+
+	// Wait for both logs to be compacted. Bytes processed are reported while
+	// cleaning is in progress, so also wait for it to finish.
+	cleaned := func(status *proto.StreamCleanerStatus) bool {
+		return status.BytesProcessed > 0 && status.LastCleanedTimestamp > 0
+	}
+	var resp *proto.GetCleanerStatusResponse
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		resp = getCleanerStatus(t, "localhost:5050")
+		if len(resp.Streams) == 2 && cleaned(resp.Streams[0]) && cleaned(resp.Streams[1]) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Stream logs were not cleaned: %+v", resp)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	require.Equal(t, "bar", resp.Streams[0].Subject)
+	require.Equal(t, "foo", resp.Streams[1].Subject)
+	require.True(t, resp.Backlog >= 0 && resp.Backlog <= 2)
+	require.Equal(t, int64(4), s1.metadata.GetStream("foo", "foo").log.OldestOffset())
+}
+
+// Ensure PurgeStream removes messages before an offset or timestamp from the
+// stream's log on all replicas while leaving the stream intact.
+func TestPurgeStream(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure servers.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+	s2Config := getTestConfig("b", false, 5051)
+	s2 := runServerWithConfig(t, s2Config)
+	defer s2.Stop()
+
+	servers := []*Server{s1, s2}
+	leader := getMetadataLeader(t, 10*time.Second, servers...)
+	addr := fmt.Sprintf("localhost:%d", leader.config.Port)
+
+	client, err := lift.Connect([]string{addr})
+	require.NoError(t, err)
+	defer client.Close()
+
+	name := "foo"
+	subject := "foo"
+	err = client.CreateStream(context.Background(), subject, name,
+		lift.ReplicationFactor(2))
+	require.NoError(t, err)
+
+	publish := func(num int) {
+		for i := 0; i < num; i++ {
+			_, err := client.Publish(context.Background(), subject, []byte("hello"),
+				lift.AckPolicyAll())
+			require.NoError(t, err)
+		}
+	}
+	publish(10)
+	waitForHW(t, 5*time.Second, subject, name, 9, servers...)
+
+	// Purging a stream which doesn't exist fails.
+	err = purgeStream(t, addr, &proto.PurgeStreamRequest{
+		Subject: "bar",
+		Name:    "bar",
+		Offset:  5,
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Purge by offset.
+	err = purgeStream(t, addr, &proto.PurgeStreamRequest{
+		Subject: subject,
+		Name:    name,
+		Offset:  4,
+	})
+	require.NoError(t, err)
+	waitForOldestOffset(t, 5*time.Second, subject, name, 4, servers...)
+	for _, s := range servers {
+		require.Equal(t, int64(4), s.metadata.GetStream(subject, name).GetStartOffset())
+	}
+
+	// Purging by timestamp must be done on the stream leader.
+	streamLeader := getStreamLeader(t, 10*time.Second, subject, name, servers...)
+	follower := s1
+	if streamLeader == s1 {
+		follower = s2
+	}
+	err = purgeStream(t, fmt.Sprintf("localhost:%d", follower.config.Port), &proto.PurgeStreamRequest{
+		Subject:   subject,
+		Name:      name,
+		Timestamp: time.Now().UnixNano(),
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Purge by timestamp, which removes all messages.
+	err = purgeStream(t, fmt.Sprintf("localhost:%d", streamLeader.config.Port), &proto.PurgeStreamRequest{
+		Subject:   subject,
+		Name:      name,
+		Timestamp: time.Now().UnixNano(),
+	})
+	require.NoError(t, err)
+	waitForOldestOffset(t, 5*time.Second, subject, name, -1, servers...)
+
+	// The stream continues where it left off.
+	publish(1)
+	waitForHW(t, 5*time.Second, subject, name, 10, servers...)
+	waitForOldestOffset(t, 5*time.Second, subject, name, 10, servers...)
+	msgs := subscribeFromOffset(t, client, subject, name, 0, 1)
+	require.Equal(t, int64(10), msgs[0].Offset)
+}
+
+func fetchStreamOffsets(t *testing.T, addr string, streams ...*proto.StreamOffsetsRequest) []*proto.StreamOffsets {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	resp, err := proto.NewAdminAPIClient(conn).FetchStreamOffsets(
+		context.Background(), &proto.FetchStreamOffsetsRequest{Streams: streams})
+	require.NoError(t, err)
+	return resp.Streams
+}
+
+// Ensure FetchStreamOffsets returns the earliest offset, latest offset, HW,
+// and offset for a timestamp of each requested stream.
+func TestFetchStreamOffsets(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	// Wait for server to elect itself leader.
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	client, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Create streams.
+	require.NoError(t, client.CreateStream(context.Background(), "foo", "foo"))
+	require.NoError(t, client.CreateStream(context.Background(), "bar", "bar"))
+
+	// Publish some messages to one of the streams, noting the time partway
+	// through.
+	var timestamp int64
+	for i := 0; i < 5; i++ {
+		if i == 3 {
+			time.Sleep(time.Millisecond)
+			timestamp = time.Now().UnixNano()
+			time.Sleep(time.Millisecond)
+		}
+		_, err = client.Publish(context.Background(), "foo", []byte("hello"))
+		require.NoError(t, err)
+	}
+	waitForHW(t, 5*time.Second, "foo", "foo", 4, s1)
+
+	offsets := fetchStreamOffsets(t, "localhost:5050",
+		&proto.StreamOffsetsRequest{Subject: "foo", Name: "foo", Timestamp: timestamp},
+		&proto.StreamOffsetsRequest{Subject: "bar", Name: "bar", Timestamp: timestamp},
+		&proto.StreamOffsetsRequest{Subject: "baz", Name: "baz"},
+	)
+	require.Len(t, offsets, 3)
+
+	require.Equal(t, &proto.StreamOffsets{
+		Subject:         "foo",
+		Name:            "foo",
+		EarliestOffset:  0,
+		LatestOffset:    4,
+		HighWatermark:   4,
+		TimestampOffset: 3,
+	}, offsets[0])
+
+	// The empty stream has no messages.
+	require.Equal(t, &proto.StreamOffsets{
+		Subject:         "bar",
+		Name:            "bar",
+		EarliestOffset:  -1,
+		LatestOffset:    -1,
+		HighWatermark:   -1,
+		TimestampOffset: 0,
+	}, offsets[1])
+
+	// The stream which doesn't exist has an error.
+	require.Equal(t, "baz", offsets[2].Subject)
+	require.Equal(t, uint32(codes.NotFound), offsets[2].ErrorCode)
+	require.NotEmpty(t, offsets[2].Error)
+}
+
+func getConsumerLag(t *testing.T, addr string, req *proto.GetConsumerLagRequest) []*proto.ConsumerLag {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	resp, err := proto.NewAdminAPIClient(conn).GetConsumerLag(context.Background(), req)
+	require.NoError(t, err)
+	return resp.Consumers
+}
+
+// waitForConsumerLag waits until the named consumer of the stream has the
+// given lag and returns it.
+func waitForConsumerLag(t *testing.T, timeout time.Duration, addr, subject, name,
+	consumer string, lag int64) *proto.ConsumerLag {
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		req := &proto.GetConsumerLagRequest{Subject: subject, Name: name}
+		for _, consumerLag := range getConsumerLag(t, addr, req) {
+			if consumerLag.Consumer == consumer && consumerLag.Lag == lag {
+				return consumerLag
+			}
+		}
+		time.Sleep(15 * time.Millisecond)
+	}
+	stackFatalf(t, "Consumer %s did not reach lag %d", consumer, lag)
+	return nil
+}
+
+// waitForConsumers waits until the named consumer of the stream has the given
+// number of subscriptions.
+func waitForConsumers(t *testing.T, timeout time.Duration, s *Server, subject, name,
+	consumer string, subscriptions int) {
+
+	stream := s.metadata.GetStream(subject, name)
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		stream.consumersMu.Lock()
+		c, ok := stream.consumers[consumer]
+		done := ok && c.subscriptions == subscriptions
+		stream.consumersMu.Unlock()
+		if done {
+			return
+		}
+		time.Sleep(15 * time.Millisecond)
+	}
+	stackFatalf(t, "Consumer %s did not reach %d subscriptions", consumer, subscriptions)
+}
+
+// Ensure the server tracks the progress of named consumers and reports their
+// lag behind the HW through GetConsumerLag and the metrics endpoint.
+func TestGetConsumerLag(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1Config.Metrics.Listen = "localhost:5060"
+	s1Config.Metrics.ConsumerLagInterval = 10 * time.Millisecond
+	s1Config.Metrics.ConsumerLagWarnMessages = 5
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	// Wait for server to elect itself leader.
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	lc, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer lc.Close()
+
+	subject := "foo"
+	name := "foo"
+	require.NoError(t, lc.CreateStream(context.Background(), subject, name))
+
+	publish := func(num int) {
+		for i := 0; i < num; i++ {
+			_, err := lc.Publish(context.Background(), subject, []byte("hello"))
+			require.NoError(t, err)
+		}
+	}
+	subscribe := func(consumer string) context.CancelFunc {
+		ctx, cancel := context.WithCancel(metadata.AppendToOutgoingContext(
+			context.Background(), consumerMetadataKey, consumer))
+		err := lc.Subscribe(ctx, subject, name, func(*client.Message, error) {},
+			lift.StartAtEarliestReceived())
+		require.NoError(t, err)
+		return cancel
+	}
+
+	publish(5)
+	waitForHW(t, 5*time.Second, subject, name, 4, s1)
+
+	// Both consumers read all the messages.
+	cancel1 := subscribe("consumer1")
+	defer cancel1()
+	cancel2 := subscribe("consumer2")
+	waitForConsumerLag(t, 5*time.Second, "localhost:5050", subject, name, "consumer1", 0)
+	waitForConsumerLag(t, 5*time.Second, "localhost:5050", subject, name, "consumer2", 0)
+
+	// The second consumer stops while more messages are published.
+	cancel2()
+	waitForConsumers(t, 5*time.Second, s1, subject, name, "consumer2", 0)
+	publish(5)
+	waitForHW(t, 5*time.Second, subject, name, 9, s1)
+
+	waitForConsumerLag(t, 5*time.Second, "localhost:5050", subject, name, "consumer1", 0)
+	consumerLag := waitForConsumerLag(t, 5*time.Second, "localhost:5050", subject, name, "consumer2", 5)
+	require.Equal(t, int32(0), consumerLag.Subscriptions)
+	require.Equal(t, int64(4), consumerLag.Offset)
+	require.Equal(t, int64(9), consumerLag.HighWatermark)
+	require.True(t, consumerLag.TimeLag > 0)
+
+	// Consumers of other streams aren't included.
+	require.Empty(t, getConsumerLag(t, "localhost:5050",
+		&proto.GetConsumerLagRequest{Subject: "bar"}))
+
+	// The lag is recorded in the metrics and the lagging consumer is flagged.
+	key := "liftbridge.consumer.lag.messages;subject=foo;name=foo;consumer=consumer2"
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data := s1.metricsSink.Data()
+		if gauge, ok := data[len(data)-1].Gauges[key]; ok && gauge.Value == 5 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Consumer lag metric was not recorded")
+		}
+		time.Sleep(15 * time.Millisecond)
+	}
+	stream := s1.metadata.GetStream(subject, name)
+	stream.consumersMu.Lock()
+	require.True(t, stream.consumers["consumer2"].lagging)
+	require.False(t, stream.consumers["consumer1"].lagging)
+	stream.consumersMu.Unlock()
+
+	// Metrics are served as JSON.
+	resp, err := http.Get("http://localhost:5060/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var summary struct{ Gauges []struct{ Name string } }
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&summary))
+	require.NotEmpty(t, summary.Gauges)
+}
+
+// Ensure a named consumer which has had no subscriptions for the consumer TTL
+// is no longer tracked while one with a subscription is.
+func TestConsumerEvictedAfterTTL(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1Config.Metrics.ConsumerLagInterval = 10 * time.Millisecond
+	s1Config.Metrics.ConsumerTTL = 200 * time.Millisecond
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	// Wait for server to elect itself leader.
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	lc, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer lc.Close()
+
+	subject := "foo"
+	name := "foo"
+	require.NoError(t, lc.CreateStream(context.Background(), subject, name))
+
+	subscribe := func(consumer string) context.CancelFunc {
+		ctx, cancel := context.WithCancel(metadata.AppendToOutgoingContext(
+			context.Background(), consumerMetadataKey, consumer))
+		err := lc.Subscribe(ctx, subject, name, func(*client.Message, error) {},
+			lift.StartAtEarliestReceived())
+		require.NoError(t, err)
+		return cancel
+	}
+
+	cancel1 := subscribe("consumer1")
+	defer cancel1()
+	cancel2 := subscribe("consumer2")
+	waitForConsumers(t, 5*time.Second, s1, subject, name, "consumer1", 1)
+	waitForConsumers(t, 5*time.Second, s1, subject, name, "consumer2", 1)
+
+	// The consumer without subscriptions is evicted once the TTL passes.
+	cancel2()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		lags := getConsumerLag(t, "localhost:5050",
+			&proto.GetConsumerLagRequest{Subject: subject, Name: name})
+		if len(lags) == 1 {
+			require.Equal(t, "consumer1", lags[0].Consumer)
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Idle consumer was not evicted")
+		}
+		time.Sleep(15 * time.Millisecond)
+	}
+}
+
+func listPeers(t *testing.T, addr string) []*proto.Peer {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	resp, err := proto.NewAdminAPIClient(conn).ListPeers(
+		context.Background(), &proto.ListPeersRequest{})
+	require.NoError(t, err)
+	return resp.Peers
+}
+
+// waitForReplicas waits until the given stream's replicas are the given
+// brokers on all of the servers.
+func waitForReplicas(t *testing.T, timeout time.Duration, subject, name string,
+	replicas []string, servers ...*Server) {
+
+	expected := make([]string, len(replicas))
+	copy(expected, replicas)
+	sort.Strings(expected)
+	deadline := time.Now().Add(timeout)
+LOOP:
+	for time.Now().Before(deadline) {
+		for _, s := range servers {
+			stream := s.metadata.GetStream(subject, name)
+			if stream == nil {
+				time.Sleep(15 * time.Millisecond)
+				continue LOOP
+			}
+			actual := stream.GetReplicas()
+			sort.Strings(actual)
+			if !reflect.DeepEqual(expected, actual) {
+				time.Sleep(15 * time.Millisecond)
+				continue LOOP
+			}
+		}
+		return
+	}
+	stackFatalf(t, "Cluster did not reach replicas %v", replicas)
+}
+
+// Ensure RemoveServer removes a server from the metadata Raft group and
+// reassigns the replicas of the streams it hosted, AddNonVoter adds a server
+// as a non-voter, PromoteServer makes it a voter, and ListPeers reports the
+// group's servers. The membership requests are sent to a server which isn't
+// the metadata leader, which forwards them to the leader.
+func TestRaftMembership(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure servers.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+	s2Config := getTestConfig("b", false, 5051)
+	s2 := runServerWithConfig(t, s2Config)
+	defer s2.Stop()
+	s3Config := getTestConfig("c", false, 5052)
+	s3 := runServerWithConfig(t, s3Config)
+	defer s3.Stop()
+
+	servers := []*Server{s1, s2, s3}
+	leader := getMetadataLeader(t, 10*time.Second, servers...)
+	addr := fmt.Sprintf("localhost:%d", leader.config.Port)
+
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	admin := proto.NewAdminAPIClient(conn)
+
+	peers := listPeers(t, addr)
+	require.Len(t, peers, 3)
+	for i, id := range []string{"a", "b", "c"} {
+		require.Equal(t, id, peers[i].Id)
+		require.True(t, peers[i].Voter)
+		require.Equal(t, id == leader.config.Clustering.ServerID, peers[i].Leader)
+	}
+
+	client, err := lift.Connect([]string{addr})
+	require.NoError(t, err)
+	defer client.Close()
+
+	name := "foo"
+	subject := "foo"
+	err = client.CreateStream(context.Background(), subject, name,
+		lift.ReplicationFactor(2))
+	require.NoError(t, err)
+	waitForStream(t, 5*time.Second, subject, name, servers...)
+
+	// Find a replica which isn't the metadata leader and the server which
+	// isn't a replica.
+	var removed, spare *Server
+	replicas := leader.metadata.GetStream(subject, name).GetReplicas()
+	for _, s := range servers {
+		id := s.config.Clustering.ServerID
+		if id != replicas[0] && id != replicas[1] {
+			spare = s
+		} else if s != leader {
+			removed = s
+		}
+	}
+	require.NotNil(t, removed)
+	require.NotNil(t, spare)
+
+	// Send membership requests through the server which is neither the
+	// metadata leader nor the one to remove.
+	var forwarder *Server
+	for _, s := range servers {
+		if s != leader && s != removed {
+			forwarder = s
+		}
+	}
+	forwarderConn, err := grpc.Dial(fmt.Sprintf("localhost:%d", forwarder.config.Port), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer forwarderConn.Close()
+	forwarderAdmin := proto.NewAdminAPIClient(forwarderConn)
+
+	// Create a stream whose only replica is the server to remove by
+	// cordoning the others. Its replica cannot be reassigned since it has no
+	// other ISR member.
+	for _, s := range servers {
+		if s != removed {
+			_, err = admin.CordonServer(context.Background(),
+				&proto.CordonServerRequest{Id: s.config.Clustering.ServerID})
+			require.NoError(t, err)
+		}
+	}
+	err = client.CreateStream(context.Background(), "bar", "bar")
+	require.NoError(t, err)
+	waitForStream(t, 5*time.Second, "bar", "bar", servers...)
+	for _, s := range servers {
+		if s != removed {
+			_, err = admin.UncordonServer(context.Background(),
+				&proto.UncordonServerRequest{Id: s.config.Clustering.ServerID})
+			require.NoError(t, err)
+		}
+	}
+
+	// The metadata leader cannot remove itself.
+	_, err = forwarderAdmin.RemoveServer(context.Background(),
+		&proto.RemoveServerRequest{Id: leader.config.Clustering.ServerID})
+	require.Error(t, err)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Removing a server which isn't in the group fails.
+	_, err = forwarderAdmin.RemoveServer(context.Background(), &proto.RemoveServerRequest{Id: "z"})
+	require.Error(t, err)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Stop and remove the replica.
+	removed.Stop()
+	resp, err := forwarderAdmin.RemoveServer(context.Background(),
+		&proto.RemoveServerRequest{Id: removed.config.Clustering.ServerID})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), resp.ReassignedStreams)
+	require.Len(t, resp.FailedStreams, 1)
+	require.Equal(t, "bar", resp.FailedStreams[0].Subject)
+	require.Equal(t, "bar", resp.FailedStreams[0].Name)
+	require.NotEmpty(t, resp.FailedStreams[0].Error)
+	require.Len(t, listPeers(t, addr), 2)
+
+	// The spare server replaces the removed one and the stream continues.
+	expected := []string{spare.config.Clustering.ServerID}
+	remaining := []*Server{spare}
+	for _, s := range servers {
+		id := s.config.Clustering.ServerID
+		if s != removed && s != spare {
+			expected = append(expected, id)
+			remaining = append(remaining, s)
+		}
+	}
+	waitForReplicas(t, 5*time.Second, subject, name, expected, remaining...)
+	_, err = client.Publish(context.Background(), subject, []byte("hello"), lift.AckPolicyAll())
+	require.NoError(t, err)
+	waitForHW(t, 5*time.Second, subject, name, 0, remaining...)
+
+	// Add a server as a non-voter and then promote it.
+	_, err = forwarderAdmin.AddNonVoter(context.Background(), &proto.AddNonVoterRequest{Id: "d"})
+	require.NoError(t, err)
+	peers = listPeers(t, addr)
+	require.Len(t, peers, 3)
+	require.Equal(t, "d", peers[2].Id)
+	require.False(t, peers[2].Voter)
+
+	_, err = forwarderAdmin.PromoteServer(context.Background(), &proto.PromoteServerRequest{Id: "d"})
+	require.NoError(t, err)
+	peers = listPeers(t, addr)
+	require.Len(t, peers, 3)
+	require.True(t, peers[2].Voter)
+
+	_, err = forwarderAdmin.PromoteServer(context.Background(), &proto.PromoteServerRequest{Id: "z"})
+	require.Error(t, err)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// Ensure CordonServer excludes a server from new stream replicas and stream
+// leader elections, ListPeers and FetchMetadata report the cordon, and
+// UncordonServer removes it.
+func TestCordonServer(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure servers.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+	s2Config := getTestConfig("b", false, 5051)
+	s2 := runServerWithConfig(t, s2Config)
+	defer s2.Stop()
+	s3Config := getTestConfig("c", false, 5052)
+	s3 := runServerWithConfig(t, s3Config)
+	defer s3.Stop()
+
+	servers := []*Server{s1, s2, s3}
+	leader := getMetadataLeader(t, 10*time.Second, servers...)
+	addr := fmt.Sprintf("localhost:%d", leader.config.Port)
+
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	admin := proto.NewAdminAPIClient(conn)
+
+	liftClient, err := lift.Connect([]string{addr})
+	require.NoError(t, err)
+	defer liftClient.Close()
+
+	// Create a stream on every server before cordoning one.
+	err = liftClient.CreateStream(context.Background(), "foo", "foo", lift.MaxReplication())
+	require.NoError(t, err)
+	waitForStream(t, 5*time.Second, "foo", "foo", servers...)
+
+	// Cordon a server other than the metadata leader.
+	var cordoned string
+	for _, s := range servers {
+		if s != leader {
+			cordoned = s.config.Clustering.ServerID
+			break
+		}
+	}
+	_, err = admin.CordonServer(context.Background(), &proto.CordonServerRequest{Id: cordoned})
+	require.NoError(t, err)
+
+	_, err = admin.CordonServer(context.Background(), &proto.CordonServerRequest{Id: "z"})
+	require.Error(t, err)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	for _, peer := range listPeers(t, addr) {
+		require.Equal(t, peer.Id == cordoned, peer.Cordoned)
+	}
+
+	var header metadata.MD
+	_, err = client.NewAPIClient(conn).FetchMetadata(context.Background(),
+		&client.FetchMetadataRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+	require.Equal(t, []string{cordoned}, header.Get(cordonedBrokersMetadataKey))
+
+	// New streams aren't placed on the cordoned server.
+	err = liftClient.CreateStream(context.Background(), "bar", "bar", lift.MaxReplication())
+	require.NoError(t, err)
+	replicas := leader.metadata.GetStream("bar", "bar").GetReplicas()
+	require.Len(t, replicas, 2)
+	require.NotContains(t, replicas, cordoned)
+
+	err = liftClient.CreateStream(context.Background(), "baz", "baz", lift.ReplicationFactor(3))
+	require.Error(t, err)
+
+	// The cordoned server isn't elected stream leader.
+	stream := leader.metadata.GetStream("foo", "foo")
+	for i := 0; i < 5; i++ {
+		require.Nil(t, leader.metadata.electNewStreamLeader(stream))
+		streamLeader, _ := stream.GetLeader()
+		require.NotEqual(t, cordoned, streamLeader)
+	}
+
+	// Uncordon the server.
+	_, err = admin.UncordonServer(context.Background(), &proto.UncordonServerRequest{Id: cordoned})
+	require.NoError(t, err)
+	for _, peer := range listPeers(t, addr) {
+		require.False(t, peer.Cordoned)
+	}
+	header = nil
+	_, err = client.NewAPIClient(conn).FetchMetadata(context.Background(),
+		&client.FetchMetadataRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+	require.Empty(t, header.Get(cordonedBrokersMetadataKey))
+
+	err = liftClient.CreateStream(context.Background(), "baz", "baz", lift.ReplicationFactor(3))
+	require.NoError(t, err)
+
+	// Wait for every server to create the stream so that none creates it
+	// while shutting down, after its streams have been closed.
+	waitForStream(t, 5*time.Second, "baz", "baz", servers...)
+}
@@ -1,25 +1,92 @@
 package server
 
 import (
+	"container/heap"
 	"fmt"
+	"strconv"
 	"time"
 
 	client "github.com/liftbridge-io/go-liftbridge/liftbridge-grpc"
+	natsd "github.com/nats-io/nats-server/v2/server"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nuid"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+
+	"github.com/liftbridge-io/liftbridge/server/commitlog"
+	"github.com/liftbridge-io/liftbridge/server/logger"
+	"github.com/liftbridge-io/liftbridge/server/proto"
+	"github.com/liftbridge-io/liftbridge/server/tracing"
 )
 
 const raftApplyTimeout = 30 * time.Second
 
+// subjectFilterMetadataKey is the gRPC metadata key used to narrow a
+// subscription on a wildcard stream to messages whose subject matches a
+// pattern.
+const subjectFilterMetadataKey = "subject-filter"
+
+// offsetOutOfRangeMetadataKey is the gRPC metadata key used to select how a
+// subscription starting at an offset outside of the stream's log is handled.
+const offsetOutOfRangeMetadataKey = "offset-out-of-range"
+
+// startOffsetMetadataKey is the gRPC header metadata key on which the offset a
+// subscription actually starts at is returned.
+const startOffsetMetadataKey = "start-offset"
+
+// cordonedBrokersMetadataKey is the gRPC header metadata key on which the IDs
+// of cordoned brokers are returned by FetchMetadata.
+const cordonedBrokersMetadataKey = "cordoned-brokers"
+
+// flushMessagesMetadataKey and flushIntervalMetadataKey are the gRPC metadata
+// keys used to override the server's flush policy for a stream when it's
+// created. The former is a number of messages and the latter a duration.
+const (
+	flushMessagesMetadataKey = "flush-messages"
+	flushIntervalMetadataKey = "flush-interval"
+)
+
+// streamNameMetadataKey and streamSubjectMetadataKey are the gRPC metadata
+// keys used to publish a message directly to a stream rather than to its NATS
+// subject. The stream subject defaults to the message subject, so it only needs
+// to be set for streams attached to wildcard subjects.
+const (
+	streamNameMetadataKey    = "stream-name"
+	streamSubjectMetadataKey = "stream-subject"
+)
+
+// uncleanLeaderElectionTimeoutMetadataKey is the gRPC metadata key used to
+// allow unclean leader elections for a stream when it's created. It's the
+// duration the stream can go without ISR candidates for leader before an
+// out-of-sync replica is elected.
+const uncleanLeaderElectionTimeoutMetadataKey = "unclean-leader-election-timeout"
+
+// ackTimeoutMetadataKey is the gRPC metadata key used to set how long each
+// message published on a PublishAsync stream waits for its ack.
+const ackTimeoutMetadataKey = "ack-timeout"
+
+// Policies for handling a subscription start offset which is below the oldest
+// offset in the log or past the offset following the HW.
+const (
+	offsetOutOfRangeError    = "error"
+	offsetOutOfRangeEarliest = "earliest"
+	offsetOutOfRangeLatest   = "latest"
+)
+
 // apiServer implements the gRPC server interface clients interact with.
 type apiServer struct {
 	*Server
 }
 
-// CreateStream creates a new stream attached to a NATS subject. It returns an
+// CreateStream creates a new stream attached to a NATS subject. The subject
+// may contain wildcards, in which case the stream captures messages published
+// to every matching subject. The server's policy for flushing the stream's log
+// to disk can be overridden with the flush-messages and flush-interval
+// metadata keys. Unclean leader elections are allowed for the stream if the
+// unclean-leader-election-timeout metadata key is set. It returns an
 // AlreadyExists status code if a stream with the given subject and name
 // already exists.
 func (a *apiServer) CreateStream(ctx context.Context, req *client.CreateStreamRequest) (
@@ -32,7 +99,33 @@ func (a *apiServer) CreateStream(ctx context.Context, req *client.CreateStreamRe
 	a.logger.Debugf("api: CreateStream [subject=%s, name=%s, replicationFactor=%d]",
 		req.Subject, req.Name, req.ReplicationFactor)
 
-	if err := a.metadata.CreateStream(ctx, req); err != nil {
+	if err := validateStreamSubject(req.Subject, a.config.Clustering.Namespace); err != nil {
+		a.logger.Errorf("api: Failed to create stream: %v", err)
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid stream subject: %v", err))
+	}
+
+	flushPolicy, err := createStreamFlushPolicy(ctx)
+	if err != nil {
+		a.logger.Errorf("api: Failed to create stream: %v", err)
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid flush policy: %v", err))
+	}
+
+	uncleanTimeout, err := createStreamUncleanLeaderElectionTimeout(ctx)
+	if err != nil {
+		a.logger.Errorf("api: Failed to create stream: %v", err)
+		return nil, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("Invalid unclean leader election timeout: %v", err))
+	}
+
+	stream := &proto.Stream{
+		Subject:                      req.Subject,
+		Name:                         req.Name,
+		Group:                        req.Group,
+		ReplicationFactor:            req.ReplicationFactor,
+		FlushPolicy:                  flushPolicy,
+		UncleanLeaderElectionTimeout: int64(uncleanTimeout),
+	}
+	if err := a.metadata.CreateStream(ctx, stream); err != nil {
 		if err.Code() != codes.AlreadyExists {
 			a.logger.Errorf("api: Failed to create stream: %v", err.Err())
 		}
@@ -45,29 +138,35 @@ func (a *apiServer) CreateStream(ctx context.Context, req *client.CreateStreamRe
 // Subscribe creates an ephemeral subscription for the given stream. It begins
 // to receive messages starting at the given offset and waits for new messages
 // when it reaches the end of the stream. Use the request context to close the
-// subscription.
+// subscription. A subject pattern may be set on the subject-filter metadata key
+// to only receive messages whose subject matches it, which is useful for
+// streams attached to wildcard subjects. If the start offset is out of range,
+// e.g. because retention removed the messages, the offset-out-of-range
+// metadata key selects whether to fail with an OutOfRange status code or reset
+// to the earliest or latest offset. The offset the subscription starts at is
+// returned on the start-offset header so that consumers can detect skipped
+// messages.
 func (a *apiServer) Subscribe(req *client.SubscribeRequest, out client.API_SubscribeServer) error {
 	a.logger.Debugf("api: Subscribe [subject=%s, name=%s, start=%s, offset=%d, timestamp=%d]",
 		req.Subject, req.Name, req.StartPosition, req.StartOffset, req.StartTimestamp)
-	stream := a.metadata.GetStream(req.Subject, req.Name)
-	if stream == nil {
-		a.logger.Errorf("api: Failed to subscribe to stream [subject=%s, name=%s]: no such stream",
-			req.Subject, req.Name)
-		return status.Error(codes.NotFound, "No such stream")
-	}
-
-	leader, _ := stream.GetLeader()
-	if leader != a.config.Clustering.ServerID {
-		a.logger.Errorf("api: Failed to subscribe to stream %s: server not stream leader", stream)
-		return status.Error(codes.FailedPrecondition, "Server not stream leader")
+	stream, err := a.getSubscribeStream(req)
+	if err != nil {
+		return err
 	}
 
 	cancel := make(chan struct{})
 	defer close(cancel)
-	ch, errCh, err := a.subscribe(out.Context(), stream, req, cancel)
-	if err != nil {
-		a.logger.Errorf("api: Failed to subscribe to stream %s: %v", stream, err.Err())
-		return err.Err()
+	ch, errCh, startOffset, st := a.subscribe(out.Context(), stream, req, cancel)
+	if st != nil {
+		if st.Code() != codes.OutOfRange {
+			a.logger.Errorf("api: Failed to subscribe to stream %s: %v", stream, st.Err())
+		}
+		return st.Err()
+	}
+
+	header := metadata.Pairs(startOffsetMetadataKey, strconv.FormatInt(startOffset, 10))
+	if err := out.SendHeader(header); err != nil {
+		return err
 	}
 
 	// Send an empty message which signals the subscription was successfully
@@ -90,6 +189,25 @@ func (a *apiServer) Subscribe(req *client.SubscribeRequest, out client.API_Subsc
 	}
 }
 
+// getSubscribeStream returns the stream a subscription is for. It returns a
+// NotFound status if the stream doesn't exist and a FailedPrecondition status
+// if this server isn't its leader.
+func (a *apiServer) getSubscribeStream(req *client.SubscribeRequest) (*stream, error) {
+	stream := a.metadata.GetStream(req.Subject, req.Name)
+	if stream == nil {
+		a.logger.Errorf("api: Failed to subscribe to stream [subject=%s, name=%s]: no such stream",
+			req.Subject, req.Name)
+		return nil, status.Error(codes.NotFound, "No such stream")
+	}
+
+	leader, _ := stream.GetLeader()
+	if leader != a.config.Clustering.ServerID {
+		a.logger.Errorf("api: Failed to subscribe to stream %s: server not stream leader", stream)
+		return nil, status.Error(codes.FailedPrecondition, "Server not stream leader")
+	}
+	return stream, nil
+}
+
 // FetchMetadata retrieves the latest cluster metadata, including stream broker
 // information.
 func (a *apiServer) FetchMetadata(ctx context.Context, req *client.FetchMetadataRequest) (
@@ -102,13 +220,27 @@ func (a *apiServer) FetchMetadata(ctx context.Context, req *client.FetchMetadata
 		return nil, err.Err()
 	}
 
+	// Report cordoned brokers in the header since the response has no field
+	// for them.
+	if cordoned := a.metadata.GetCordoned(); len(cordoned) > 0 {
+		header := metadata.MD{cordonedBrokersMetadataKey: cordoned}
+		if err := grpc.SetHeader(ctx, header); err != nil {
+			a.logger.Warnf("api: Failed to set cordoned brokers header: %v", err)
+		}
+	}
+
 	return resp, nil
 }
 
 // Publish a new message to a subject. If the AckPolicy is not NONE and a
 // deadline is provided, this will synchronously block until the ack is
 // received. If the ack is not received in time, a DeadlineExceeded status code
-// is returned.
+// is returned. If the AckPolicy is ALL and the stream's ISR is below the minimum
+// ISR size, the stream leader rejects the message and an Unavailable status
+// code is returned. If the stream-name metadata key is set, the message is
+// published directly to that stream rather than to every stream attached to
+// its subject. It's appended by this server if it's the stream leader and
+// forwarded to the leader otherwise, avoiding the round trip through NATS.
 func (a *apiServer) Publish(ctx context.Context, req *client.PublishRequest) (
 	*client.PublishResponse, error) {
 	if req.Message == nil {
@@ -117,27 +249,37 @@ func (a *apiServer) Publish(ctx context.Context, req *client.PublishRequest) (
 	}
 	a.logger.Debugf("api: Publish [subject=%s]", req.Message.Subject)
 
+	span := a.startPublishSpan(ctx, req.Message)
+	defer span.End()
+
+	// If AckPolicy is NONE or a timeout isn't specified, then we will fire and
+	// forget.
+	var (
+		resp           = new(client.PublishResponse)
+		_, hasDeadline = ctx.Deadline()
+		waitForAck     = req.Message.AckPolicy != client.AckPolicy_NONE && hasDeadline
+		err            error
+	)
+
+	if subject, name, ok := publishStream(ctx, req.Message); ok {
+		resp.Ack, err = a.publishDirect(ctx, subject, name, req.Message, waitForAck)
+		if err != nil {
+			span.SetAttribute("error", err.Error())
+		}
+		return resp, err
+	}
+
 	if req.Message.AckInbox == "" {
 		req.Message.AckInbox = nuid.Next()
 	}
 
-	msg, err := req.Message.Marshal()
+	buf, err := marshalEnvelope(req.Message)
 	if err != nil {
 		a.logger.Errorf("api: Failed to publish message: %v", err.Error())
 		return nil, err
 	}
 
-	buf := make([]byte, envelopeCookieLen+len(msg))
-	copy(buf[0:], envelopeCookie)
-	copy(buf[envelopeCookieLen:], msg)
-
-	// If AckPolicy is NONE or a timeout isn't specified, then we will fire and
-	// forget.
-	var (
-		resp           = new(client.PublishResponse)
-		_, hasDeadline = ctx.Deadline()
-	)
-	if req.Message.AckPolicy == client.AckPolicy_NONE || !hasDeadline {
+	if !waitForAck {
 		if err := a.ncPublishes.Publish(req.Message.Subject, buf); err != nil {
 			a.logger.Errorf("api: Failed to publish message: %v", err)
 			return nil, err
@@ -147,9 +289,188 @@ func (a *apiServer) Publish(ctx context.Context, req *client.PublishRequest) (
 
 	// Otherwise we need to publish and wait for the ack.
 	resp.Ack, err = a.publishSync(ctx, req.Message.Subject, req.Message.AckInbox, buf)
+	if err != nil {
+		span.SetAttribute("error", err.Error())
+	}
 	return resp, err
 }
 
+// publishDirect publishes the message directly to the given stream, bypassing
+// NATS, if this server is the stream leader and forwards it to the leader
+// otherwise. If waitForAck is true, this waits for the ack and returns it.
+func (a *apiServer) publishDirect(ctx context.Context, subject, name string,
+	msg *client.Message, waitForAck bool) (*client.Ack, error) {
+
+	stream := a.metadata.GetStream(subject, name)
+	if stream == nil {
+		a.logger.Errorf("api: Failed to publish message to stream [subject=%s, name=%s]: no such stream",
+			subject, name)
+		return nil, status.Error(codes.NotFound, "No such stream")
+	}
+	if !subjectMatches(msg.Subject, stream.Subject) {
+		a.logger.Errorf("api: Failed to publish message to stream %s: subject %s doesn't match stream",
+			stream, msg.Subject)
+		return nil, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("Subject %s doesn't match stream subject %s", msg.Subject, stream.Subject))
+	}
+
+	var (
+		ack *client.Ack
+		st  *status.Status
+	)
+	if stream.IsLeader() {
+		ack, st = stream.Publish(ctx, msg, waitForAck)
+	} else {
+		ack, st = stream.ForwardPublish(ctx, msg, waitForAck)
+	}
+	if st != nil {
+		a.logger.Errorf("api: Failed to publish message to stream %s: %v", stream, st.Message())
+		return nil, st.Err()
+	}
+	return ack, nil
+}
+
+// publishStream returns the subject and name of the stream a message is
+// published directly to, if any, from the gRPC metadata. The subject defaults
+// to the message subject.
+func publishStream(ctx context.Context, msg *client.Message) (string, string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", "", false
+	}
+	names := md.Get(streamNameMetadataKey)
+	if len(names) == 0 {
+		return "", "", false
+	}
+	subject := msg.Subject
+	if subjects := md.Get(streamSubjectMetadataKey); len(subjects) > 0 {
+		subject = subjects[0]
+	}
+	return subject, names[0], true
+}
+
+// startPublishSpan continues the publisher's trace, if there is one, and
+// propagates the publish span to the stream leader in the message headers.
+func (s *Server) startPublishSpan(ctx context.Context, msg *client.Message) *tracing.Span {
+	span := s.tracer.StartSpanFromTraceParent("publish", publishTraceParent(ctx, msg))
+	if span != nil {
+		span.SetAttribute("subject", msg.Subject)
+		if msg.Headers == nil {
+			msg.Headers = make(map[string][]byte)
+		}
+		msg.Headers[tracing.TraceParentHeader] = []byte(span.TraceParent())
+	}
+	return span
+}
+
+// publishTraceParent returns the trace context for a published message. This
+// is taken from the message headers or, if it's not set there, the request
+// metadata.
+func publishTraceParent(ctx context.Context, msg *client.Message) string {
+	if traceParent, ok := msg.Headers[tracing.TraceParentHeader]; ok {
+		return string(traceParent)
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(tracing.TraceParentHeader); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// createStreamFlushPolicy returns the flush policy to create a stream with, if
+// any, from the gRPC metadata. It returns nil if neither flush key is set, in
+// which case the stream uses the server's flush policy.
+func createStreamFlushPolicy(ctx context.Context) (*proto.FlushPolicy, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	var (
+		messages = md.Get(flushMessagesMetadataKey)
+		interval = md.Get(flushIntervalMetadataKey)
+		policy   = &proto.FlushPolicy{}
+	)
+	if len(messages) == 0 && len(interval) == 0 {
+		return nil, nil
+	}
+	if len(messages) > 0 {
+		n, err := strconv.ParseInt(messages[0], 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid %s %q", flushMessagesMetadataKey, messages[0])
+		}
+		policy.Messages = n
+	}
+	if len(interval) > 0 {
+		dur, err := time.ParseDuration(interval[0])
+		if err != nil || dur < 0 {
+			return nil, fmt.Errorf("invalid %s %q", flushIntervalMetadataKey, interval[0])
+		}
+		policy.Interval = int64(dur)
+	}
+	return policy, nil
+}
+
+// createStreamUncleanLeaderElectionTimeout returns the unclean leader election
+// timeout to create a stream with from the gRPC metadata. It returns zero,
+// which disables unclean leader elections, if the key isn't set.
+func createStreamUncleanLeaderElectionTimeout(ctx context.Context) (time.Duration, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, nil
+	}
+	values := md.Get(uncleanLeaderElectionTimeoutMetadataKey)
+	if len(values) == 0 {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(values[0])
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("invalid %s %q", uncleanLeaderElectionTimeoutMetadataKey, values[0])
+	}
+	return timeout, nil
+}
+
+// publishAckTimeout returns how long each message published on a
+// PublishAsync stream waits for its ack from the gRPC metadata. It returns
+// defaultAsyncAckTimeout if the key isn't set.
+func publishAckTimeout(ctx context.Context) (time.Duration, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return defaultAsyncAckTimeout, nil
+	}
+	values := md.Get(ackTimeoutMetadataKey)
+	if len(values) == 0 {
+		return defaultAsyncAckTimeout, nil
+	}
+	timeout, err := time.ParseDuration(values[0])
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", ackTimeoutMetadataKey, values[0])
+	}
+	return timeout, nil
+}
+
+// subscribeOffsetOutOfRange returns the policy for handling an out-of-range
+// subscription start offset, if any, from the gRPC metadata.
+func subscribeOffsetOutOfRange(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(offsetOutOfRangeMetadataKey); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// subscribeSubjectFilter returns the subject pattern a subscription is
+// narrowed to, if any, from the gRPC metadata.
+func subscribeSubjectFilter(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(subjectFilterMetadataKey); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
 func (a *apiServer) publishSync(ctx context.Context, subject,
 	ackInbox string, msg []byte) (*client.Ack, error) {
 
@@ -179,30 +500,81 @@ func (a *apiServer) publishSync(ctx context.Context, subject,
 		return nil, err
 	}
 
-	ack := new(client.Ack)
-	if err := ack.Unmarshal(ackMsg.Data); err != nil {
+	ack, st, err := decodeAck(ackMsg.Data)
+	if err != nil {
 		a.logger.Errorf("api: Invalid ack for publish: %v", err)
 		return nil, err
 	}
+	if st != nil {
+		a.logger.Errorf("api: Publish rejected by stream leader: %s", st.Message())
+		return nil, st.Err()
+	}
 	return ack, nil
 }
 
+// decodeAck unmarshals an ack published by a stream leader along with the
+// status the message was rejected with, if the leader rejected it.
+func decodeAck(data []byte) (*client.Ack, *status.Status, error) {
+	ack := new(client.Ack)
+	if err := ack.Unmarshal(data); err != nil {
+		return nil, nil, err
+	}
+	ext := new(proto.AckExtension)
+	if err := ext.Unmarshal(data); err != nil {
+		return nil, nil, err
+	}
+	if ext.Error != nil {
+		return ack, ackErrorStatus(ext.Error), nil
+	}
+	return ack, nil, nil
+}
+
+// ackErrorStatus returns the status for a message a stream leader rejected
+// with the given error. NOT_ENOUGH_REPLICAS is returned as ResourceExhausted,
+// which publishing doesn't fail with otherwise, so that producers can tell it
+// apart from other failures.
+func ackErrorStatus(ackErr *proto.AckError) *status.Status {
+	code := codes.Unknown
+	if ackErr.Code == proto.AckErrorCode_NOT_ENOUGH_REPLICAS {
+		code = codes.ResourceExhausted
+	}
+	return status.New(code, ackErr.Msg)
+}
+
 // subscribe sets up a subscription on the given stream and begins sending
 // messages on the returned channel. The subscription will run until the cancel
 // channel is closed, the context is canceled, or an error is returned
 // asynchronously on the status channel.
 func (a *apiServer) subscribe(ctx context.Context, stream *stream,
 	req *client.SubscribeRequest, cancel chan struct{}) (
-	<-chan *client.Message, <-chan *status.Status, *status.Status) {
+	<-chan *client.Message, <-chan *status.Status, int64, *status.Status) {
+
+	filter := subscribeSubjectFilter(ctx)
+	if filter != "" && !natsd.IsValidSubject(filter) {
+		return nil, nil, 0, status.New(
+			codes.InvalidArgument, fmt.Sprintf("Invalid subject filter %s", filter))
+	}
+
+	outOfRange := subscribeOffsetOutOfRange(ctx)
+	switch outOfRange {
+	case "", offsetOutOfRangeError, offsetOutOfRangeEarliest, offsetOutOfRangeLatest:
+	default:
+		return nil, nil, 0, status.New(
+			codes.InvalidArgument, fmt.Sprintf("Unknown offset-out-of-range policy %s", outOfRange))
+	}
 
 	var startOffset int64
 	switch req.StartPosition {
 	case client.StartPosition_OFFSET:
-		startOffset = req.StartOffset
+		offset, st := resolveStartOffset(stream, req.StartOffset, outOfRange)
+		if st != nil {
+			return nil, nil, 0, st
+		}
+		startOffset = offset
 	case client.StartPosition_TIMESTAMP:
 		offset, err := stream.log.OffsetForTimestamp(req.StartTimestamp)
 		if err != nil {
-			return nil, nil, status.New(
+			return nil, nil, 0, status.New(
 				codes.Internal, fmt.Sprintf("Failed to lookup offset for timestamp: %v", err))
 		}
 		startOffset = offset
@@ -213,7 +585,7 @@ func (a *apiServer) subscribe(ctx context.Context, stream *stream,
 	case client.StartPosition_NEW_ONLY:
 		startOffset = stream.log.NewestOffset() + 1
 	default:
-		return nil, nil, status.New(
+		return nil, nil, 0, status.New(
 			codes.InvalidArgument,
 			fmt.Sprintf("Unknown StartPosition %s", req.StartPosition))
 	}
@@ -229,10 +601,22 @@ func (a *apiServer) subscribe(ctx context.Context, stream *stream,
 		reader, err = stream.log.NewReader(startOffset, false)
 	)
 	if err != nil {
-		return nil, nil, status.New(
+		return nil, nil, 0, status.New(
 			codes.Internal, fmt.Sprintf("Failed to create stream reader: %v", err))
 	}
 
+	// Track the progress of the subscription if it's for a named consumer.
+	var namedConsumer *consumer
+	if name := subscribeConsumerName(ctx); name != "" {
+		namedConsumer = stream.subscribeConsumer(name, startOffset)
+	}
+
+	// Messages are read in a separate goroutine so that a message held back
+	// until its deliver-after time doesn't delay the messages following it.
+	var (
+		readCh  = make(chan *delayedMessage)
+		readErr = make(chan error)
+	)
 	a.startGoroutine(func() {
 		headersBuf := make([]byte, 28)
 		for {
@@ -240,30 +624,159 @@ func (a *apiServer) subscribe(ctx context.Context, stream *stream,
 			m, offset, timestamp, _, err := reader.ReadMessage(ctx, headersBuf)
 			if err != nil {
 				select {
-				case errCh <- status.Convert(err):
+				case readErr <- err:
 				case <-cancel:
 				}
 				return
 			}
-			headers := m.Headers()
+			select {
+			case readCh <- &delayedMessage{msg: m, offset: offset, timestamp: timestamp}:
+			case <-cancel:
+				return
+			}
+		}
+	})
+
+	a.startGoroutine(func() {
+		if namedConsumer != nil {
+			defer stream.unsubscribeConsumer(namedConsumer)
+		}
+		var delayed delayedMessages
+
+		// markDelivered records a message as delivered to a named consumer
+		// unless an earlier message is still being held back, in which case
+		// the consumer's offset must not move past it.
+		markDelivered := func(offset, timestamp int64) {
+			if namedConsumer != nil && !delayed.holdsBefore(offset) {
+				stream.consumerDelivered(namedConsumer, offset, timestamp)
+			}
+		}
+
+		// deliver sends the message to the subscriber, skipping it if it has
+		// expired. It returns false if the subscription was canceled.
+		deliver := func(m *delayedMessage) bool {
+			headers := m.msg.Headers()
+			if expiration, ok := commitlog.TimestampHeader(headers, commitlog.ExpirationHeader); ok &&
+				expiration <= time.Now().UnixNano() {
+				markDelivered(m.offset, m.timestamp)
+				return true
+			}
 			var (
 				msg = &client.Message{
-					Offset:    offset,
-					Key:       m.Key(),
-					Value:     m.Value(),
-					Timestamp: timestamp,
+					Offset:    m.offset,
+					Key:       m.msg.Key(),
+					Value:     m.msg.Value(),
+					Timestamp: m.timestamp,
 					Headers:   headers,
 					Subject:   string(headers["subject"]),
 					Reply:     string(headers["reply"]),
 				}
+				span = a.tracer.StartSpanFromTraceParent("deliver",
+					string(headers[tracing.TraceParentHeader]))
 			)
+			if span != nil {
+				span.SetAttribute(logger.FieldStreamSubject, stream.Subject)
+				span.SetAttribute(logger.FieldStreamName, stream.Name)
+				span.SetAttribute(logger.FieldOffset, m.offset)
+				headers[tracing.TraceParentHeader] = []byte(span.TraceParent())
+			}
 			select {
 			case ch <- msg:
+				span.End()
+				markDelivered(m.offset, m.timestamp)
+				return true
 			case <-cancel:
+				span.End()
+				return false
+			}
+		}
+
+		for {
+			var (
+				timer  *time.Timer
+				timerC <-chan time.Time
+			)
+			if len(delayed) > 0 {
+				timer = time.NewTimer(time.Duration(delayed[0].deliverAfter - time.Now().UnixNano()))
+				timerC = timer.C
+			}
+			select {
+			case m := <-readCh:
+				if timer != nil {
+					timer.Stop()
+				}
+				headers := m.msg.Headers()
+				if filter != "" && !subjectMatches(string(headers["subject"]), filter) {
+					markDelivered(m.offset, m.timestamp)
+					continue
+				}
+				// Hold the message back until its deliver-after time, if it
+				// has one.
+				if deliverAfter, ok := commitlog.TimestampHeader(headers, commitlog.DeliverAfterHeader); ok &&
+					deliverAfter > time.Now().UnixNano() {
+					m.deliverAfter = deliverAfter
+					heap.Push(&delayed, m)
+					continue
+				}
+				if !deliver(m) {
+					return
+				}
+			case <-timerC:
+				now := time.Now().UnixNano()
+				for len(delayed) > 0 && delayed[0].deliverAfter <= now {
+					if !deliver(heap.Pop(&delayed).(*delayedMessage)) {
+						return
+					}
+				}
+			case err := <-readErr:
+				if timer != nil {
+					timer.Stop()
+				}
+				select {
+				case errCh <- status.Convert(err):
+				case <-cancel:
+				}
+				return
+			case <-cancel:
+				if timer != nil {
+					timer.Stop()
+				}
 				return
 			}
 		}
 	})
 
-	return ch, errCh, nil
+	return ch, errCh, startOffset, nil
+}
+
+// resolveStartOffset applies the out-of-range policy to a subscription start
+// offset. Offsets from the oldest offset in the log through the offset
+// following the HW, i.e. the next message to be committed, are in range. With
+// no policy, an offset below the oldest offset starts at the oldest offset and
+// an offset past the HW waits for the log to reach it.
+func resolveStartOffset(stream *stream, offset int64, policy string) (int64, *status.Status) {
+	var (
+		latest   = stream.log.HighWatermark() + 1
+		earliest = stream.log.OldestOffset()
+	)
+	if earliest < 0 || earliest > latest {
+		// The log is empty, so the next message is the only one to read.
+		earliest = latest
+	}
+	if offset >= earliest && offset <= latest {
+		return offset, nil
+	}
+	switch policy {
+	case offsetOutOfRangeError:
+		return 0, status.New(codes.OutOfRange, fmt.Sprintf(
+			"Start offset %d is out of range [%d, %d]", offset, earliest, latest))
+	case offsetOutOfRangeEarliest:
+		return earliest, nil
+	case offsetOutOfRangeLatest:
+		return latest, nil
+	}
+	if offset < earliest {
+		return earliest, nil
+	}
+	return offset, nil
 }
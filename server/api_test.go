@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,7 +14,11 @@ import (
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+
+	"github.com/liftbridge-io/liftbridge/server/commitlog"
+	"github.com/liftbridge-io/liftbridge/server/tracing"
 )
 
 type message struct {
@@ -357,3 +362,462 @@ func TestStreamPublishSubscribe(t *testing.T) {
 		t.Fatal("Did not receive all expected messages")
 	}
 }
+
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans map[string]*tracing.Span
+}
+
+func (r *spanRecorder) ExportSpan(span *tracing.Span) error {
+	r.mu.Lock()
+	r.spans[span.Name] = span
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *spanRecorder) Close() error { return nil }
+
+func (r *spanRecorder) waitForSpans(t *testing.T, timeout time.Duration, names ...string) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		r.mu.Lock()
+		found := 0
+		for _, name := range names {
+			if _, ok := r.spans[name]; ok {
+				found++
+			}
+		}
+		r.mu.Unlock()
+		if found == len(names) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	stackFatalf(t, "Did not export spans %v", names)
+}
+
+// Ensure trace context on a published message is propagated through the
+// publish, append, commit, and delivery spans and on to the subscriber.
+func TestStreamPublishSubscribeTracing(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	recorder := &spanRecorder{spans: make(map[string]*tracing.Span)}
+	tracing.RegisterExporter("recorder", recorder)
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1Config.Tracing.Exporter = "recorder"
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	client, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	name := "foo"
+	subject := "foo"
+	err = client.CreateStream(context.Background(), subject, name)
+	require.NoError(t, err)
+
+	conn, err := grpc.Dial("localhost:5050", grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	apiClient := proto.NewAPIClient(conn)
+
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = apiClient.Publish(ctx, &proto.PublishRequest{
+		Message: &proto.Message{
+			Subject:   subject,
+			Value:     []byte("hello"),
+			Headers:   map[string][]byte{tracing.TraceParentHeader: []byte(traceParent)},
+			AckPolicy: proto.AckPolicy_ALL,
+		},
+	})
+	require.NoError(t, err)
+
+	msgs := make(chan *proto.Message, 1)
+	subCtx, subCancel := context.WithCancel(context.Background())
+	defer subCancel()
+	err = client.Subscribe(subCtx, subject, name, func(msg *proto.Message, err error) {
+		require.NoError(t, err)
+		msgs <- msg
+		subCancel()
+	}, lift.StartAtEarliestReceived())
+	require.NoError(t, err)
+
+	var msg *proto.Message
+	select {
+	case msg = <-msgs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Did not receive expected message")
+	}
+
+	recorder.waitForSpans(t, 5*time.Second, "publish", "append", "commit", "deliver")
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	var (
+		publishSpan = recorder.spans["publish"]
+		appendSpan  = recorder.spans["append"]
+		commitSpan  = recorder.spans["commit"]
+		deliverSpan = recorder.spans["deliver"]
+	)
+	for _, span := range []*tracing.Span{publishSpan, appendSpan, commitSpan, deliverSpan} {
+		require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.Context.TraceID.String())
+	}
+	require.Equal(t, "00f067aa0ba902b7", publishSpan.ParentSpanID.String())
+	require.Equal(t, publishSpan.Context.SpanID, appendSpan.ParentSpanID)
+	require.Equal(t, appendSpan.Context.SpanID, commitSpan.ParentSpanID)
+	require.Equal(t, appendSpan.Context.SpanID, deliverSpan.ParentSpanID)
+	require.Equal(t, int64(0), deliverSpan.Attributes["offset"])
+
+	// The subscriber receives the delivery span's context.
+	require.Equal(t, deliverSpan.TraceParent(), string(msg.Headers[tracing.TraceParentHeader]))
+}
+
+// Ensure the commit spans of messages still pending commit are ended when the
+// stream leader stops leading.
+func TestStreamCommitSpansEndedOnStopLeading(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	recorder := &spanRecorder{spans: make(map[string]*tracing.Span)}
+	tracing.RegisterExporter("recorder", recorder)
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1Config.Tracing.Exporter = "recorder"
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	client, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	name := "foo"
+	subject := "foo"
+	err = client.CreateStream(context.Background(), subject, name)
+	require.NoError(t, err)
+	getStreamLeader(t, 10*time.Second, subject, name, s1)
+
+	// Simulate a traced message which is pending commit.
+	stream := s1.metadata.GetStream(subject, name)
+	stream.commitSpansMu.Lock()
+	stream.commitSpans[0] = s1.tracer.StartSpanFromTraceParent("commit",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	stream.commitSpansMu.Unlock()
+
+	stream.mu.Lock()
+	require.NoError(t, stream.stopLeading())
+	stream.mu.Unlock()
+
+	recorder.waitForSpans(t, 5*time.Second, "commit")
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	require.Equal(t, "stream leadership lost", recorder.spans["commit"].Attributes["error"])
+	stream.commitSpansMu.Lock()
+	require.Empty(t, stream.commitSpans)
+	stream.commitSpansMu.Unlock()
+}
+
+// Ensure creating a stream with an invalid subject, or a wildcard subject
+// which would capture internal subjects, fails.
+func TestCreateStreamInvalidSubject(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	client, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	for _, subject := range []string{"foo..bar", "foo.>.bar", ">", "*.bar",
+		s1Config.Clustering.Namespace + ".>"} {
+		err = client.CreateStream(context.Background(), subject, "foo")
+		require.Error(t, err, subject)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), subject)
+	}
+}
+
+// Ensure a stream attached to a wildcard subject captures messages published
+// to every matching subject and subscriptions can be narrowed to a subject
+// pattern.
+func TestStreamPublishSubscribeWildcard(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	client, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	name := "foo"
+	subject := "foo.*.created"
+	err = client.CreateStream(context.Background(), subject, name)
+	require.NoError(t, err)
+
+	// Publish a message which doesn't match the stream subject.
+	_, err = client.Publish(context.Background(), "foo.bar.deleted", []byte("x"),
+		lift.AckPolicyNone())
+	require.NoError(t, err)
+
+	// Publish messages to matching subjects.
+	subjects := []string{"foo.bar.created", "foo.baz.created", "foo.bar.created"}
+	for i, subj := range subjects {
+		_, err = client.Publish(context.Background(), subj, []byte(strconv.Itoa(i)))
+		require.NoError(t, err)
+	}
+
+	subscribe := func(ctx context.Context, num int) []*proto.Message {
+		var (
+			msgs = make(chan *proto.Message, num)
+			i    = 0
+		)
+		err := client.Subscribe(ctx, subject, name, func(msg *proto.Message, err error) {
+			if i == num {
+				return
+			}
+			require.NoError(t, err)
+			msgs <- msg
+			i++
+		}, lift.StartAtEarliestReceived())
+		require.NoError(t, err)
+		received := make([]*proto.Message, num)
+		for i := 0; i < num; i++ {
+			select {
+			case received[i] = <-msgs:
+			case <-time.After(10 * time.Second):
+				t.Fatal("Did not receive all expected messages")
+			}
+		}
+		return received
+	}
+
+	// The stream contains every message published to a matching subject.
+	received := subscribe(context.Background(), len(subjects))
+	for i, msg := range received {
+		require.Equal(t, int64(i), msg.Offset)
+		require.Equal(t, subjects[i], msg.Subject)
+		require.Equal(t, []byte(strconv.Itoa(i)), msg.Value)
+	}
+
+	// Narrow the subscription to a subject pattern.
+	ctx, cancel := context.WithCancel(metadata.AppendToOutgoingContext(
+		context.Background(), subjectFilterMetadataKey, "foo.bar.*"))
+	defer cancel()
+	received = subscribe(ctx, 2)
+	require.Equal(t, int64(0), received[0].Offset)
+	require.Equal(t, int64(2), received[1].Offset)
+	for _, msg := range received {
+		require.Equal(t, "foo.bar.created", msg.Subject)
+	}
+}
+
+// Ensure a subscription starting at an offset outside of the stream's log is
+// handled by the requested out-of-range policy and the offset the subscription
+// actually starts at is returned in the header.
+func TestSubscribeOffsetOutOfRange(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server to retain only the last five messages.
+	s1Config := getTestConfig("a", true, 5050)
+	s1Config.Log.SegmentMaxBytes = 1
+	s1Config.Log.RetentionMaxMessages = 5
+	s1Config.Log.CleanerInterval = time.Hour
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	client, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	name := "foo"
+	subject := "foo"
+	err = client.CreateStream(context.Background(), subject, name)
+	require.NoError(t, err)
+
+	// Wait for each ack so that every message is written in its own batch and
+	// therefore its own segment, which retention removes one at a time.
+	for i := 0; i < 10; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err = client.Publish(ctx, subject, []byte(strconv.Itoa(i)), lift.AckPolicyLeader())
+		cancel()
+		require.NoError(t, err)
+	}
+	waitForHW(t, 5*time.Second, subject, name, 9, s1)
+
+	// Apply retention to remove the first five messages.
+	stream := s1.metadata.GetStream(subject, name)
+	require.NoError(t, stream.log.Clean())
+	require.Equal(t, int64(5), stream.log.OldestOffset())
+
+	conn, err := grpc.Dial("localhost:5050", grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	apiClient := proto.NewAPIClient(conn)
+
+	// subscribe returns the start offset header and first message of a
+	// subscription at the given offset with the given out-of-range policy.
+	subscribe := func(offset int64, policy string) (string, *proto.Message, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if policy != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, offsetOutOfRangeMetadataKey, policy)
+		}
+		stream, err := apiClient.Subscribe(ctx, &proto.SubscribeRequest{
+			Subject:       subject,
+			Name:          name,
+			StartPosition: proto.StartPosition_OFFSET,
+			StartOffset:   offset,
+		})
+		require.NoError(t, err)
+		// The first message signals the subscription was created.
+		if _, err := stream.Recv(); err != nil {
+			return "", nil, err
+		}
+		header, err := stream.Header()
+		require.NoError(t, err)
+		var startOffset string
+		if values := header.Get(startOffsetMetadataKey); len(values) > 0 {
+			startOffset = values[0]
+		}
+		if offset > 10 {
+			// Nothing to receive yet.
+			return startOffset, nil, nil
+		}
+		msg, err := stream.Recv()
+		require.NoError(t, err)
+		return startOffset, msg, nil
+	}
+
+	// Offsets in range are unaffected.
+	startOffset, msg, err := subscribe(7, offsetOutOfRangeError)
+	require.NoError(t, err)
+	require.Equal(t, "7", startOffset)
+	require.Equal(t, int64(7), msg.Offset)
+
+	// Without a policy, the subscription starts at the oldest offset.
+	startOffset, msg, err = subscribe(2, "")
+	require.NoError(t, err)
+	require.Equal(t, "5", startOffset)
+	require.Equal(t, int64(5), msg.Offset)
+
+	// The error policy fails the subscription.
+	_, _, err = subscribe(2, offsetOutOfRangeError)
+	require.Error(t, err)
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+	_, _, err = subscribe(20, offsetOutOfRangeError)
+	require.Error(t, err)
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+
+	// The earliest policy resets to the oldest offset.
+	startOffset, msg, err = subscribe(2, offsetOutOfRangeEarliest)
+	require.NoError(t, err)
+	require.Equal(t, "5", startOffset)
+	require.Equal(t, int64(5), msg.Offset)
+
+	// The latest policy resets to the offset of the next message.
+	startOffset, _, err = subscribe(20, offsetOutOfRangeLatest)
+	require.NoError(t, err)
+	require.Equal(t, "10", startOffset)
+
+	// An unknown policy is rejected.
+	_, _, err = subscribe(2, "foo")
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// Ensure streams use the server's flush policy unless one is set when the
+// stream is created and flush latency is recorded in the metrics.
+func TestCreateStreamFlushPolicy(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server with a flush interval.
+	s1Config := getTestConfig("a", true, 5050)
+	s1Config.Log.FlushInterval = time.Minute
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	client, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	// A stream created without a flush policy uses the server's.
+	err = client.CreateStream(context.Background(), "foo", "foo")
+	require.NoError(t, err)
+	log := s1.metadata.GetStream("foo", "foo").log.(*commitlog.CommitLog)
+	require.Equal(t, int64(0), log.FlushMessages)
+	require.Equal(t, time.Minute, log.FlushInterval)
+
+	// A stream created with a flush policy overrides the server's.
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		flushMessagesMetadataKey, "1")
+	err = client.CreateStream(ctx, "bar", "bar")
+	require.NoError(t, err)
+	log = s1.metadata.GetStream("bar", "bar").log.(*commitlog.CommitLog)
+	require.Equal(t, int64(1), log.FlushMessages)
+	require.Equal(t, time.Duration(0), log.FlushInterval)
+
+	// Each append to the stream is flushed.
+	_, err = client.Publish(context.Background(), "bar", []byte("hello"))
+	require.NoError(t, err)
+	waitForHW(t, 5*time.Second, "bar", "bar", 0, s1)
+	flushes := 0
+	for _, interval := range s1.metricsSink.Data() {
+		interval.RLock()
+		if sample, ok := interval.Samples["liftbridge.log.flush.latency;subject=bar;name=bar"]; ok {
+			flushes += sample.Count
+		}
+		interval.RUnlock()
+	}
+	require.True(t, flushes > 0)
+
+	// An invalid flush policy is rejected.
+	ctx = metadata.AppendToOutgoingContext(context.Background(),
+		flushIntervalMetadataKey, "foo")
+	err = client.CreateStream(ctx, "baz", "baz")
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
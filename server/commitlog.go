@@ -1,6 +1,8 @@
 package server
 
 import (
+	"time"
+
 	"github.com/liftbridge-io/liftbridge/server/commitlog"
 	"github.com/liftbridge-io/liftbridge/server/proto"
 )
@@ -57,10 +59,22 @@ type CommitLog interface {
 	// returns the corresponding offsets in the log.
 	AppendMessageSet(ms []byte) ([]int64, error)
 
+	// Purge removes all messages before the given offset from the log,
+	// advancing its start offset. If the offset is beyond the end of the log,
+	// all messages are removed and the log continues from the offset.
+	Purge(offset int64) error
+
 	// Clean applies retention and compaction rules against the log, if
 	// applicable.
 	Clean() error
 
+	// CleanerStatus returns the progress of cleaning the log.
+	CleanerStatus() commitlog.CleanerStatus
+
+	// SetRetention updates the log retention policy. The new policy is
+	// enforced the next time the log is cleaned.
+	SetRetention(maxBytes, maxMessages int64, maxAge time.Duration)
+
 	// Close closes each log segment file and stops the background goroutine
 	// checkpointing the high watermark to disk.
 	Close() error
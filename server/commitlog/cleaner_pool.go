@@ -0,0 +1,192 @@
+package commitlog
+
+import (
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/liftbridge-io/liftbridge/server/logger"
+)
+
+const defaultCleanerThreads = 1
+
+// CleanerPoolOptions contains configuration settings for the CleanerPool.
+type CleanerPoolOptions struct {
+	Logger              logger.Logger
+	Threads             int   // Number of logs to clean concurrently
+	IOMaxBytesPerSecond int64 // Max combined rate of compaction reads and writes
+}
+
+// CleanerPool cleans logs on a fixed number of goroutines shared by all the
+// logs on a broker rather than each log cleaning itself. This bounds the
+// amount of I/O spent on retention and compaction at any one time. Logs
+// attached to the pool are queued for cleaning on their cleaner interval, and
+// the queued log which was least recently cleaned is cleaned next so that a
+// log with an expensive compaction doesn't starve the others. Compaction reads
+// and writes are throttled to a combined maximum number of bytes per second.
+type CleanerPool struct {
+	CleanerPoolOptions
+	throttler *Throttler
+	mu        sync.Mutex
+	cond      *sync.Cond
+	logs      map[*CommitLog]*cleanerState
+	closed    bool
+	wg        sync.WaitGroup
+}
+
+// cleanerState tracks the scheduling of a log in a CleanerPool.
+type cleanerState struct {
+	queued      bool
+	queuedSince time.Time
+	cleaning    bool
+}
+
+// NewCleanerPool returns a new CleanerPool and starts its goroutines. Call
+// Close to stop them.
+func NewCleanerPool(opts CleanerPoolOptions) *CleanerPool {
+	if opts.Logger == nil {
+		opts.Logger = logger.NewLogger(0)
+		opts.Logger.SetWriter(ioutil.Discard)
+	}
+	if opts.Threads <= 0 {
+		opts.Threads = defaultCleanerThreads
+	}
+	p := &CleanerPool{
+		CleanerPoolOptions: opts,
+		throttler:          NewThrottler(opts.IOMaxBytesPerSecond),
+		logs:               make(map[*CommitLog]*cleanerState),
+	}
+	p.cond = sync.NewCond(&p.mu)
+	p.wg.Add(opts.Threads)
+	for i := 0; i < opts.Threads; i++ {
+		go p.cleanLoop()
+	}
+	return p
+}
+
+// Backlog returns the number of logs waiting to be cleaned.
+func (p *CleanerPool) Backlog() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	backlog := 0
+	for _, state := range p.logs {
+		if state.queued {
+			backlog++
+		}
+	}
+	return backlog
+}
+
+// Close stops the pool's goroutines, waiting for any logs being cleaned to
+// finish.
+func (p *CleanerPool) Close() {
+	p.mu.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// add attaches the log to the pool.
+func (p *CleanerPool) add(l *CommitLog) {
+	p.mu.Lock()
+	p.logs[l] = &cleanerState{}
+	p.mu.Unlock()
+}
+
+// remove detaches the log from the pool. If the log is queued, it won't be
+// cleaned.
+func (p *CleanerPool) remove(l *CommitLog) {
+	p.mu.Lock()
+	delete(p.logs, l)
+	p.mu.Unlock()
+}
+
+// schedule queues the log to be cleaned. This is a no-op if the log is already
+// queued.
+func (p *CleanerPool) schedule(l *CommitLog) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	state, ok := p.logs[l]
+	if !ok || state.queued {
+		return
+	}
+	state.queued = true
+	state.queuedSince = time.Now()
+	p.cond.Signal()
+}
+
+// status fills in the scheduling state of the log.
+func (p *CleanerPool) status(l *CommitLog, status *CleanerStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	state, ok := p.logs[l]
+	if !ok {
+		return
+	}
+	status.Queued = state.queued
+	if state.queued {
+		status.QueuedSince = state.queuedSince
+	}
+	status.Cleaning = state.cleaning
+}
+
+// cleanLoop is a long-running loop which cleans queued logs until the pool is
+// closed.
+func (p *CleanerPool) cleanLoop() {
+	defer p.wg.Done()
+	for {
+		l := p.next()
+		if l == nil {
+			return
+		}
+		if err := l.Clean(); err != nil {
+			p.Logger.Errorf("Failed to clean log %s: %v", l.Path, err)
+		}
+		p.mu.Lock()
+		if state, ok := p.logs[l]; ok {
+			state.cleaning = false
+			// The log may have been queued again while it was being cleaned.
+			if state.queued {
+				p.cond.Signal()
+			}
+		}
+		p.mu.Unlock()
+	}
+}
+
+// next blocks until there is a queued log which isn't already being cleaned and
+// returns the one which was least recently cleaned, marking it as being
+// cleaned. It returns nil if the pool is closed.
+func (p *CleanerPool) next() *CommitLog {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for {
+		if p.closed {
+			return nil
+		}
+		var (
+			next        *CommitLog
+			nextCleaned time.Time
+		)
+		for l, state := range p.logs {
+			if !state.queued || state.cleaning {
+				continue
+			}
+			lastCleaned, _ := l.lastCleaned()
+			if next == nil || lastCleaned.Before(nextCleaned) ||
+				(lastCleaned.Equal(nextCleaned) &&
+					state.queuedSince.Before(p.logs[next].queuedSince)) {
+				next = l
+				nextCleaned = lastCleaned
+			}
+		}
+		if next != nil {
+			state := p.logs[next]
+			state.queued = false
+			state.cleaning = true
+			return next
+		}
+		p.cond.Wait()
+	}
+}
@@ -0,0 +1,100 @@
+package commitlog
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/liftbridge-io/liftbridge/server/proto"
+)
+
+// Ensure logs attached to a CleanerPool are cleaned on their cleaner interval
+// by the pool and their cleaner status is reported.
+func TestCleanerPool(t *testing.T) {
+	pool := NewCleanerPool(CleanerPoolOptions{Threads: 2})
+	defer pool.Close()
+
+	logs := make([]*CommitLog, 3)
+	for i := range logs {
+		opts := Options{
+			Path:            tempDir(t),
+			MaxSegmentBytes: 6,
+			MaxLogMessages:  2,
+			CleanerInterval: 10 * time.Millisecond,
+			CleanerPool:     pool,
+		}
+		l, cleanup := setupWithOptions(t, opts)
+		defer cleanup()
+		defer l.Close()
+		logs[i] = l
+		for j := 0; j < 10; j++ {
+			_, err := l.Append([]*proto.Message{{Value: []byte(strconv.Itoa(j))}})
+			require.NoError(t, err)
+		}
+	}
+
+	for _, l := range logs {
+		deadline := time.Now().Add(5 * time.Second)
+		for l.OldestOffset() != 8 {
+			if time.Now().After(deadline) {
+				t.Fatalf("Log was not cleaned, oldest offset: %d", l.OldestOffset())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		status := l.CleanerStatus()
+		require.False(t, status.LastCleaned.IsZero())
+	}
+}
+
+// Ensure the CleanerPool cleans the queued log which was least recently
+// cleaned first and doesn't clean a log which is already being cleaned.
+func TestCleanerPoolSchedule(t *testing.T) {
+	// Don't start any goroutines so that the schedule can be inspected.
+	pool := &CleanerPool{logs: make(map[*CommitLog]*cleanerState)}
+	pool.cond = sync.NewCond(&pool.mu)
+
+	opts := func() Options {
+		return Options{Path: tempDir(t), CleanerPool: pool}
+	}
+	l1, cleanup1 := setupWithOptions(t, opts())
+	defer cleanup1()
+	defer l1.Close()
+	l2, cleanup2 := setupWithOptions(t, opts())
+	defer cleanup2()
+	defer l2.Close()
+	l3, cleanup3 := setupWithOptions(t, opts())
+	defer cleanup3()
+	defer l3.Close()
+
+	now := time.Now()
+	l1.lastCleanedAt = now
+	l2.lastCleanedAt = now.Add(-time.Minute)
+
+	pool.schedule(l1)
+	pool.schedule(l2)
+	require.Equal(t, 2, pool.Backlog())
+
+	// l2 was cleaned less recently than l1.
+	require.Equal(t, l2, pool.next())
+	require.Equal(t, 1, pool.Backlog())
+	status := l2.CleanerStatus()
+	require.False(t, status.Queued)
+	require.True(t, status.Cleaning)
+
+	// l2 is queued again but is still being cleaned. l3 was never cleaned.
+	pool.schedule(l2)
+	pool.schedule(l3)
+	require.Equal(t, l3, pool.next())
+	require.Equal(t, l1, pool.next())
+	status = l2.CleanerStatus()
+	require.True(t, status.Queued)
+	require.True(t, status.Cleaning)
+
+	// Detached logs aren't cleaned.
+	pool.remove(l2)
+	pool.Close()
+	require.Nil(t, pool.next())
+}
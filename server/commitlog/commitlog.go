@@ -27,6 +27,7 @@ const (
 	logFileSuffix               = ".log"
 	indexFileSuffix             = ".index"
 	hwFileName                  = "replication-offset-checkpoint"
+	cleanShutdownFileName       = "clean-shutdown"
 	defaultMaxSegmentBytes      = 1073741824
 	defaultHWCheckpointInterval = 5 * time.Second
 	defaultCleanerInterval      = 5 * time.Minute
@@ -40,28 +41,54 @@ type CommitLog struct {
 	compactCleaner   *CompactCleaner
 	name             string
 	mu               sync.RWMutex
+	cleanMu          sync.Mutex
 	hw               int64
 	closed           chan struct{}
 	segments         []*Segment
 	vActiveSegment   *Segment
 	hwWaiters        map[contextReader]chan struct{}
 	leaderEpochCache *leaderEpochCache
+	cleanerMu        sync.Mutex
+	lastCleanedAt    time.Time
+	lastCleanTime    time.Duration
+	flushMu          sync.Mutex
+	unflushed        int64 // Messages appended since the last flush
+	flushedOffset    int64 // Newest offset as of the last flush
+}
+
+// CleanerStatus describes the progress of cleaning a log. The queued and
+// cleaning state is only tracked for logs cleaned by a CleanerPool.
+type CleanerStatus struct {
+	Queued         bool          // Log is waiting to be cleaned
+	QueuedSince    time.Time     // Time the log was queued, if it's queued
+	Cleaning       bool          // Log is being cleaned
+	LastCleaned    time.Time     // Time the log was last cleaned, zero if never
+	LastDuration   time.Duration // Time the last cleaning took
+	BytesProcessed int64         // Bytes compaction read and wrote in the current or last cleaning
 }
 
 // Options contains settings for configuring a CommitLog.
 type Options struct {
-	Stream               string        // Stream name
-	Path                 string        // Path to log directory
-	MaxSegmentBytes      int64         // Max bytes a Segment can contain before creating a new one
-	MaxLogBytes          int64         // Retention by bytes
-	MaxLogMessages       int64         // Retention by messages
-	MaxLogAge            time.Duration // Retention by age
-	Compact              bool          // Run compaction on log clean
-	CompactMaxGoroutines int           // Max number of goroutines to use in a log compaction
-	CleanerInterval      time.Duration // Frequency to enforce retention policy
-	HWCheckpointInterval time.Duration // Frequency to checkpoint HW to disk
-	LogRollTime          time.Duration // Max time before a new log segment is rolled out.
-	Logger               logger.Logger
+	Stream                 string              // Stream name
+	Path                   string              // Path to log directory
+	MaxSegmentBytes        int64               // Max bytes a Segment can contain before creating a new one
+	MaxLogBytes            int64               // Retention by bytes
+	MaxLogMessages         int64               // Retention by messages
+	MaxLogAge              time.Duration       // Retention by age
+	Compact                bool                // Run compaction on log clean
+	CompactMaxGoroutines   int                 // Max number of goroutines to use in a log compaction
+	CompactDeleteRetention time.Duration       // Time to retain tombstones once compacted
+	CompactMinLag          time.Duration       // Min age of a segment's messages before it can be compacted
+	CompactMaxLag          time.Duration       // Max age of a segment's messages before it's compacted regardless of dirty ratio
+	CompactMinDirtyRatio   float64             // Min ratio of removable messages in a segment before it's compacted
+	CleanerInterval        time.Duration       // Frequency to enforce retention policy
+	HWCheckpointInterval   time.Duration       // Frequency to checkpoint HW to disk
+	LogRollTime            time.Duration       // Max time before a new log segment is rolled out.
+	CleanerPool            *CleanerPool        // Pool to clean the log on rather than its own goroutine, if set
+	FlushMessages          int64               // Number of messages to append before flushing the log to disk
+	FlushInterval          time.Duration       // Max time between flushes of the log to disk
+	OnFlush                func(time.Duration) // Called with the time each flush took, if set
+	Logger                 logger.Logger
 }
 
 // New creates a new CommitLog and starts a background goroutine which
@@ -96,9 +123,16 @@ func New(opts Options) (*CommitLog, error) {
 	cleaner := NewDeleteCleaner(cleanerOpts)
 
 	compactCleanerOpts := CompactCleanerOptions{
-		Name:          opts.Stream,
-		Logger:        opts.Logger,
-		MaxGoroutines: opts.CompactMaxGoroutines,
+		Name:            opts.Stream,
+		Logger:          opts.Logger,
+		MaxGoroutines:   opts.CompactMaxGoroutines,
+		DeleteRetention: opts.CompactDeleteRetention,
+		MinLag:          opts.CompactMinLag,
+		MaxLag:          opts.CompactMaxLag,
+		MinDirtyRatio:   opts.CompactMinDirtyRatio,
+	}
+	if opts.CleanerPool != nil {
+		compactCleanerOpts.Throttler = opts.CleanerPool.throttler
 	}
 	compactCleaner := NewCompactCleaner(compactCleanerOpts)
 
@@ -117,6 +151,7 @@ func New(opts Options) (*CommitLog, error) {
 		closed:           make(chan struct{}),
 		hwWaiters:        make(map[contextReader]chan struct{}),
 		leaderEpochCache: epochCache,
+		flushedOffset:    -1,
 	}
 
 	if err := l.init(); err != nil {
@@ -142,7 +177,13 @@ func New(opts Options) (*CommitLog, error) {
 	}
 
 	go l.checkpointHWLoop()
+	if l.CleanerPool != nil {
+		l.CleanerPool.add(l)
+	}
 	go l.cleanerLoop()
+	if l.FlushInterval > 0 {
+		go l.flushLoop()
+	}
 
 	return l, nil
 }
@@ -160,6 +201,13 @@ func (l *CommitLog) open() error {
 	if err != nil {
 		return errors.Wrap(err, "read dir failed")
 	}
+	// The clean-shutdown marker is written when the log is closed. If it's
+	// missing, the log was not shut down cleanly and needs to be recovered.
+	// Remove the marker so that a subsequent crash is detected.
+	cleanShutdown, err := l.removeCleanShutdownMarker()
+	if err != nil {
+		return err
+	}
 	for _, file := range files {
 		// If this file is an index file, make sure it has a corresponding .log
 		// file.
@@ -203,6 +251,10 @@ func (l *CommitLog) open() error {
 			return err
 		}
 		l.segments = append(l.segments, segment)
+	} else if !cleanShutdown {
+		if err := l.recoverSegment(l.segments[len(l.segments)-1]); err != nil {
+			return errors.Wrap(err, "failed to recover active segment")
+		}
 	}
 	activeSegment := l.segments[len(l.segments)-1]
 	atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&l.vActiveSegment)),
@@ -210,6 +262,46 @@ func (l *CommitLog) open() error {
 	return nil
 }
 
+// removeCleanShutdownMarker removes the clean-shutdown marker from the log
+// directory and returns a bool indicating if it was present.
+func (l *CommitLog) removeCleanShutdownMarker() (bool, error) {
+	err := os.Remove(filepath.Join(l.Path, cleanShutdownFileName))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "failed to remove clean shutdown marker")
+	}
+	return true, nil
+}
+
+// recoverSegment validates the messages in the given segment, truncating it
+// at the first partially written or corrupted message, and rebuilds its index.
+// This is used to repair the active segment after an unclean shutdown.
+func (l *CommitLog) recoverSegment(segment *Segment) error {
+	l.Logger.Warnf("Log %s was not shut down cleanly, recovering segment %d",
+		l.Path, segment.BaseOffset)
+	recovery, err := segment.recoverLog()
+	if err != nil {
+		return err
+	}
+	if recovery.truncatedBytes == 0 {
+		l.Logger.Infof("Recovered segment %d for log %s\n"+
+			"\tMessages: %d\n"+
+			"\tNext Offset: %d",
+			segment.BaseOffset, l.Path, recovery.messages, segment.NextOffset())
+		return nil
+	}
+	l.Logger.Warnf("Recovered segment %d for log %s\n"+
+		"\tMessages: %d\n"+
+		"\tNext Offset: %d\n"+
+		"\tTruncated Bytes: %d\n"+
+		"\tReason: %s",
+		segment.BaseOffset, l.Path, recovery.messages, segment.NextOffset(),
+		recovery.truncatedBytes, recovery.reason)
+	return nil
+}
+
 // Append writes the given batch of messages to the log and returns their
 // corresponding offsets in the log.
 func (l *CommitLog) Append(msgs []*proto.Message) ([]int64, error) {
@@ -246,6 +338,9 @@ func (l *CommitLog) append(segment *Segment, ms []byte, entries []*Entry) ([]int
 	if err := segment.WriteMessageSet(ms, entries); err != nil {
 		return nil, err
 	}
+	if err := l.appended(len(entries)); err != nil {
+		return nil, err
+	}
 	lastLeaderEpoch := l.leaderEpochCache.LastLeaderEpoch()
 	offsets := make([]int64, len(entries))
 	for i, entry := range entries {
@@ -319,8 +414,18 @@ func (l *CommitLog) OffsetForTimestamp(timestamp int64) (int64, error) {
 }
 
 // SetHighWatermark sets the high watermark on the log. All messages up to and
-// including the high watermark are considered committed.
+// including the high watermark are considered committed. If the log has a
+// flush policy, the log is flushed before the high watermark advances past
+// unflushed messages so that committed messages are on disk. If the flush
+// fails, the high watermark is left as is.
 func (l *CommitLog) SetHighWatermark(hw int64) {
+	if l.flushEnabled() && hw > l.lastFlushedOffset() {
+		if err := l.Flush(); err != nil {
+			l.Logger.Errorf("Failed to flush log %s before advancing HW to %d: %v",
+				l.Path, hw, err)
+			return
+		}
+	}
 	l.mu.Lock()
 	if hw > l.hw {
 		l.hw = hw
@@ -399,8 +504,13 @@ func (l *CommitLog) activeSegment() *Segment {
 }
 
 // Close closes each log segment file and stops the background goroutine
-// checkpointing the high watermark to disk.
+// checkpointing the high watermark to disk. Once everything is closed, a
+// clean-shutdown marker is written so that recovery can be skipped when the
+// log is reopened.
 func (l *CommitLog) Close() error {
+	if l.CleanerPool != nil {
+		l.CleanerPool.remove(l)
+	}
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	if err := l.checkpointHW(); err != nil {
@@ -412,7 +522,9 @@ func (l *CommitLog) Close() error {
 			return err
 		}
 	}
-	return nil
+	return errors.Wrap(
+		ioutil.WriteFile(filepath.Join(l.Path, cleanShutdownFileName), []byte{}, 0666),
+		"failed to write clean shutdown marker")
 }
 
 // Delete closes the log and removes all data associated with it from the
@@ -425,6 +537,9 @@ func (l *CommitLog) Delete() error {
 }
 
 // Truncate removes all messages from the log starting at the given offset.
+// If the HW is at or beyond the offset, it's moved back to the new end of the
+// log. This only happens when committed messages are truncated, e.g. after an
+// unclean leader election.
 func (l *CommitLog) Truncate(offset int64) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -493,9 +608,86 @@ func (l *CommitLog) Truncate(offset int64) error {
 	atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&l.vActiveSegment)),
 		unsafe.Pointer(activeSegment))
 	l.segments = segments
+	if l.hw >= offset {
+		l.hw = offset - 1
+	}
 	return l.leaderEpochCache.ClearLatest(offset)
 }
 
+// Purge removes all messages before the given offset from the log, advancing
+// its start offset. Segments containing only earlier messages are deleted and
+// the segment containing the offset is trimmed. If the offset is beyond the
+// end of the log, all messages are removed and the log continues from the
+// offset. This is a no-op if there are no messages before the offset. Purge
+// waits for any cleaning of the log in progress to finish.
+func (l *CommitLog) Purge(offset int64) error {
+	l.cleanMu.Lock()
+	defer l.cleanMu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	oldest := l.segments[0]
+	if offset <= oldest.BaseOffset || (!oldest.IsEmpty() && offset <= oldest.FirstOffset()) {
+		// Nothing to purge.
+		return nil
+	}
+
+	var segments []*Segment
+	if active := l.activeSegment(); offset >= active.NextOffset() {
+		// All messages are purged, so replace the segments with an empty one
+		// starting at the offset.
+		segment, err := NewSegment(l.Path, offset, l.MaxSegmentBytes, true, "")
+		if err != nil {
+			return err
+		}
+		for _, seg := range l.segments {
+			if err := seg.Delete(); err != nil {
+				return err
+			}
+		}
+		segments = []*Segment{segment}
+	} else {
+		segment, idx := findSegment(l.segments, offset)
+
+		// Delete all preceding segments.
+		for _, seg := range l.segments[:idx] {
+			if err := seg.Delete(); err != nil {
+				return err
+			}
+		}
+		segments = make([]*Segment, len(l.segments)-idx)
+		copy(segments, l.segments[idx:])
+
+		// Replace the segment containing the offset with a trimmed segment.
+		if segment.FirstOffset() < offset {
+			var (
+				ss              = NewSegmentScanner(segment)
+				newSegment, err = segment.Truncated()
+			)
+			if err != nil {
+				return err
+			}
+			for ms, _, err := ss.Scan(); err == nil; ms, _, err = ss.Scan() {
+				if ms.Offset() >= offset {
+					entries := EntriesForMessageSet(newSegment.Position(), ms)
+					if err := newSegment.WriteMessageSet(ms, entries); err != nil {
+						return err
+					}
+				}
+			}
+			if err = newSegment.Replace(segment); err != nil {
+				return err
+			}
+			segments[0] = newSegment
+		}
+	}
+	activeSegment := segments[len(segments)-1]
+	atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&l.vActiveSegment)),
+		unsafe.Pointer(activeSegment))
+	l.segments = segments
+	return l.leaderEpochCache.ClearEarliest(offset)
+}
+
 func (l *CommitLog) Segments() []*Segment {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
@@ -516,6 +708,15 @@ func (l *CommitLog) checkAndPerformSplit() (bool, error) {
 		if !activeSegment.CheckSplit(l.LogRollTime) {
 			return false, nil
 		}
+		// Flush the active segment before it's replaced since later flushes
+		// only cover the new one. Once the new segment is active, a
+		// concurrent flush of it would reset the unflushed messages and
+		// cause them to be skipped here.
+		if l.flushEnabled() {
+			if err := l.flushSegment(activeSegment); err != nil {
+				return false, err
+			}
+		}
 		if err := l.split(activeSegment); err != nil {
 			// ErrSegmentExists indicates another thread has already performed
 			// the segment split, so reload the new active segment and check
@@ -576,14 +777,114 @@ func (l *CommitLog) cleanerLoop() {
 			continue
 		}
 
+		// If the log is cleaned by a pool, queue it rather than cleaning it
+		// here.
+		if l.CleanerPool != nil {
+			l.CleanerPool.schedule(l)
+			continue
+		}
+
 		if err := l.Clean(); err != nil {
 			l.Logger.Errorf("Failed to clean log %s: %v", l.Path, err)
 		}
 	}
 }
 
+// Flush commits any messages appended to the active segment since the last
+// flush to disk.
+func (l *CommitLog) Flush() error {
+	return l.flushSegment(l.activeSegment())
+}
+
+// flushSegment syncs the given segment to disk if any messages were appended
+// since the last flush.
+func (l *CommitLog) flushSegment(segment *Segment) error {
+	l.flushMu.Lock()
+	defer l.flushMu.Unlock()
+	if l.unflushed == 0 {
+		return nil
+	}
+	var (
+		newest = segment.NextOffset() - 1
+		start  = time.Now()
+	)
+	if err := segment.Sync(); err != nil {
+		return errors.Wrap(err, "failed to flush segment")
+	}
+	if l.OnFlush != nil {
+		l.OnFlush(time.Since(start))
+	}
+	l.unflushed = 0
+	l.flushedOffset = newest
+	return nil
+}
+
+// appended records that the given number of messages were appended to the log
+// and flushes it if the flush message threshold is reached.
+func (l *CommitLog) appended(n int) error {
+	if !l.flushEnabled() {
+		return nil
+	}
+	l.flushMu.Lock()
+	l.unflushed += int64(n)
+	flush := l.FlushMessages > 0 && l.unflushed >= l.FlushMessages
+	l.flushMu.Unlock()
+	if flush {
+		return l.Flush()
+	}
+	return nil
+}
+
+// lastFlushedOffset returns the newest offset as of the last flush.
+func (l *CommitLog) lastFlushedOffset() int64 {
+	l.flushMu.Lock()
+	defer l.flushMu.Unlock()
+	return l.flushedOffset
+}
+
+// flushEnabled indicates if the log has a flush policy. Without one, the log
+// is left to the operating system to write back and is only synced when
+// closed.
+func (l *CommitLog) flushEnabled() bool {
+	return l.FlushMessages > 0 || l.FlushInterval > 0
+}
+
+// flushLoop is a long-running loop which flushes the log on the flush
+// interval until the log is closed.
+func (l *CommitLog) flushLoop() {
+	ticker := time.NewTicker(l.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-l.closed:
+			return
+		}
+		if err := l.Flush(); err != nil {
+			l.Logger.Errorf("Failed to flush log %s: %v", l.Path, err)
+		}
+	}
+}
+
+// SetRetention updates the log retention policy. The new policy is enforced
+// the next time the log is cleaned.
+func (l *CommitLog) SetRetention(maxBytes, maxMessages int64, maxAge time.Duration) {
+	l.deleteCleaner.SetRetention(maxBytes, maxMessages, maxAge)
+}
+
 // Clean applies retention and compaction rules against the log, if applicable.
 func (l *CommitLog) Clean() error {
+	// Cleaning works on a snapshot of the segments, so it can't be run
+	// concurrently with a purge which removes segments.
+	l.cleanMu.Lock()
+	defer l.cleanMu.Unlock()
+	start := time.Now()
+	defer func() {
+		l.cleanerMu.Lock()
+		l.lastCleanedAt = time.Now()
+		l.lastCleanTime = l.lastCleanedAt.Sub(start)
+		l.cleanerMu.Unlock()
+	}()
 	l.mu.RLock()
 	oldSegments := l.segments
 	l.mu.RUnlock()
@@ -612,16 +913,37 @@ func (l *CommitLog) Clean() error {
 	return err
 }
 
+// lastCleaned returns the time the log was last cleaned and how long it took.
+func (l *CommitLog) lastCleaned() (time.Time, time.Duration) {
+	l.cleanerMu.Lock()
+	defer l.cleanerMu.Unlock()
+	return l.lastCleanedAt, l.lastCleanTime
+}
+
+// CleanerStatus returns the progress of cleaning the log.
+func (l *CommitLog) CleanerStatus() CleanerStatus {
+	status := CleanerStatus{BytesProcessed: l.compactCleaner.BytesProcessed()}
+	status.LastCleaned, status.LastDuration = l.lastCleaned()
+	if l.CleanerPool != nil {
+		l.CleanerPool.status(l, &status)
+	}
+	return status
+}
+
 // rebaseSegments adds the segments in from to the end of the slice of segments
-// in to and adds any leader epoch offsets to the given leaderEpochCache.
+// in to and adds any leader epoch offsets to the given leaderEpochCache, if
+// not nil.
 func (l *CommitLog) rebaseSegments(from, to []*Segment, epochCache *leaderEpochCache) []*Segment {
 	for _, seg := range from {
 		to = append(to, seg)
 	}
 	// Rebase any leader epoch offsets also. We don't check the error returned
 	// here because Rebase can't return an error since epochCache is not
-	// file-backed.
-	epochCache.Rebase(l.leaderEpochCache, from[0].BaseOffset)
+	// file-backed. If compaction didn't run, there is no epochCache and the
+	// log's own cache already contains the offsets.
+	if epochCache != nil {
+		epochCache.Rebase(l.leaderEpochCache, from[0].BaseOffset)
+	}
 	return to
 }
 
@@ -1,8 +1,10 @@
 package commitlog
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -124,6 +126,110 @@ func TestCommitLogRecoverHW(t *testing.T) {
 	require.Equal(t, int64(100), l.HighWatermark())
 }
 
+// Ensure the clean-shutdown marker is written when the log is closed and
+// removed when it's reopened.
+func TestCommitLogCleanShutdownMarker(t *testing.T) {
+	opts := Options{Path: tempDir(t)}
+	l, cleanup := setupWithOptions(t, opts)
+	defer cleanup()
+	marker := filepath.Join(opts.Path, cleanShutdownFileName)
+	require.False(t, exists(marker))
+
+	require.NoError(t, l.Close())
+	require.True(t, exists(marker))
+
+	l, cleanup = setupWithOptions(t, opts)
+	defer cleanup()
+	defer l.Close()
+	require.False(t, exists(marker))
+}
+
+// Ensure the active segment is truncated at a partially written message and
+// its index is rebuilt when the log was not shut down cleanly.
+func TestCommitLogRecoverUncleanShutdownPartialMessage(t *testing.T) {
+	opts := Options{Path: tempDir(t)}
+	l, cleanup := setupWithOptions(t, opts)
+	defer cleanup()
+	_, err := l.Append(msgs)
+	require.NoError(t, err)
+	logFile := l.activeSegment().logPath()
+	require.NoError(t, l.Close())
+
+	// Simulate a crash in the middle of a write.
+	require.NoError(t, os.Remove(filepath.Join(opts.Path, cleanShutdownFileName)))
+	f, err := os.OpenFile(logFile, os.O_WRONLY|os.O_APPEND, 0666)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 0, 0, 0, 0, 4, 0, 0})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	l, cleanup = setupWithOptions(t, opts)
+	defer cleanup()
+	defer l.Close()
+	require.Equal(t, int64(len(msgs)-1), l.NewestOffset())
+
+	// Ensure we can continue appending to the log.
+	offsets, err := l.Append([]*proto.Message{&proto.Message{Value: []byte("five")}})
+	require.NoError(t, err)
+	require.Equal(t, []int64{int64(len(msgs))}, offsets)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r, err := l.NewReader(0, true)
+	require.NoError(t, err)
+	headers := make([]byte, 28)
+	for i, exp := range msgs {
+		msg, offset, _, _, err := r.ReadMessage(ctx, headers)
+		require.NoError(t, err)
+		require.Equal(t, int64(i), offset)
+		compareMessages(t, exp, msg)
+	}
+	msg, offset, _, _, err := r.ReadMessage(ctx, headers)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(msgs)), offset)
+	require.Equal(t, []byte("five"), msg.Value())
+}
+
+// Ensure the active segment is truncated at the first message whose CRC does
+// not match when the log was not shut down cleanly.
+func TestCommitLogRecoverUncleanShutdownCorruptedMessage(t *testing.T) {
+	opts := Options{Path: tempDir(t)}
+	l, cleanup := setupWithOptions(t, opts)
+	defer cleanup()
+	_, err := l.Append(msgs)
+	require.NoError(t, err)
+	logFile := l.activeSegment().logPath()
+	require.NoError(t, l.Close())
+
+	// Corrupt the value of the third message.
+	require.NoError(t, os.Remove(filepath.Join(opts.Path, cleanShutdownFileName)))
+	data, err := ioutil.ReadFile(logFile)
+	require.NoError(t, err)
+	idx := bytes.Index(data, []byte("three"))
+	require.True(t, idx > 0)
+	data[idx] = 'T'
+	require.NoError(t, ioutil.WriteFile(logFile, data, 0666))
+
+	l, cleanup = setupWithOptions(t, opts)
+	defer cleanup()
+	defer l.Close()
+	require.Equal(t, int64(0), l.OldestOffset())
+	require.Equal(t, int64(1), l.NewestOffset())
+	require.Equal(t, int64(2), l.activeSegment().MessageCount())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r, err := l.NewReader(0, true)
+	require.NoError(t, err)
+	headers := make([]byte, 28)
+	for i, exp := range msgs[:2] {
+		msg, offset, _, _, err := r.ReadMessage(ctx, headers)
+		require.NoError(t, err)
+		require.Equal(t, int64(i), offset)
+		compareMessages(t, exp, msg)
+	}
+}
+
 func BenchmarkCommitLog(b *testing.B) {
 	var err error
 	l, cleanup := setup(b)
@@ -433,6 +539,210 @@ func TestTruncate(t *testing.T) {
 	require.Equal(t, int64(5), l.LastOffsetForLeaderEpoch(1))
 }
 
+// Ensure Truncate moves the HW back to the new end of the log when committed
+// messages are truncated and leaves it alone otherwise.
+func TestTruncateHighWatermark(t *testing.T) {
+	l, cleanup := setupWithOptions(t, Options{
+		Path:            tempDir(t),
+		MaxSegmentBytes: 6,
+	})
+	defer l.Close()
+	defer cleanup()
+
+	for i := 0; i < 10; i++ {
+		_, err := l.Append([]*proto.Message{&proto.Message{
+			Value:       []byte(strconv.Itoa(i)),
+			Timestamp:   time.Now().UnixNano(),
+			LeaderEpoch: 1,
+		}})
+		require.NoError(t, err)
+	}
+	l.SetHighWatermark(5)
+
+	require.NoError(t, l.Truncate(8))
+	require.Equal(t, int64(7), l.NewestOffset())
+	require.Equal(t, int64(5), l.HighWatermark())
+
+	require.NoError(t, l.Truncate(3))
+	require.Equal(t, int64(2), l.NewestOffset())
+	require.Equal(t, int64(2), l.HighWatermark())
+}
+
+// Ensure Purge removes messages before the given offset, updates the leader
+// epoch cache, and continues the log from the offset if it's beyond the end of
+// the log.
+func TestPurge(t *testing.T) {
+	for _, test := range segmentSizeTests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := Options{
+				Path:            tempDir(t),
+				MaxSegmentBytes: test.segmentSize,
+			}
+			l, cleanup := setupWithOptions(t, opts)
+			defer cleanup()
+
+			// Add some messages.
+			for i := 0; i < 10; i++ {
+				_, err := l.Append([]*proto.Message{&proto.Message{
+					Value:       []byte(strconv.Itoa(i)),
+					Timestamp:   time.Now().UnixNano(),
+					LeaderEpoch: uint64(i/5 + 1),
+				}})
+				require.NoError(t, err)
+			}
+
+			// Purge some messages.
+			require.NoError(t, l.Purge(3))
+			require.Equal(t, int64(3), l.OldestOffset())
+			require.Equal(t, int64(9), l.NewestOffset())
+			require.Equal(t, int64(3), l.leaderEpochCache.earliestOffset())
+			require.Equal(t, uint64(2), l.LastLeaderEpoch())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			r, err := l.NewReader(0, true)
+			require.NoError(t, err)
+			headers := make([]byte, 28)
+			for i := 3; i < 10; i++ {
+				msg, offset, _, _, err := r.ReadMessage(ctx, headers)
+				require.NoError(t, err)
+				require.Equal(t, int64(i), offset)
+				require.Equal(t, []byte(strconv.Itoa(i)), msg.Value())
+			}
+
+			// Purging before the start of the log is a no-op.
+			require.NoError(t, l.Purge(2))
+			require.Equal(t, int64(3), l.OldestOffset())
+
+			// Purge beyond the end of the log.
+			require.NoError(t, l.Purge(15))
+			require.Equal(t, int64(-1), l.OldestOffset())
+			require.Equal(t, int64(14), l.NewestOffset())
+			require.Len(t, l.Segments(), 1)
+			require.Equal(t, int64(15), l.leaderEpochCache.earliestOffset())
+			require.Equal(t, uint64(2), l.LastLeaderEpoch())
+
+			// The log continues from the purge offset, including after it's
+			// reopened.
+			offsets, err := l.Append([]*proto.Message{&proto.Message{
+				Value:       []byte("15"),
+				Timestamp:   time.Now().UnixNano(),
+				LeaderEpoch: 3,
+			}})
+			require.NoError(t, err)
+			require.Equal(t, []int64{15}, offsets)
+			require.NoError(t, l.Close())
+
+			l, err = New(opts)
+			require.NoError(t, err)
+			defer l.Close()
+			require.Equal(t, int64(15), l.OldestOffset())
+			require.Equal(t, int64(15), l.NewestOffset())
+		})
+	}
+}
+
+// Ensure the log is flushed once the flush message threshold is reached, when
+// a segment is rolled, and before the HW advances past unflushed messages.
+func TestFlushMessages(t *testing.T) {
+	var flushes int
+	opts := Options{
+		Path:          tempDir(t),
+		FlushMessages: 3,
+		OnFlush:       func(time.Duration) { flushes++ },
+	}
+	l, cleanup := setupWithOptions(t, opts)
+	defer cleanup()
+	defer l.Close()
+
+	_, err := l.Append(msgs[:2])
+	require.NoError(t, err)
+	require.Equal(t, 0, flushes)
+	require.Equal(t, int64(-1), l.lastFlushedOffset())
+
+	_, err = l.Append(msgs[2:3])
+	require.NoError(t, err)
+	require.Equal(t, 1, flushes)
+	require.Equal(t, int64(2), l.lastFlushedOffset())
+
+	// Advancing the HW over flushed messages doesn't flush.
+	l.SetHighWatermark(2)
+	require.Equal(t, 1, flushes)
+
+	// Advancing the HW past unflushed messages flushes.
+	_, err = l.Append(msgs[3:4])
+	require.NoError(t, err)
+	require.Equal(t, 1, flushes)
+	l.SetHighWatermark(3)
+	require.Equal(t, 2, flushes)
+	require.Equal(t, int64(3), l.lastFlushedOffset())
+	require.Equal(t, int64(3), l.HighWatermark())
+}
+
+// Ensure the former active segment is flushed before a new segment is rolled
+// so that a concurrent flush of the new active segment can't skip it.
+func TestFlushSegmentRoll(t *testing.T) {
+	var (
+		l        *CommitLog
+		flushes  int
+		segments int
+	)
+	opts := Options{
+		Path:            tempDir(t),
+		MaxSegmentBytes: 6,
+		FlushMessages:   10,
+		OnFlush: func(time.Duration) {
+			flushes++
+			segments = len(l.Segments())
+		},
+	}
+	l, cleanup := setupWithOptions(t, opts)
+	defer cleanup()
+	defer l.Close()
+
+	_, err := l.Append(msgs[:1])
+	require.NoError(t, err)
+	require.Equal(t, 0, flushes)
+
+	// The first segment is full, so this rolls a new one.
+	_, err = l.Append(msgs[1:2])
+	require.NoError(t, err)
+	require.Len(t, l.Segments(), 2)
+	require.Equal(t, 1, flushes)
+	require.Equal(t, 1, segments)
+	require.Equal(t, int64(0), l.lastFlushedOffset())
+}
+
+// Ensure the log is flushed on the flush interval when there are unflushed
+// messages.
+func TestFlushInterval(t *testing.T) {
+	flushed := make(chan struct{}, 10)
+	opts := Options{
+		Path:          tempDir(t),
+		FlushInterval: 10 * time.Millisecond,
+		OnFlush:       func(time.Duration) { flushed <- struct{}{} },
+	}
+	l, cleanup := setupWithOptions(t, opts)
+	defer cleanup()
+	defer l.Close()
+
+	_, err := l.Append(msgs)
+	require.NoError(t, err)
+	select {
+	case <-flushed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Log was not flushed")
+	}
+	require.Equal(t, int64(3), l.lastFlushedOffset())
+
+	// Nothing is flushed without new messages.
+	select {
+	case <-flushed:
+		t.Fatal("Unexpected flush")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
 func setup(t require.TestingT) (*CommitLog, func()) {
 	opts := Options{
 		Path:            tempDir(t),
@@ -2,6 +2,7 @@ package commitlog
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -14,15 +15,33 @@ const defaultCompactMaxGoroutines = 10
 // CompactCleanerOptions contains configuration settings for the
 // CompactCleaner.
 type CompactCleanerOptions struct {
-	Logger        logger.Logger
-	Name          string
-	MaxGoroutines int
+	Logger          logger.Logger
+	Name            string
+	MaxGoroutines   int
+	DeleteRetention time.Duration
+	MinLag          time.Duration
+	MaxLag          time.Duration
+	MinDirtyRatio   float64
+	Throttler       *Throttler
 }
 
 // CompactCleaner implements the compaction policy which replaces segments with
 // compacted ones, i.e. retaining only the last message for a given key.
+// Messages which have expired are also removed. A message with a key and a nil
+// value is a tombstone which deletes the key. Once compaction has removed the
+// earlier messages for the key, the tombstone itself is removed after the
+// DeleteRetention period, measured from its timestamp, has passed.
+//
+// Segments are only compacted once their last message is older than MinLag and
+// at least MinDirtyRatio of their messages would be removed. If MaxLag is set,
+// segments whose first message is older than it are compacted regardless of
+// their dirty ratio.
+//
+// If a Throttler is set, the segment reads and writes performed by compaction
+// are throttled by it.
 type CompactCleaner struct {
 	CompactCleanerOptions
+	bytesProcessed int64
 }
 
 // NewCompactCleaner returns a new Cleaner which performs log compaction by
@@ -32,15 +51,29 @@ func NewCompactCleaner(opts CompactCleanerOptions) *CompactCleaner {
 	if opts.MaxGoroutines == 0 {
 		opts.MaxGoroutines = defaultCompactMaxGoroutines
 	}
-	return &CompactCleaner{opts}
+	return &CompactCleaner{CompactCleanerOptions: opts}
+}
+
+// BytesProcessed returns the number of bytes read and written by the current
+// compaction or, if compaction isn't running, the last one.
+func (c *CompactCleaner) BytesProcessed() int64 {
+	return atomic.LoadInt64(&c.bytesProcessed)
+}
+
+// io records that compaction read or wrote the given number of bytes and
+// throttles it if needed.
+func (c *CompactCleaner) io(n int) {
+	atomic.AddInt64(&c.bytesProcessed, int64(n))
+	c.Throttler.Throttle(n)
 }
 
 // Compact performs log compaction by rewriting segments such that they contain
-// only the last message for a given key. Compaction is applied to all segments
-// up to but excluding the active (last) segment or the provided HW, whichever
-// comes first. This returns the compacted segments and a leaderEpochCache
-// containing the earliest offsets for each leader epoch or nil if nothing was
-// compacted.
+// only the last message for a given key and no expired messages. Compaction is
+// applied to all segments up to but excluding the active (last) segment or the
+// provided HW, whichever comes first, which are eligible based on the
+// compaction lag and dirty ratio settings. This returns the compacted segments
+// and a leaderEpochCache containing the earliest offsets for each leader epoch
+// or nil if nothing was compacted.
 func (c *CompactCleaner) Compact(hw int64, segments []*Segment) ([]*Segment,
 	*leaderEpochCache, error) {
 
@@ -49,14 +82,17 @@ func (c *CompactCleaner) Compact(hw int64, segments []*Segment) ([]*Segment,
 	}
 
 	c.Logger.Debugf("Compacting log %s", c.Name)
+	atomic.StoreInt64(&c.bytesProcessed, 0)
 	before := time.Now()
 	compacted, epochCache, removed, err := c.compact(hw, segments)
 	if err == nil {
 		c.Logger.Debugf("Finished compacting log %s\n"+
 			"\tMessages Removed: %d\n"+
 			"\tSegments: %d -> %d\n"+
+			"\tBytes Processed: %d\n"+
 			"\tDuration: %s",
-			c.Name, removed, len(segments), len(compacted), time.Since(before))
+			c.Name, removed, len(segments), len(compacted), c.BytesProcessed(),
+			time.Since(before))
 	}
 
 	return compacted, epochCache, errors.Wrap(err, "failed to compact log")
@@ -87,17 +123,27 @@ func (c *CompactCleaner) compact(hw int64, segments []*Segment) ([]*Segment,
 
 	// Compact messages up to the last segment or HW, whichever is first, by
 	// scanning keys and retaining only the latest.
-	// TODO: Implement option for configuring minimum compaction lag.
 	var (
 		compacted  = make([]*Segment, 0, len(segments))
 		epochCache = newLeaderEpochCacheNoFile(c.Name, c.Logger)
 		removed    = 0
 		keyOffsets = c.scanKeys(hw, segments)
+		now        = timestamp()
 	)
 
 	// Write new segments. Skip the last segment since we will not compact it.
 	// TODO: Join segments that are below the bytes limit.
 	for _, seg := range segments[:len(segments)-1] {
+		stats := c.segmentStats(seg, keyOffsets, hw, now)
+		if !c.shouldCompact(stats, now) {
+			// Leave the segment as it is.
+			if err := assignLeaderEpochs(epochCache, seg); err != nil {
+				return nil, nil, 0, err
+			}
+			compacted = append(compacted, seg)
+			continue
+		}
+
 		cleaned, err := seg.Cleaned()
 		if err != nil {
 			return nil, nil, 0, err
@@ -105,23 +151,16 @@ func (c *CompactCleaner) compact(hw int64, segments []*Segment) ([]*Segment,
 		ss := NewSegmentScanner(seg)
 		for ms, _, err := ss.Scan(); err == nil; ms, _, err = ss.Scan() {
 			var (
-				offset       = ms.Offset()
-				key          = ms.Message().Key()
-				leaderEpoch  = ms.LeaderEpoch()
-				latest, ok   = keyOffsets.Load(string(key))
-				latestOffset int64
+				offset      = ms.Offset()
+				leaderEpoch = ms.LeaderEpoch()
 			)
-			if ok {
-				latestOffset = latest.(*keyOffset).get()
-			}
-
-			// Retain all messages with no keys and last message for each key.
-			// Also retain all messages after the HW.
-			if key == nil || offset == latestOffset || offset >= hw {
+			c.io(len(ms))
+			if c.retain(ms, latestKeyOffset(keyOffsets, ms), hw, now) {
 				entries := EntriesForMessageSet(cleaned.Position(), ms)
 				if err := cleaned.WriteMessageSet(ms, entries); err != nil {
 					return nil, nil, 0, err
 				}
+				c.io(len(ms))
 				// Maintain start offset for each new leader epoch.
 				if leaderEpoch > epochCache.LastLeaderEpoch() {
 					if err := epochCache.Assign(leaderEpoch, offset); err != nil {
@@ -152,17 +191,118 @@ func (c *CompactCleaner) compact(hw int64, segments []*Segment) ([]*Segment,
 	compacted = append(compacted, last)
 
 	// Maintain start offset for each new leader epoch for the last segment.
-	ss := NewSegmentScanner(last)
+	if err := assignLeaderEpochs(epochCache, last); err != nil {
+		return nil, nil, 0, err
+	}
+
+	return compacted, epochCache, removed, nil
+}
+
+// segmentStats contains the number of messages in a segment, how many of them
+// compaction would remove, and the timestamps of the first and last messages.
+type segmentStats struct {
+	messages       int
+	dirty          int
+	firstTimestamp int64
+	lastTimestamp  int64
+}
+
+// dirtyRatio returns the ratio of messages in the segment which compaction
+// would remove.
+func (s segmentStats) dirtyRatio() float64 {
+	if s.messages == 0 {
+		return 0
+	}
+	return float64(s.dirty) / float64(s.messages)
+}
+
+// segmentStats scans the given segment to determine how much of it compaction
+// would remove. This read only decides whether to compact the segment, so it
+// isn't throttled or counted as bytes processed, unlike the key scan and the
+// rewrite of the segment.
+func (c *CompactCleaner) segmentStats(seg *Segment, keyOffsets *sync.Map, hw, now int64) segmentStats {
+	var (
+		stats = segmentStats{}
+		ss    = NewSegmentScanner(seg)
+	)
+	for ms, _, err := ss.Scan(); err == nil; ms, _, err = ss.Scan() {
+		if stats.messages == 0 {
+			stats.firstTimestamp = ms.Timestamp()
+		}
+		stats.lastTimestamp = ms.Timestamp()
+		stats.messages++
+		if !c.retain(ms, latestKeyOffset(keyOffsets, ms), hw, now) {
+			stats.dirty++
+		}
+	}
+	return stats
+}
+
+// shouldCompact indicates if a segment with the given stats should be
+// compacted. A segment is protected from compaction until its last message is
+// older than the minimum compaction lag. After that, it's compacted once its
+// dirty ratio reaches the minimum dirty ratio or, if set, once its first
+// message is older than the maximum compaction lag, whichever comes first.
+func (c *CompactCleaner) shouldCompact(stats segmentStats, now int64) bool {
+	if stats.dirty == 0 {
+		return false
+	}
+	if c.MinLag > 0 && stats.lastTimestamp > now-int64(c.MinLag) {
+		return false
+	}
+	if c.MaxLag > 0 && stats.firstTimestamp <= now-int64(c.MaxLag) {
+		return true
+	}
+	return stats.dirtyRatio() >= c.MinDirtyRatio
+}
+
+// latestKeyOffset returns the offset of the latest message with the same key
+// as the given message.
+func latestKeyOffset(keyOffsets *sync.Map, ms MessageSet) int64 {
+	latest, ok := keyOffsets.Load(string(ms.Message().Key()))
+	if !ok {
+		return 0
+	}
+	return latest.(*keyOffset).get()
+}
+
+// assignLeaderEpochs maintains the start offset for each new leader epoch in
+// the given segment.
+func assignLeaderEpochs(epochCache *leaderEpochCache, seg *Segment) error {
+	ss := NewSegmentScanner(seg)
 	for ms, _, err := ss.Scan(); err == nil; ms, _, err = ss.Scan() {
 		leaderEpoch := ms.LeaderEpoch()
 		if leaderEpoch > epochCache.LastLeaderEpoch() {
 			if err := epochCache.Assign(leaderEpoch, ms.Offset()); err != nil {
-				return nil, nil, 0, err
+				return err
 			}
 		}
 	}
+	return nil
+}
 
-	return compacted, epochCache, removed, nil
+// retain indicates if compaction should retain the given message. All
+// unexpired messages with no keys and the last message for each key are
+// retained, unless it's a tombstone older than the delete retention period.
+// All messages after the HW are also retained.
+func (c *CompactCleaner) retain(ms MessageSet, latestOffset, hw, now int64) bool {
+	offset := ms.Offset()
+	if offset >= hw {
+		return true
+	}
+	msg := ms.Message()
+	if expired(msg, now) {
+		return false
+	}
+	key := msg.Key()
+	if key == nil {
+		return true
+	}
+	if offset != latestOffset {
+		return false
+	}
+	tombstone := msg.Value() == nil
+	return !tombstone || ms.Timestamp()+int64(c.DeleteRetention) > now
 }
 
 func (c *CompactCleaner) scanKeys(hw int64, segments []*Segment) *sync.Map {
@@ -199,6 +339,7 @@ LOOP:
 			if offset > hw {
 				break LOOP
 			}
+			c.io(len(ms))
 			curr, loaded := keyOffsets.LoadOrStore(
 				string(ms.Message().Key()), &keyOffset{offset: offset})
 			if loaded {
@@ -209,6 +350,13 @@ LOOP:
 	wg.Done()
 }
 
+// expired indicates if the message has an expiration at or before the given
+// time.
+func expired(msg Message, now int64) bool {
+	expiration, ok := TimestampHeader(msg.Headers(), ExpirationHeader)
+	return ok && expiration <= now
+}
+
 func cleanupEmptySegment(new, old *Segment) error {
 	// Delete the new segment if it's empty.
 	if err := new.Delete(); err != nil {
@@ -3,7 +3,9 @@ package commitlog
 import (
 	"fmt"
 	"math/rand"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
@@ -190,6 +192,231 @@ func TestCompactCleanerNoKeys(t *testing.T) {
 	}
 }
 
+// Ensure Compact removes expired messages, with or without keys, up to the HW.
+func TestCompactCleanerExpired(t *testing.T) {
+	opts := Options{
+		Path:            tempDir(t),
+		MaxSegmentBytes: 100,
+		Compact:         true,
+	}
+	l, cleanup := setupWithOptions(t, opts)
+	defer cleanup()
+
+	var (
+		expired = map[string][]byte{
+			ExpirationHeader: []byte(strconv.FormatInt(time.Now().Add(-time.Hour).UnixNano(), 10)),
+		}
+		unexpired = map[string][]byte{
+			ExpirationHeader: []byte(strconv.FormatInt(time.Now().Add(time.Hour).UnixNano(), 10)),
+		}
+		msgs = []*proto.Message{
+			&proto.Message{Key: []byte("foo"), Value: []byte("first"), Headers: expired},
+			&proto.Message{Value: []byte("first"), Headers: expired},
+			&proto.Message{Key: []byte("bar"), Value: []byte("first"), Headers: unexpired},
+			&proto.Message{Value: []byte("second"), Headers: unexpired},
+			&proto.Message{Key: []byte("baz"), Value: []byte("first")},
+			&proto.Message{Key: []byte("baz"), Value: []byte("second"), Headers: expired},
+			&proto.Message{Value: []byte("third"), Headers: expired},
+		}
+	)
+	for _, msg := range msgs {
+		offsets, err := l.Append([]*proto.Message{msg})
+		require.NoError(t, err)
+		l.SetHighWatermark(offsets[0])
+	}
+
+	// Force a compaction.
+	require.NoError(t, l.Clean())
+
+	expected := []*expectedMsg{
+		&expectedMsg{Offset: 2, Msg: msgs[2]},
+		&expectedMsg{Offset: 3, Msg: msgs[3]},
+		// This one is present because it's in the active segment.
+		&expectedMsg{Offset: 6, Msg: msgs[6]},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r, err := l.NewReader(0, true)
+	require.NoError(t, err)
+	headers := make([]byte, 28)
+	for _, exp := range expected {
+		msg, offset, _, _, err := r.ReadMessage(ctx, headers)
+		require.NoError(t, err)
+		require.Equal(t, exp.Offset, offset)
+		compareMessages(t, exp.Msg, msg)
+	}
+}
+
+// Ensure Compact removes earlier messages for keys which have a tombstone and
+// removes the tombstones once the delete retention period has passed.
+func TestCompactCleanerTombstones(t *testing.T) {
+	entries := []keyValue{
+		keyValue{[]byte("foo"), []byte("first")},
+		keyValue{[]byte("bar"), []byte("first")},
+		keyValue{[]byte("foo"), nil},
+		keyValue{[]byte("bar"), []byte("second")},
+		keyValue{[]byte("baz"), []byte("first")},
+		keyValue{[]byte("qux"), nil},
+		keyValue{[]byte("baz"), []byte("second")},
+		keyValue{[]byte("quux"), []byte("first")},
+	}
+	tests := []struct {
+		deleteRetention time.Duration
+		expectedOffsets []int64
+	}{
+		// Tombstones are retained until the delete retention period passes.
+		{time.Hour, []int64{2, 3, 5, 6, 7}},
+		// Tombstones are removed along with the earlier messages.
+		{0, []int64{3, 6, 7}},
+	}
+	for _, test := range tests {
+		opts := Options{
+			Path:                   tempDir(t),
+			MaxSegmentBytes:        100,
+			Compact:                true,
+			CompactDeleteRetention: test.deleteRetention,
+		}
+		l, cleanup := setupWithOptions(t, opts)
+		for _, entry := range entries {
+			offsets, err := l.Append([]*proto.Message{&proto.Message{
+				Key:       entry.key,
+				Value:     entry.value,
+				Timestamp: time.Now().UnixNano(),
+			}})
+			require.NoError(t, err)
+			l.SetHighWatermark(offsets[0])
+		}
+
+		// Force a compaction.
+		require.NoError(t, l.Clean())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		r, err := l.NewReader(0, true)
+		require.NoError(t, err)
+		headers := make([]byte, 28)
+		for _, expectedOffset := range test.expectedOffsets {
+			msg, offset, _, _, err := r.ReadMessage(ctx, headers)
+			require.NoError(t, err)
+			require.Equal(t, expectedOffset, offset)
+			entry := entries[offset]
+			compareMessages(t, &proto.Message{Key: entry.key, Value: entry.value}, msg)
+		}
+		cancel()
+		cleanup()
+	}
+}
+
+// Ensure Compact only compacts segments which satisfy the compaction lag and
+// dirty ratio settings.
+func TestCompactCleanerLagAndDirtyRatio(t *testing.T) {
+	var (
+		now     = time.Now()
+		old     = now.Add(-2 * time.Hour).UnixNano()
+		recent  = now.UnixNano()
+		entries = []struct {
+			keyValue
+			timestamp int64
+		}{
+			// Segment 0 (all messages superseded).
+			{keyValue{[]byte("foo"), []byte("a")}, old},
+			{keyValue{[]byte("bar"), []byte("a")}, old},
+			// Segment 1 (half the messages superseded).
+			{keyValue{[]byte("foo"), []byte("b")}, old},
+			{keyValue{[]byte("baz"), []byte("a")}, recent},
+			// Segment 2 (no messages superseded).
+			{keyValue{[]byte("bar"), []byte("b")}, recent},
+			{keyValue{[]byte("foo"), []byte("c")}, recent},
+			// Active segment.
+			{keyValue{[]byte("qux"), []byte("a")}, recent},
+		}
+	)
+	tests := []struct {
+		name            string
+		minLag          time.Duration
+		maxLag          time.Duration
+		minDirtyRatio   float64
+		expectedOffsets []int64
+	}{
+		{"defaults", 0, 0, 0, []int64{3, 4, 5, 6}},
+		{"dirty ratio", 0, 0, 0.6, []int64{2, 3, 4, 5, 6}},
+		{"min lag", time.Hour, 0, 0, []int64{2, 3, 4, 5, 6}},
+		{"max lag", 0, time.Hour, 1, []int64{3, 4, 5, 6}},
+		{"max lag not reached", 0, 3 * time.Hour, 1, []int64{2, 3, 4, 5, 6}},
+	}
+	for _, test := range tests {
+		// Each segment holds two messages.
+		opts := Options{
+			Path:                 tempDir(t),
+			MaxSegmentBytes:      96,
+			Compact:              true,
+			CompactMinLag:        test.minLag,
+			CompactMaxLag:        test.maxLag,
+			CompactMinDirtyRatio: test.minDirtyRatio,
+		}
+		l, cleanup := setupWithOptions(t, opts)
+		for _, entry := range entries {
+			offsets, err := l.Append([]*proto.Message{&proto.Message{
+				Key:       entry.key,
+				Value:     entry.value,
+				Timestamp: entry.timestamp,
+			}})
+			require.NoError(t, err)
+			l.SetHighWatermark(offsets[0])
+		}
+		require.Len(t, l.Segments(), 4, test.name)
+
+		// Force a compaction.
+		require.NoError(t, l.Clean(), test.name)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		r, err := l.NewReader(0, true)
+		require.NoError(t, err)
+		headers := make([]byte, 28)
+		for _, expectedOffset := range test.expectedOffsets {
+			msg, offset, _, _, err := r.ReadMessage(ctx, headers)
+			require.NoError(t, err)
+			require.Equal(t, expectedOffset, offset, test.name)
+			entry := entries[offset]
+			compareMessages(t, &proto.Message{Key: entry.key, Value: entry.value}, msg)
+		}
+		cancel()
+		cleanup()
+	}
+}
+
+// Ensure reading a segment to decide whether to compact it isn't counted as
+// bytes processed, so a compaction which leaves every segment as it is only
+// counts the key scan.
+func TestCompactCleanerBytesProcessed(t *testing.T) {
+	opts := Options{
+		Path:            tempDir(t),
+		MaxSegmentBytes: 100,
+	}
+	l, cleanup := setupWithOptions(t, opts)
+	defer cleanup()
+
+	// Append messages with unique keys so that nothing is compacted.
+	entries := make([]keyValue, 6)
+	for i := range entries {
+		entries[i] = keyValue{[]byte(strconv.Itoa(i)), []byte("value")}
+	}
+	appendToLog(t, l, entries, true)
+	segments := l.Segments()
+	require.True(t, len(segments) > 1)
+
+	var size int64
+	for _, seg := range segments {
+		size += seg.Position()
+	}
+
+	cleaner := NewCompactCleaner(CompactCleanerOptions{Name: "foo", Logger: noopLogger()})
+	compacted, _, err := cleaner.Compact(l.HighWatermark(), segments)
+	require.NoError(t, err)
+	require.Equal(t, segments, compacted)
+	require.Equal(t, size, cleaner.BytesProcessed())
+}
+
 // Ensure neither log truncation nor compaction fail when run concurrently.
 func TestCompactCleanerTruncateConcurrent(t *testing.T) {
 	opts := Options{
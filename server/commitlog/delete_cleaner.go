@@ -1,6 +1,7 @@
 package commitlog
 
 import (
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -29,17 +30,31 @@ type DeleteCleanerOptions struct {
 // segments based on the retention policy.
 type DeleteCleaner struct {
 	DeleteCleanerOptions
+	mu sync.RWMutex
 }
 
 // NewDeleteCleaner returns a new Cleaner which enforces log retention
 // policies by deleting segments.
 func NewDeleteCleaner(opts DeleteCleanerOptions) *DeleteCleaner {
-	return &DeleteCleaner{opts}
+	return &DeleteCleaner{DeleteCleanerOptions: opts}
+}
+
+// SetRetention updates the retention policy enforced by the cleaner. The new
+// policy takes effect the next time Clean is called.
+func (c *DeleteCleaner) SetRetention(bytes, messages int64, age time.Duration) {
+	c.mu.Lock()
+	c.Retention.Bytes = bytes
+	c.Retention.Messages = messages
+	c.Retention.Age = age
+	c.mu.Unlock()
 }
 
 // Clean will enforce the log retention policy by deleting old segments.
 // Deletion only occurs at the segment granularity.
 func (c *DeleteCleaner) Clean(segments []*Segment) ([]*Segment, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	var err error
 	if len(segments) == 0 || c.noRetentionLimits() {
 		return segments, nil
@@ -75,11 +90,11 @@ func (c *DeleteCleaner) Clean(segments []*Segment) ([]*Segment, error) {
 	return segments, nil
 }
 
-func (c DeleteCleaner) noRetentionLimits() bool {
+func (c *DeleteCleaner) noRetentionLimits() bool {
 	return c.Retention.Bytes == 0 && c.Retention.Messages == 0 && c.Retention.Age == 0
 }
 
-func (c DeleteCleaner) applyMessagesLimit(segments []*Segment) ([]*Segment, error) {
+func (c *DeleteCleaner) applyMessagesLimit(segments []*Segment) ([]*Segment, error) {
 	if len(segments) <= 1 {
 		return segments, nil
 	}
@@ -119,6 +119,31 @@ func TestDeleteCleanerMessages(t *testing.T) {
 	}
 }
 
+// Ensure Clean enforces the retention policy set with SetRetention.
+func TestDeleteCleanerSetRetention(t *testing.T) {
+	opts := DeleteCleanerOptions{Name: "foo", Logger: noopLogger()}
+	cleaner := NewDeleteCleaner(opts)
+	dir := tempDir(t)
+	defer remove(t, dir)
+
+	segs := make([]*Segment, 20)
+	for i := 0; i < 20; i++ {
+		segs[i] = createSegment(t, dir, int64(i), 20)
+		writeToSegment(t, segs[i], int64(i), []byte("blah"))
+	}
+	actual, err := cleaner.Clean(segs)
+	require.NoError(t, err)
+	require.Len(t, actual, 20)
+
+	cleaner.SetRetention(0, 5, 0)
+	actual, err = cleaner.Clean(actual)
+	require.NoError(t, err)
+	require.Len(t, actual, 5)
+	for i := 0; i < 5; i++ {
+		require.Equal(t, int64(i+15), actual[i].BaseOffset)
+	}
+}
+
 // Ensure Clean is a no-op when there are segments and a messages limit but the
 // segments don't exceed the limit.
 func TestDeleteCleanerMessagesBelowLimit(t *testing.T) {
@@ -234,6 +234,18 @@ func (idx *Index) Name() string {
 	return idx.file.Name()
 }
 
+// Reset removes all entries from the index.
+func (idx *Index) Reset() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	// Zero out the index contents so stale entries are not picked up by
+	// InitializePosition if the index is reopened before being shrunk.
+	for i := range idx.mmap {
+		idx.mmap[i] = 0
+	}
+	idx.position = 0
+}
+
 func (idx *Index) TruncateEntries(number int) error {
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
@@ -49,6 +49,13 @@ func TestLeaderEpochCache(t *testing.T) {
 
 	require.Equal(t, int64(0), l.earliestOffset())
 
+	require.NoError(t, l.ClearEarliest(5))
+
+	require.Equal(t, int64(5), l.earliestOffset())
+	require.Equal(t, uint64(1), l.epochOffsets[0].leaderEpoch)
+	require.Equal(t, uint64(3), l.LastLeaderEpoch())
+	require.Equal(t, int64(15), l.latestOffset())
+
 	require.NoError(t, l.ClearEarliest(15))
 
 	require.Equal(t, int64(15), l.earliestOffset())
@@ -1,6 +1,36 @@
 package commitlog
 
-import "github.com/liftbridge-io/liftbridge/server/proto"
+import (
+	"strconv"
+
+	"github.com/liftbridge-io/liftbridge/server/proto"
+)
+
+const (
+	// ExpirationHeader is the message header containing the time, in Unix
+	// nanoseconds, at which the message expires. Expired messages are not
+	// delivered to subscribers and are removed by compaction.
+	ExpirationHeader = "expiration"
+
+	// DeliverAfterHeader is the message header containing the time, in Unix
+	// nanoseconds, before which the message is held back from subscribers.
+	DeliverAfterHeader = "deliver-after"
+)
+
+// TimestampHeader returns the value of the header with the given name as a
+// time in Unix nanoseconds. It returns false if the header is not set or is
+// not a valid timestamp.
+func TimestampHeader(headers map[string][]byte, name string) (int64, bool) {
+	value, ok := headers[name]
+	if !ok {
+		return 0, false
+	}
+	timestamp, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return timestamp, true
+}
 
 type Message []byte
 
@@ -68,6 +98,9 @@ func (m Message) valueOffsets() (start, end, size int32) {
 	_, keyEnd, _ := m.keyOffsets()
 	start = keyEnd
 	size = int32(proto.Encoding.Uint32(m[start:]))
-	end = start + 4 + size
+	end = start + 4
+	if size != -1 {
+		end += size
+	}
 	return
 }
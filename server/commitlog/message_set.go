@@ -18,6 +18,11 @@ const (
 	leaderEpochPos  = 16
 	sizePos         = 24
 	msgSetHeaderLen = 28
+
+	// minMessageSize is the size of a message with no key, value, or
+	// headers: 4 bytes for the CRC, 1 for the magic byte, 1 for attributes, 4
+	// each for the key and value lengths, and 2 for the number of headers.
+	minMessageSize = 16
 )
 
 type MessageSet []byte
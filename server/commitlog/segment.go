@@ -2,6 +2,7 @@ package commitlog
 
 import (
 	"fmt"
+	"hash/crc32"
 	"io"
 	"os"
 	"path/filepath"
@@ -271,6 +272,20 @@ func (s *Segment) removeWaiter(r contextReader) {
 	s.Unlock()
 }
 
+// Sync commits the segment's log and index to stable storage. This is a no-op
+// if the segment is closed, since closing it syncs it.
+func (s *Segment) Sync() error {
+	s.RLock()
+	defer s.RUnlock()
+	if s.closed {
+		return nil
+	}
+	if err := s.log.Sync(); err != nil {
+		return errors.Wrap(err, "log sync failed")
+	}
+	return s.Index.Sync()
+}
+
 // Close a segment such that it can no longer be read from or written to. This
 // operation is idempotent.
 func (s *Segment) Close() error {
@@ -283,6 +298,9 @@ func (s *Segment) close() error {
 	if s.closed {
 		return nil
 	}
+	if err := s.log.Sync(); err != nil {
+		return errors.Wrap(err, "log sync failed")
+	}
 	if err := s.log.Close(); err != nil {
 		return err
 	}
@@ -334,6 +352,106 @@ func (s *Segment) Replace(old *Segment) error {
 	return s.setupIndex()
 }
 
+// segmentRecovery describes the result of recovering a log segment.
+type segmentRecovery struct {
+	messages       int    // Number of valid messages retained in the segment
+	truncatedBytes int64  // Number of bytes truncated from the log file
+	reason         string // Why the log file was truncated, if it was
+}
+
+// recoverLog validates each message in the segment's log file, verifying its
+// CRC, and truncates the log at the first partially written or corrupted
+// message. The index is then rebuilt from the messages which remain. This is
+// used to repair the active segment after an unclean shutdown since neither
+// the log nor the index are guaranteed to have been flushed.
+func (s *Segment) recoverLog() (*segmentRecovery, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	info, err := s.log.Stat()
+	if err != nil {
+		return nil, errors.Wrap(err, "stat file failed")
+	}
+
+	var (
+		size       = info.Size()
+		position   int64
+		lastOffset = s.BaseOffset - 1
+		entries    = []*Entry{}
+		header     = make(MessageSet, msgSetHeaderLen)
+		recovery   = &segmentRecovery{}
+	)
+	for position < size {
+		if position+msgSetHeaderLen > size {
+			recovery.reason = fmt.Sprintf("partial message header at position %d", position)
+			break
+		}
+		if _, err := s.log.ReadAt(header, position); err != nil {
+			return nil, errors.Wrap(err, "log read failed")
+		}
+		var (
+			offset  = header.Offset()
+			msgSize = int64(header.Size())
+		)
+		if offset <= lastOffset {
+			recovery.reason = fmt.Sprintf("invalid offset %d at position %d", offset, position)
+			break
+		}
+		if msgSize < minMessageSize || position+msgSetHeaderLen+msgSize > size {
+			recovery.reason = fmt.Sprintf("partial message at offset %d", offset)
+			break
+		}
+		msg := make(Message, msgSize)
+		if _, err := s.log.ReadAt(msg, position+msgSetHeaderLen); err != nil {
+			return nil, errors.Wrap(err, "log read failed")
+		}
+		if crc, c := msg.Crc(), crc32.ChecksumIEEE(msg[4:]); crc != c {
+			recovery.reason = fmt.Sprintf(
+				"corrupted message at offset %d, expected CRC: 0x%08x, got: 0x%08x",
+				offset, crc, c)
+			break
+		}
+		entries = append(entries, &Entry{
+			Offset:      offset,
+			Timestamp:   header.Timestamp(),
+			LeaderEpoch: header.LeaderEpoch(),
+			Position:    position,
+			Size:        int32(msgSetHeaderLen + msgSize),
+		})
+		lastOffset = offset
+		position += msgSetHeaderLen + msgSize
+	}
+
+	// Remove anything following the last valid message.
+	if position < size {
+		if err := s.log.Truncate(position); err != nil {
+			return nil, errors.Wrap(err, "log truncate failed")
+		}
+		recovery.truncatedBytes = size - position
+	}
+	s.position = position
+
+	// Rebuild the index from the valid messages.
+	s.Index.Reset()
+	if len(entries) > 0 {
+		if err := s.Index.writeEntries(entries); err != nil {
+			return nil, err
+		}
+	}
+
+	s.firstOffset, s.lastOffset = -1, -1
+	s.firstWriteTime, s.lastWriteTime = 0, 0
+	if len(entries) > 0 {
+		first, last := entries[0], entries[len(entries)-1]
+		s.firstOffset = first.Offset
+		s.firstWriteTime = first.Timestamp
+		s.lastOffset = last.Offset
+		s.lastWriteTime = last.Timestamp
+	}
+	recovery.messages = len(entries)
+	return recovery, nil
+}
+
 // findEntry returns the first entry whose offset is greater than or equal to
 // the given offset.
 func (s *Segment) findEntry(offset int64) (e *Entry, err error) {
@@ -0,0 +1,56 @@
+package commitlog
+
+import (
+	"sync"
+	"time"
+)
+
+// throttleWindow is the period over which a Throttler measures its rate. Using
+// a window rather than measuring from the start avoids a burst after a period
+// of inactivity.
+const throttleWindow = time.Second
+
+// Throttler limits the rate of I/O to a maximum number of bytes per second.
+// It's safe for concurrent use, in which case the limit applies to the
+// combined I/O of all goroutines using it. A nil Throttler or one with a rate
+// of zero does not limit I/O.
+type Throttler struct {
+	mu    sync.Mutex
+	rate  int64
+	start time.Time
+	bytes int64
+}
+
+// NewThrottler returns a new Throttler which limits I/O to the given number of
+// bytes per second. A rate of zero disables throttling.
+func NewThrottler(bytesPerSecond int64) *Throttler {
+	return &Throttler{rate: bytesPerSecond, start: time.Now()}
+}
+
+// Throttle records that the given number of bytes were read or written and
+// blocks for as long as needed to keep I/O at or below the rate.
+func (t *Throttler) Throttle(n int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	if t.rate <= 0 {
+		t.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	elapsed := now.Sub(t.start)
+	if elapsed > throttleWindow {
+		t.start = now
+		t.bytes = 0
+		elapsed = 0
+	}
+	t.bytes += int64(n)
+	// Determine how long the bytes processed in this window should have
+	// taken at the rate and wait out the difference.
+	expected := time.Duration(float64(t.bytes) / float64(t.rate) * float64(time.Second))
+	t.mu.Unlock()
+	if wait := expected - elapsed; wait > 0 {
+		time.Sleep(wait)
+	}
+}
@@ -0,0 +1,27 @@
+package commitlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Ensure Throttler limits I/O to its rate.
+func TestThrottler(t *testing.T) {
+	throttler := NewThrottler(10000)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		throttler.Throttle(1000)
+	}
+	require.True(t, time.Since(start) >= 450*time.Millisecond)
+}
+
+// Ensure a nil Throttler or one with a rate of zero doesn't limit I/O.
+func TestThrottlerDisabled(t *testing.T) {
+	start := time.Now()
+	var throttler *Throttler
+	throttler.Throttle(1000000)
+	NewThrottler(0).Throttle(1000000)
+	require.True(t, time.Since(start) < 100*time.Millisecond)
+}
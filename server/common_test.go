@@ -7,6 +7,8 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+
+	"github.com/liftbridge-io/liftbridge/server/logger"
 )
 
 // Used by both testing.B and testing.T so need to use
@@ -81,3 +83,7 @@ func (c *captureFatalLogger) Writer() io.Writer {
 }
 
 func (c *captureFatalLogger) SetWriter(writer io.Writer) {}
+
+func (c *captureFatalLogger) SetLevel(level uint32) {}
+
+func (c *captureFatalLogger) WithFields(fields logger.Fields) logger.Logger { return c }
@@ -26,31 +26,58 @@ const (
 )
 
 const (
-	defaultReplicaMaxLagTime       = 10 * time.Second
-	defaultReplicaMaxLeaderTimeout = 10 * time.Second
-	defaultRaftSnapshots           = 2
-	defaultRaftCacheSize           = 512
-	defaultMetadataCacheMaxAge     = 2 * time.Minute
-	defaultBatchMaxMessages        = 1024
-	defaultReplicaFetchTimeout     = 3 * time.Second
-	defaultMinInsyncReplicas       = 1
-	defaultRetentionMaxAge         = 7 * 24 * time.Hour
-	defaultCleanerInterval         = 5 * time.Minute
-	defaultMaxSegmentBytes         = 1024 * 1024 * 256 // 256MB
-	defaultLogRollTime             = defaultRetentionMaxAge
-	defaultCompactMaxGoroutines    = 10
+	defaultReplicaMaxLagTime         = 10 * time.Second
+	defaultReplicaMaxLeaderTimeout   = 10 * time.Second
+	defaultRaftSnapshots             = 2
+	defaultRaftCacheSize             = 512
+	defaultMetadataCacheMaxAge       = 2 * time.Minute
+	defaultBatchMaxMessages          = 1024
+	defaultReplicaFetchTimeout       = 3 * time.Second
+	defaultMinInsyncReplicas         = 1
+	defaultControlledShutdownTimeout = 30 * time.Second
+	defaultRetentionMaxAge           = 7 * 24 * time.Hour
+	defaultCleanerInterval           = 5 * time.Minute
+	defaultCleanerThreads            = 1
+	defaultMaxSegmentBytes           = 1024 * 1024 * 256 // 256MB
+	defaultLogRollTime               = defaultRetentionMaxAge
+	defaultCompactMaxGoroutines      = 10
+	defaultCompactDeleteRetention    = 24 * time.Hour
+	defaultLogFileMaxSize            = 100 // 100MB
+	defaultConsumerLagInterval       = 10 * time.Second
+	defaultConsumerTTL               = time.Hour
+	defaultSubscriberStallTimeout    = time.Minute
+)
+
+// Supported log output formats.
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// Built-in trace span exporters.
+const (
+	traceExporterStdout = "stdout"
+	traceExporterFile   = "file"
 )
 
 // LogConfig contains settings for controlling the message log for a stream.
 type LogConfig struct {
-	RetentionMaxBytes    int64
-	RetentionMaxMessages int64
-	RetentionMaxAge      time.Duration
-	CleanerInterval      time.Duration
-	SegmentMaxBytes      int64
-	LogRollTime          time.Duration
-	Compact              bool
-	CompactMaxGoroutines int
+	RetentionMaxBytes      int64
+	RetentionMaxMessages   int64
+	RetentionMaxAge        time.Duration
+	CleanerInterval        time.Duration
+	CleanerThreads         int
+	CleanerIOMaxBytes      int64
+	SegmentMaxBytes        int64
+	LogRollTime            time.Duration
+	Compact                bool
+	CompactMaxGoroutines   int
+	CompactDeleteRetention time.Duration
+	CompactMinLag          time.Duration
+	CompactMaxLag          time.Duration
+	CompactMinDirtyRatio   float64
+	FlushMessages          int64
+	FlushInterval          time.Duration
 }
 
 // RetentionString returns a human-readable string representation of the
@@ -80,36 +107,85 @@ func (l LogConfig) RetentionString() string {
 
 // ClusteringConfig contains settings for controlling cluster behavior.
 type ClusteringConfig struct {
-	ServerID                string
-	Namespace               string
-	RaftSnapshots           int
-	RaftSnapshotThreshold   uint64
-	RaftCacheSize           int
-	RaftBootstrapSeed       bool
-	RaftBootstrapPeers      []string
-	RaftLogging             bool
-	ReplicaMaxLagTime       time.Duration
-	ReplicaMaxLeaderTimeout time.Duration
-	ReplicaFetchTimeout     time.Duration
-	MinISR                  int
+	ServerID                  string
+	Namespace                 string
+	RaftSnapshots             int
+	RaftSnapshotThreshold     uint64
+	RaftCacheSize             int
+	RaftBootstrapSeed         bool
+	RaftBootstrapPeers        []string
+	RaftObserver              bool
+	RaftLogging               bool
+	ReplicaMaxLagTime         time.Duration
+	ReplicaMaxLeaderTimeout   time.Duration
+	ReplicaFetchTimeout       time.Duration
+	MinISR                    int
+	ControlledShutdown        bool
+	ControlledShutdownTimeout time.Duration
+}
+
+// EmbeddedNATSConfig contains settings for running a NATS server inside the
+// Liftbridge process rather than connecting to an external NATS cluster.
+type EmbeddedNATSConfig struct {
+	Enabled     bool
+	Host        string
+	Port        int
+	ClusterHost string
+	ClusterPort int
+	Routes      []string
+}
+
+// TracingConfig contains settings for exporting message trace spans.
+type TracingConfig struct {
+	Exporter string
+	File     string
+}
+
+// MetricsConfig contains settings for server metrics and consumer lag
+// monitoring.
+type MetricsConfig struct {
+	Listen                  string
+	ConsumerLagInterval     time.Duration
+	ConsumerLagWarnMessages int64
+	ConsumerLagWarnTime     time.Duration
+	ConsumerTTL             time.Duration
 }
 
 // Config contains all settings for a Liftbridge Server.
 type Config struct {
-	Host                string
-	Port                int
-	LogLevel            uint32
-	LogRecovery         bool
-	LogSilent           bool
-	DataDir             string
-	BatchMaxMessages    int
-	BatchWaitTime       time.Duration
-	MetadataCacheMaxAge time.Duration
-	TLSKey              string
-	TLSCert             string
-	NATS                nats.Options
-	Log                 LogConfig
-	Clustering          ClusteringConfig
+	Host                   string
+	Port                   int
+	LogLevel               uint32
+	LogFormat              string
+	LogFile                string
+	LogFileMaxSize         int
+	LogFileRollTime        time.Duration
+	LogFileMaxBackups      int
+	LogRecovery            bool
+	LogSilent              bool
+	DataDir                string
+	BatchMaxMessages       int
+	BatchWaitTime          time.Duration
+	MetadataCacheMaxAge    time.Duration
+	SubscriberStallTimeout time.Duration
+	TLSKey                 string
+	TLSCert                string
+	NATS                   nats.Options
+	EmbeddedNATS           EmbeddedNATSConfig
+	Log                    LogConfig
+	Clustering             ClusteringConfig
+	Tracing                TracingConfig
+	Metrics                MetricsConfig
+
+	// ConfigFile is the path of the configuration file the settings were
+	// loaded from, if any. It's re-parsed when the server reloads its
+	// configuration.
+	ConfigFile string
+
+	// ReloadOverrides, if set, is applied to the configuration re-parsed on
+	// reload. This allows settings provided outside of the configuration
+	// file, such as command-line flags, to continue to take precedence.
+	ReloadOverrides func(*Config) error
 }
 
 // NewDefaultConfig creates a new Config with default settings.
@@ -119,8 +195,11 @@ func NewDefaultConfig() *Config {
 		Port: DefaultPort,
 	}
 	config.LogLevel = uint32(log.InfoLevel)
+	config.LogFormat = logFormatText
+	config.LogFileMaxSize = defaultLogFileMaxSize
 	config.BatchMaxMessages = defaultBatchMaxMessages
 	config.MetadataCacheMaxAge = defaultMetadataCacheMaxAge
+	config.SubscriberStallTimeout = defaultSubscriberStallTimeout
 	config.Clustering.ServerID = nuid.Next()
 	config.Clustering.Namespace = DefaultNamespace
 	config.Clustering.ReplicaMaxLagTime = defaultReplicaMaxLagTime
@@ -129,10 +208,15 @@ func NewDefaultConfig() *Config {
 	config.Clustering.RaftSnapshots = defaultRaftSnapshots
 	config.Clustering.RaftCacheSize = defaultRaftCacheSize
 	config.Clustering.MinISR = defaultMinInsyncReplicas
+	config.Clustering.ControlledShutdownTimeout = defaultControlledShutdownTimeout
 	config.Log.SegmentMaxBytes = defaultMaxSegmentBytes
 	config.Log.RetentionMaxAge = defaultRetentionMaxAge
 	config.Log.LogRollTime = defaultLogRollTime
+	config.Log.CompactDeleteRetention = defaultCompactDeleteRetention
 	config.Log.CleanerInterval = defaultCleanerInterval
+	config.Log.CleanerThreads = defaultCleanerThreads
+	config.Metrics.ConsumerLagInterval = defaultConsumerLagInterval
+	config.Metrics.ConsumerTTL = defaultConsumerTTL
 	return config
 }
 
@@ -167,6 +251,7 @@ func NewConfig(configFile string) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	config.ConfigFile = configFile
 
 	// Reset LogRollTime since this will get overwritten later.
 	config.Log.LogRollTime = 0
@@ -190,6 +275,24 @@ func NewConfig(configFile string) (*Config, error) {
 				return nil, err
 			}
 			config.LogLevel = level
+		case "log.format":
+			format := strings.ToLower(v.(string))
+			if format != logFormatText && format != logFormatJSON {
+				return nil, fmt.Errorf("Invalid log.format setting %q", v)
+			}
+			config.LogFormat = format
+		case "log.file":
+			config.LogFile = v.(string)
+		case "log.file.max.size":
+			config.LogFileMaxSize = int(v.(int64))
+		case "log.file.roll.time":
+			dur, err := time.ParseDuration(v.(string))
+			if err != nil {
+				return nil, err
+			}
+			config.LogFileRollTime = dur
+		case "log.file.max.backups":
+			config.LogFileMaxBackups = int(v.(int64))
 		case "log.recovery":
 			config.LogRecovery = v.(bool)
 		case "data.dir":
@@ -208,12 +311,18 @@ func NewConfig(configFile string) (*Config, error) {
 				return nil, err
 			}
 			config.MetadataCacheMaxAge = dur
+		case "subscriber.stall.timeout":
+			dur, err := time.ParseDuration(v.(string))
+			if err != nil {
+				return nil, err
+			}
+			config.SubscriberStallTimeout = dur
 		case "tls.key":
 			config.TLSKey = v.(string)
 		case "tls.cert":
 			config.TLSCert = v.(string)
 		case "nats":
-			if err := parseNATSConfig(v.(map[string]interface{}), &config.NATS); err != nil {
+			if err := parseNATSConfig(config, v.(map[string]interface{})); err != nil {
 				return nil, err
 			}
 		case "log":
@@ -224,6 +333,14 @@ func NewConfig(configFile string) (*Config, error) {
 			if err := parseClusteringConfig(config, v.(map[string]interface{})); err != nil {
 				return nil, err
 			}
+		case "tracing":
+			if err := parseTracingConfig(config, v.(map[string]interface{})); err != nil {
+				return nil, err
+			}
+		case "metrics":
+			if err := parseMetricsConfig(config, v.(map[string]interface{})); err != nil {
+				return nil, err
+			}
 		default:
 			return nil, fmt.Errorf("Unknown configuration setting %q", k)
 		}
@@ -238,15 +355,37 @@ func NewConfig(configFile string) (*Config, error) {
 }
 
 // parseNATSConfig parses the `nats` section of a config file and populates the
-// given nats.Options.
-func parseNATSConfig(m map[string]interface{}, opts *nats.Options) error {
+// given Config.
+func parseNATSConfig(config *Config, m map[string]interface{}) error {
 	for k, v := range m {
 		switch strings.ToLower(k) {
 		case "servers":
 			servers := v.([]interface{})
-			opts.Servers = make([]string, len(servers))
+			config.NATS.Servers = make([]string, len(servers))
 			for i, p := range servers {
-				opts.Servers[i] = p.(string)
+				config.NATS.Servers[i] = p.(string)
+			}
+		case "embedded":
+			config.EmbeddedNATS.Enabled = v.(bool)
+		case "embedded.listen":
+			hp, err := parseListen(v)
+			if err != nil {
+				return err
+			}
+			config.EmbeddedNATS.Host = hp.host
+			config.EmbeddedNATS.Port = hp.port
+		case "embedded.cluster.listen":
+			hp, err := parseListen(v)
+			if err != nil {
+				return err
+			}
+			config.EmbeddedNATS.ClusterHost = hp.host
+			config.EmbeddedNATS.ClusterPort = hp.port
+		case "embedded.cluster.routes":
+			routes := v.([]interface{})
+			config.EmbeddedNATS.Routes = make([]string, len(routes))
+			for i, r := range routes {
+				config.EmbeddedNATS.Routes[i] = r.(string)
 			}
 		default:
 			return fmt.Errorf("Unknown nats configuration setting %q", k)
@@ -276,6 +415,18 @@ func parseLogConfig(config *Config, m map[string]interface{}) error {
 				return err
 			}
 			config.Log.CleanerInterval = dur
+		case "cleaner.threads":
+			config.Log.CleanerThreads = int(v.(int64))
+		case "flush.messages":
+			config.Log.FlushMessages = v.(int64)
+		case "flush.interval":
+			dur, err := time.ParseDuration(v.(string))
+			if err != nil {
+				return err
+			}
+			config.Log.FlushInterval = dur
+		case "cleaner.io.max.bytes.per.second":
+			config.Log.CleanerIOMaxBytes = v.(int64)
 		case "segment.max.bytes":
 			config.Log.SegmentMaxBytes = v.(int64)
 		case "log.roll.time":
@@ -288,6 +439,38 @@ func parseLogConfig(config *Config, m map[string]interface{}) error {
 			config.Log.Compact = v.(bool)
 		case "compact.max.goroutines":
 			config.Log.CompactMaxGoroutines = v.(int)
+		case "compact.delete.retention":
+			dur, err := time.ParseDuration(v.(string))
+			if err != nil {
+				return err
+			}
+			config.Log.CompactDeleteRetention = dur
+		case "compact.min.lag":
+			dur, err := time.ParseDuration(v.(string))
+			if err != nil {
+				return err
+			}
+			config.Log.CompactMinLag = dur
+		case "compact.max.lag":
+			dur, err := time.ParseDuration(v.(string))
+			if err != nil {
+				return err
+			}
+			config.Log.CompactMaxLag = dur
+		case "compact.min.dirty.ratio":
+			var ratio float64
+			switch n := v.(type) {
+			case float64:
+				ratio = n
+			case int64:
+				ratio = float64(n)
+			default:
+				return fmt.Errorf("Invalid compact.min.dirty.ratio %v, must be between 0 and 1", v)
+			}
+			if ratio < 0 || ratio > 1 {
+				return fmt.Errorf("Invalid compact.min.dirty.ratio %v, must be between 0 and 1", v)
+			}
+			config.Log.CompactMinDirtyRatio = ratio
 		default:
 			return fmt.Errorf("Unknown log configuration setting %q", k)
 		}
@@ -318,6 +501,8 @@ func parseClusteringConfig(config *Config, m map[string]interface{}) error {
 			for i, p := range peers {
 				config.Clustering.RaftBootstrapPeers[i] = p.(string)
 			}
+		case "raft.observer":
+			config.Clustering.RaftObserver = v.(bool)
 		case "raft.logging":
 			config.Clustering.RaftLogging = v.(bool)
 		case "replica.max.lag.time":
@@ -340,6 +525,14 @@ func parseClusteringConfig(config *Config, m map[string]interface{}) error {
 			config.Clustering.ReplicaFetchTimeout = dur
 		case "min.insync.replicas":
 			config.Clustering.MinISR = int(v.(int64))
+		case "controlled.shutdown":
+			config.Clustering.ControlledShutdown = v.(bool)
+		case "controlled.shutdown.timeout":
+			dur, err := time.ParseDuration(v.(string))
+			if err != nil {
+				return err
+			}
+			config.Clustering.ControlledShutdownTimeout = dur
 		default:
 			return fmt.Errorf("Unknown clustering configuration setting %q", k)
 		}
@@ -347,6 +540,60 @@ func parseClusteringConfig(config *Config, m map[string]interface{}) error {
 	return nil
 }
 
+// parseTracingConfig parses the `tracing` section of a config file and
+// populates the given Config.
+func parseTracingConfig(config *Config, m map[string]interface{}) error {
+	for k, v := range m {
+		switch strings.ToLower(k) {
+		case "exporter":
+			config.Tracing.Exporter = v.(string)
+		case "file":
+			config.Tracing.File = v.(string)
+		default:
+			return fmt.Errorf("Unknown tracing configuration setting %q", k)
+		}
+	}
+	return nil
+}
+
+// parseMetricsConfig parses the `metrics` section of a config file and
+// populates the given Config.
+func parseMetricsConfig(config *Config, m map[string]interface{}) error {
+	for k, v := range m {
+		switch strings.ToLower(k) {
+		case "listen":
+			hp, err := parseListen(v)
+			if err != nil {
+				return err
+			}
+			config.Metrics.Listen = net.JoinHostPort(hp.host, strconv.Itoa(hp.port))
+		case "consumer.lag.interval":
+			dur, err := time.ParseDuration(v.(string))
+			if err != nil {
+				return err
+			}
+			config.Metrics.ConsumerLagInterval = dur
+		case "consumer.lag.warn.messages":
+			config.Metrics.ConsumerLagWarnMessages = v.(int64)
+		case "consumer.lag.warn.time":
+			dur, err := time.ParseDuration(v.(string))
+			if err != nil {
+				return err
+			}
+			config.Metrics.ConsumerLagWarnTime = dur
+		case "consumer.ttl":
+			dur, err := time.ParseDuration(v.(string))
+			if err != nil {
+				return err
+			}
+			config.Metrics.ConsumerTTL = dur
+		default:
+			return fmt.Errorf("Unknown metrics configuration setting %q", k)
+		}
+	}
+	return nil
+}
+
 // hostPort is simple struct to hold parsed listen/addr strings.
 type hostPort struct {
 	host string
@@ -0,0 +1,192 @@
+package server
+
+import (
+	"sort"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+// consumerMetadataKey is the gRPC metadata key used to name the consumer of a
+// subscription so that the server can track its progress and lag.
+const consumerMetadataKey = "consumer"
+
+// consumer tracks the progress of a named consumer of a stream on the stream
+// leader. A consumer may have several subscriptions at once, such as multiple
+// instances of the same application, in which case its progress is that of
+// the furthest subscription.
+type consumer struct {
+	name          string
+	subscriptions int
+	offset        int64     // Offset of the last message delivered
+	timestamp     int64     // Timestamp of the last message delivered
+	lastActive    time.Time // Last time a message was delivered or a subscription started or ended
+	lagging       bool      // Lag is over one of the warning thresholds
+}
+
+// consumerLag is a point-in-time view of how far a consumer is behind the HW.
+type consumerLag struct {
+	Consumer      string
+	Subscriptions int
+	Offset        int64
+	HighWatermark int64
+	Lag           int64
+	TimeLag       time.Duration
+	LastActive    time.Time
+}
+
+// subscribeConsumer returns the consumer with the given name, adding it if
+// needed, and records a new subscription for it starting at the given offset.
+// Call unsubscribeConsumer when the subscription ends. Idle consumers are
+// evicted before adding a new one so that they don't accumulate even if lag
+// monitoring is disabled.
+func (s *stream) subscribeConsumer(name string, startOffset int64) *consumer {
+	s.consumersMu.Lock()
+	defer s.consumersMu.Unlock()
+	c, ok := s.consumers[name]
+	if !ok {
+		s.evictIdleConsumers(s.srv.config.Metrics.ConsumerTTL, time.Now())
+		c = &consumer{name: name}
+		s.consumers[name] = c
+	}
+	// If the consumer has no other subscriptions, it's now positioned
+	// wherever this subscription starts.
+	if c.subscriptions == 0 {
+		c.offset = startOffset - 1
+		c.timestamp = 0
+	}
+	c.subscriptions++
+	c.lastActive = time.Now()
+	return c
+}
+
+// unsubscribeConsumer records that a subscription for the consumer ended. The
+// consumer continues to be tracked so that its lag is still reported while it
+// has no subscriptions, until it's evicted by evictIdleConsumers.
+func (s *stream) unsubscribeConsumer(c *consumer) {
+	s.consumersMu.Lock()
+	c.subscriptions--
+	c.lastActive = time.Now()
+	s.consumersMu.Unlock()
+}
+
+// consumerDelivered records that the message with the given offset and
+// timestamp was delivered to, or skipped over for, the consumer.
+func (s *stream) consumerDelivered(c *consumer, offset, timestamp int64) {
+	s.consumersMu.Lock()
+	if offset > c.offset {
+		c.offset = offset
+		c.timestamp = timestamp
+	}
+	c.lastActive = time.Now()
+	s.consumersMu.Unlock()
+}
+
+// consumerLags returns the lag of each of the stream's consumers sorted by
+// consumer name.
+func (s *stream) consumerLags() []*consumerLag {
+	var (
+		hw  = s.log.HighWatermark()
+		now = time.Now()
+	)
+	s.consumersMu.Lock()
+	lags := make([]*consumerLag, 0, len(s.consumers))
+	for _, c := range s.consumers {
+		lags = append(lags, c.lagAt(hw, now))
+	}
+	s.consumersMu.Unlock()
+	sort.Slice(lags, func(i, j int) bool {
+		return lags[i].Consumer < lags[j].Consumer
+	})
+	return lags
+}
+
+// checkConsumerLag updates the consumer lag metrics for the stream and logs a
+// warning when a consumer's lag exceeds one of the configured thresholds and
+// again once it recovers.
+func (s *stream) checkConsumerLag(config MetricsConfig) {
+	var (
+		hw  = s.log.HighWatermark()
+		now = time.Now()
+	)
+	s.consumersMu.Lock()
+	defer s.consumersMu.Unlock()
+	s.evictIdleConsumers(config.ConsumerTTL, now)
+	for _, c := range s.consumers {
+		lag := c.lagAt(hw, now)
+		labels := []metrics.Label{
+			{Name: "subject", Value: s.Subject},
+			{Name: "name", Value: s.Name},
+			{Name: "consumer", Value: c.name},
+		}
+		s.srv.metrics.SetGaugeWithLabels([]string{"consumer", "lag", "messages"},
+			float32(lag.Lag), labels)
+		s.srv.metrics.SetGaugeWithLabels([]string{"consumer", "lag", "seconds"},
+			float32(lag.TimeLag.Seconds()), labels)
+
+		lagging := (config.ConsumerLagWarnMessages > 0 && lag.Lag >= config.ConsumerLagWarnMessages) ||
+			(config.ConsumerLagWarnTime > 0 && lag.TimeLag >= config.ConsumerLagWarnTime)
+		switch {
+		case lagging && !c.lagging:
+			s.logger.Warnf("Consumer %s of stream %s is lagging %d messages (%s) behind the HW at offset %d",
+				c.name, s, lag.Lag, lag.TimeLag, c.offset)
+		case !lagging && c.lagging:
+			s.logger.Infof("Consumer %s of stream %s is no longer lagging, %d messages behind the HW",
+				c.name, s, lag.Lag)
+		}
+		c.lagging = lagging
+	}
+}
+
+// evictIdleConsumers stops tracking consumers which have had no subscriptions
+// for at least the given TTL. Their lag gauges are no longer set, so they drop
+// out of the metrics once the intervals they were last set in are no longer
+// retained. A TTL of 0 disables eviction. This must be called with the
+// consumersMu held.
+func (s *stream) evictIdleConsumers(ttl time.Duration, now time.Time) {
+	if ttl <= 0 {
+		return
+	}
+	for name, c := range s.consumers {
+		if c.subscriptions == 0 && now.Sub(c.lastActive) >= ttl {
+			delete(s.consumers, name)
+			s.logger.Debugf("Evicted consumer %s of stream %s after %s without subscriptions",
+				name, s, ttl)
+		}
+	}
+}
+
+// lagAt returns the consumer's lag behind the given HW at the given time. The
+// time lag is the age of the last message delivered to the consumer, or zero
+// if it's caught up. This must be called with the stream's consumersMu held.
+func (c *consumer) lagAt(hw int64, now time.Time) *consumerLag {
+	lag := &consumerLag{
+		Consumer:      c.name,
+		Subscriptions: c.subscriptions,
+		Offset:        c.offset,
+		HighWatermark: hw,
+		Lag:           hw - c.offset,
+		LastActive:    c.lastActive,
+	}
+	if lag.Lag <= 0 {
+		lag.Lag = 0
+		return lag
+	}
+	if c.timestamp > 0 {
+		lag.TimeLag = now.Sub(time.Unix(0, c.timestamp))
+	}
+	return lag
+}
+
+// subscribeConsumerName returns the name of the consumer a subscription is for,
+// if any, from the gRPC metadata.
+func subscribeConsumerName(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(consumerMetadataKey); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package server
+
+import "github.com/liftbridge-io/liftbridge/server/commitlog"
+
+// delayedMessage is a message read by a subscription. If it has a
+// deliver-after time in the future, it is held back in a delayedMessages queue
+// until then.
+type delayedMessage struct {
+	msg          commitlog.Message
+	offset       int64
+	timestamp    int64
+	deliverAfter int64
+}
+
+// delayedMessages is a heap of messages held back by a subscription, ordered
+// by deliver-after time and then by offset. It implements heap.Interface.
+type delayedMessages []*delayedMessage
+
+func (d delayedMessages) Len() int { return len(d) }
+
+func (d delayedMessages) Less(i, j int) bool {
+	if d[i].deliverAfter == d[j].deliverAfter {
+		return d[i].offset < d[j].offset
+	}
+	return d[i].deliverAfter < d[j].deliverAfter
+}
+
+func (d delayedMessages) Swap(i, j int) { d[i], d[j] = d[j], d[i] }
+
+func (d *delayedMessages) Push(x interface{}) {
+	*d = append(*d, x.(*delayedMessage))
+}
+
+func (d *delayedMessages) Pop() interface{} {
+	var (
+		old = *d
+		n   = len(old)
+		m   = old[n-1]
+	)
+	old[n-1] = nil
+	*d = old[:n-1]
+	return m
+}
+
+// holdsBefore indicates if a message with an offset lower than the given one
+// is being held back.
+func (d delayedMessages) holdsBefore(offset int64) bool {
+	for _, m := range d {
+		if m.offset < offset {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"container/heap"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Ensure delayedMessages pops messages in deliver-after order, breaking ties
+// by offset, and reports held back messages preceding an offset.
+func TestDelayedMessages(t *testing.T) {
+	var d delayedMessages
+	heap.Push(&d, &delayedMessage{offset: 4, deliverAfter: 30})
+	heap.Push(&d, &delayedMessage{offset: 2, deliverAfter: 20})
+	heap.Push(&d, &delayedMessage{offset: 3, deliverAfter: 10})
+	heap.Push(&d, &delayedMessage{offset: 1, deliverAfter: 20})
+
+	require.False(t, d.holdsBefore(1))
+	require.True(t, d.holdsBefore(5))
+
+	for _, offset := range []int64{3, 1, 2, 4} {
+		require.Equal(t, offset, heap.Pop(&d).(*delayedMessage).offset)
+	}
+	require.Len(t, d, 0)
+	require.False(t, d.holdsBefore(5))
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	natsd "github.com/nats-io/nats-server/v2/server"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/liftbridge-io/liftbridge/server/logger"
+)
+
+// embeddedNATSStartTimeout is the amount of time to wait for the embedded NATS
+// server to begin accepting connections.
+const embeddedNATSStartTimeout = 10 * time.Second
+
+// startEmbeddedNATS starts a NATS server in the Liftbridge process using the
+// embedded NATS settings. If a cluster port is set, the server listens for
+// routes from other embedded servers and connects to the configured routes.
+func (s *Server) startEmbeddedNATS() error {
+	config := s.config.EmbeddedNATS
+	opts := &natsd.Options{
+		Host:   config.Host,
+		Port:   config.Port,
+		NoSigs: true,
+	}
+	if config.ClusterPort != 0 {
+		opts.Cluster.Host = config.ClusterHost
+		opts.Cluster.Port = config.ClusterPort
+		opts.Routes = natsd.RoutesFromStr(strings.Join(config.Routes, ","))
+	}
+	ns, err := natsd.NewServer(opts)
+	if err != nil {
+		return errors.Wrap(err, "failed to create embedded NATS server")
+	}
+	ns.SetLogger(&natsLogger{s.logger.WithFields(logger.Fields{logger.FieldComponent: "nats"})},
+		s.config.LogLevel == uint32(log.DebugLevel), false)
+	go ns.Start()
+	if !ns.ReadyForConnections(embeddedNATSStartTimeout) {
+		ns.Shutdown()
+		return errors.New("embedded NATS server failed to start")
+	}
+	s.natsServer = ns
+	s.logger.Infof("Started embedded NATS server on %s", s.embeddedNATSURL())
+	return nil
+}
+
+// embeddedNATSURL returns the URL to connect to the embedded NATS server.
+func (s *Server) embeddedNATSURL() string {
+	addr := s.natsServer.Addr().(*net.TCPAddr)
+	host := addr.IP.String()
+	if addr.IP.IsUnspecified() {
+		// The server is listening on all interfaces, so connect over
+		// loopback.
+		host = "127.0.0.1"
+	}
+	return fmt.Sprintf("nats://%s", net.JoinHostPort(host, strconv.Itoa(addr.Port)))
+}
+
+// natsLogger adapts a Logger to the logging interface used by the NATS server.
+type natsLogger struct {
+	logger.Logger
+}
+
+func (n *natsLogger) Noticef(format string, v ...interface{}) {
+	n.Infof(format, v...)
+}
+
+func (n *natsLogger) Fatalf(format string, v ...interface{}) {
+	// Let the server shut down rather than exiting the process from inside
+	// the NATS server.
+	n.Errorf(format, v...)
+}
+
+func (n *natsLogger) Tracef(format string, v ...interface{}) {
+	n.Debugf(format, v...)
+}
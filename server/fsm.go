@@ -151,8 +151,9 @@ func (s *Server) apply(log *proto.RaftLog, index uint64, recovered bool) (interf
 			subject = log.ChangeLeaderOp.Subject
 			name    = log.ChangeLeaderOp.Name
 			leader  = log.ChangeLeaderOp.Leader
+			unclean = log.ChangeLeaderOp.Unclean
 		)
-		if err := s.applyChangeStreamLeader(subject, name, leader, index); err != nil {
+		if err := s.applyChangeStreamLeader(subject, name, leader, unclean, index); err != nil {
 			return nil, err
 		}
 	case proto.Op_EXPAND_ISR:
@@ -164,6 +165,27 @@ func (s *Server) apply(log *proto.RaftLog, index uint64, recovered bool) (interf
 		if err := s.applyExpandISR(subject, name, replica, index); err != nil {
 			return nil, err
 		}
+	case proto.Op_PURGE_STREAM:
+		var (
+			subject = log.PurgeStreamOp.Subject
+			name    = log.PurgeStreamOp.Name
+			offset  = log.PurgeStreamOp.Offset
+		)
+		if err := s.applyPurgeStream(subject, name, offset); err != nil {
+			return nil, err
+		}
+	case proto.Op_REASSIGN_REPLICAS:
+		var (
+			subject  = log.ReassignReplicasOp.Subject
+			name     = log.ReassignReplicasOp.Name
+			replicas = log.ReassignReplicasOp.Replicas
+			leader   = log.ReassignReplicasOp.Leader
+		)
+		if err := s.applyReassignReplicas(subject, name, replicas, leader, index); err != nil {
+			return nil, err
+		}
+	case proto.Op_CORDON_SERVER:
+		s.applyCordonServer(log.CordonServerOp.Id, log.CordonServerOp.Cordoned)
 	default:
 		return nil, fmt.Errorf("Unknown Raft operation: %s", log.Op)
 	}
@@ -257,7 +279,10 @@ func (s *Server) Snapshot() (raft.FSMSnapshot, error) {
 	for i, stream := range streams {
 		protos[i] = stream.Stream
 	}
-	return &fsmSnapshot{&proto.MetadataSnapshot{Streams: protos}}, nil
+	return &fsmSnapshot{&proto.MetadataSnapshot{
+		Streams:         protos,
+		CordonedServers: s.metadata.GetCordoned(),
+	}}, nil
 }
 
 // Restore is used to restore an FSM from a snapshot. It is not called
@@ -294,6 +319,9 @@ func (s *Server) Restore(snapshot io.ReadCloser) error {
 		}
 		count++
 	}
+	for _, id := range snap.CordonedServers {
+		s.metadata.SetCordoned(id, true)
+	}
 	s.logger.Debugf("fsm: Finished restoring Raft state from snapshot, recovered %s",
 		english.Plural(count, "stream", ""))
 	return nil
@@ -316,6 +344,11 @@ func (s *Server) applyCreateStream(protoStream *proto.Stream, recovered bool) er
 	if err != nil {
 		return errors.Wrap(err, "failed to add stream to metadata store")
 	}
+	// Finish any purge which was replicated before the snapshot the stream was
+	// restored from.
+	if stream.GetStartOffset() > 0 {
+		stream.purgeLog()
+	}
 	s.logger.Debugf("fsm: Created stream %s", stream)
 	return nil
 }
@@ -371,9 +404,12 @@ func (s *Server) applyExpandISR(subject, name, replica string, epoch uint64) err
 }
 
 // applyChangeStreamLeader sets the stream's leader to the given replica and
-// updates the stream epoch. If the stream epoch is greater than or equal to
+// updates the stream epoch. If the leader was elected uncleanly, the ISR is
+// reset to just the new leader. If the stream epoch is greater than or equal to
 // the specified epoch, this does nothing.
-func (s *Server) applyChangeStreamLeader(subject, name string, leader string, epoch uint64) error {
+func (s *Server) applyChangeStreamLeader(subject, name string, leader string, unclean bool,
+	epoch uint64) error {
+
 	stream := s.metadata.GetStream(subject, name)
 	if stream == nil {
 		return fmt.Errorf("No such stream [subject=%s, name=%s]", subject, name)
@@ -384,12 +420,72 @@ func (s *Server) applyChangeStreamLeader(subject, name string, leader string, ep
 		return nil
 	}
 
-	if err := stream.SetLeader(leader, epoch); err != nil {
+	if unclean {
+		if err := stream.SetUncleanLeader(leader, epoch); err != nil {
+			return errors.Wrap(err, "failed to change stream leader")
+		}
+	} else if err := stream.SetLeader(leader, epoch); err != nil {
 		return errors.Wrap(err, "failed to change stream leader")
 	}
 
 	stream.SetEpoch(epoch)
 
-	s.logger.Debugf("fsm: Changed leader for stream %s to %s", stream, leader)
+	if unclean {
+		s.logger.Warnf("fsm: Changed leader for stream %s to out-of-sync replica %s", stream, leader)
+	} else {
+		s.logger.Debugf("fsm: Changed leader for stream %s to %s", stream, leader)
+	}
 	return nil
 }
+
+// applyPurgeStream sets the stream's start offset so that all messages before
+// the given offset are removed from its log. The log is trimmed in the
+// background. This is idempotent since the start offset only moves forward.
+func (s *Server) applyPurgeStream(subject, name string, offset int64) error {
+	stream := s.metadata.GetStream(subject, name)
+	if stream == nil {
+		return fmt.Errorf("No such stream [subject=%s, name=%s]", subject, name)
+	}
+
+	if stream.Purge(offset) {
+		s.logger.Infof("fsm: Purging messages before offset %d from stream %s", offset, stream)
+	}
+	return nil
+}
+
+// applyReassignReplicas sets the stream's replicas and leader and updates the
+// stream epoch. If the stream epoch is greater than or equal to the specified
+// epoch, this does nothing.
+func (s *Server) applyReassignReplicas(subject, name string, replicas []string, leader string,
+	epoch uint64) error {
+
+	stream := s.metadata.GetStream(subject, name)
+	if stream == nil {
+		return fmt.Errorf("No such stream [subject=%s, name=%s]", subject, name)
+	}
+
+	// Idempotency check.
+	if stream.GetEpoch() >= epoch {
+		return nil
+	}
+
+	if err := stream.Reassign(replicas, leader, epoch); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to reassign replicas for stream %s", stream))
+	}
+
+	stream.SetEpoch(epoch)
+
+	s.logger.Infof("fsm: Reassigned replicas for stream %s to %v, leader %s", stream, replicas, leader)
+	return nil
+}
+
+// applyCordonServer marks the given server as cordoned or removes the mark.
+// This is idempotent.
+func (s *Server) applyCordonServer(id string, cordoned bool) {
+	s.metadata.SetCordoned(id, cordoned)
+	if cordoned {
+		s.logger.Infof("fsm: Cordoned server %s", id)
+	} else {
+		s.logger.Infof("fsm: Uncordoned server %s", id)
+	}
+}
@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"math"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileWriterOptions contains settings for configuring a FileWriter.
+type FileWriterOptions struct {
+	Path       string        // Path to the log file
+	MaxSize    int           // Max megabytes the file can contain before it's rotated, 0 disables
+	RollTime   time.Duration // Max time before the file is rotated, 0 disables
+	MaxBackups int           // Max number of rotated files to retain, 0 retains all
+}
+
+// FileWriter is an io.WriteCloser which writes to a log file and rotates it
+// when it exceeds a maximum size or age. Rotated files are renamed to include
+// the time they were rotated.
+type FileWriter struct {
+	*lumberjack.Logger
+	stop chan struct{}
+}
+
+// NewFileWriter creates a new FileWriter. If a RollTime is set, this starts a
+// background goroutine which rotates the file periodically until the
+// FileWriter is closed.
+func NewFileWriter(opts FileWriterOptions) *FileWriter {
+	maxSize := opts.MaxSize
+	if maxSize == 0 {
+		// lumberjack applies a default size when MaxSize is 0, so use the
+		// largest size possible to disable size-based rotation.
+		maxSize = math.MaxInt32
+	}
+	f := &FileWriter{
+		Logger: &lumberjack.Logger{
+			Filename:   opts.Path,
+			MaxSize:    maxSize,
+			MaxBackups: opts.MaxBackups,
+			LocalTime:  true,
+		},
+		stop: make(chan struct{}),
+	}
+	if opts.RollTime > 0 {
+		go f.rotateLoop(opts.RollTime)
+	}
+	return f
+}
+
+// rotateLoop rotates the log file every interval until the FileWriter is
+// closed.
+func (f *FileWriter) rotateLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-f.stop:
+			return
+		}
+		// There's nowhere to report a failed rotation, so writes continue to
+		// the current file and rotation is retried on the next tick.
+		f.Rotate()
+	}
+}
+
+// Close stops periodic rotation and closes the log file.
+func (f *FileWriter) Close() error {
+	close(f.stop)
+	return f.Logger.Close()
+}
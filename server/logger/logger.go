@@ -6,6 +6,19 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// Structured field names attached to log entries.
+const (
+	FieldStreamSubject = "stream_subject"
+	FieldStreamName    = "stream_name"
+	FieldReplica       = "replica"
+	FieldEpoch         = "epoch"
+	FieldOffset        = "offset"
+	FieldComponent     = "component"
+)
+
+// Fields contains structured fields attached to log entries.
+type Fields map[string]interface{}
+
 // Logger interface is used to allow tests to inject custom loggers.
 type Logger interface {
 	Fatalf(string, ...interface{})
@@ -19,13 +32,16 @@ type Logger interface {
 	Fatal(...interface{})
 	Writer() io.Writer
 	SetWriter(io.Writer)
+	SetLevel(uint32)
+	WithFields(Fields) Logger
 }
 
 type logger struct {
 	*log.Logger
 }
 
-// NewLogger returns a new Logger instance backed by Logrus.
+// NewLogger returns a new Logger instance backed by Logrus which writes
+// human-readable text.
 func NewLogger(level uint32) Logger {
 	l := log.New()
 	l.SetLevel(log.Level(level))
@@ -37,6 +53,15 @@ func NewLogger(level uint32) Logger {
 	return &logger{l}
 }
 
+// NewJSONLogger returns a new Logger instance backed by Logrus which writes
+// each entry as a JSON object.
+func NewJSONLogger(level uint32) Logger {
+	l := log.New()
+	l.SetLevel(log.Level(level))
+	l.Formatter = &log.JSONFormatter{}
+	return &logger{l}
+}
+
 func (l *logger) Writer() io.Writer {
 	return l.Out
 }
@@ -44,3 +69,34 @@ func (l *logger) Writer() io.Writer {
 func (l *logger) SetWriter(writer io.Writer) {
 	l.Out = writer
 }
+
+func (l *logger) SetLevel(level uint32) {
+	l.Logger.SetLevel(log.Level(level))
+}
+
+func (l *logger) WithFields(fields Fields) Logger {
+	return &entry{l.Logger.WithFields(log.Fields(fields))}
+}
+
+// entry is a Logger which attaches a set of structured fields to each log
+// entry. Changes to the writer or level apply to the Logger it was created
+// from.
+type entry struct {
+	*log.Entry
+}
+
+func (e *entry) Writer() io.Writer {
+	return e.Logger.Out
+}
+
+func (e *entry) SetWriter(writer io.Writer) {
+	e.Logger.Out = writer
+}
+
+func (e *entry) SetLevel(level uint32) {
+	e.Logger.SetLevel(log.Level(level))
+}
+
+func (e *entry) WithFields(fields Fields) Logger {
+	return &entry{e.Entry.WithFields(log.Fields(fields))}
+}
@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+// Ensure the JSON logger writes structured fields attached with WithFields.
+func TestJSONLoggerWithFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewJSONLogger(uint32(log.InfoLevel))
+	l.SetWriter(&buf)
+
+	l.WithFields(Fields{FieldStreamSubject: "foo", FieldStreamName: "bar"}).
+		WithFields(Fields{FieldOffset: 42}).
+		Infof("hello %s", "world")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "hello world", entry["msg"])
+	require.Equal(t, "info", entry["level"])
+	require.Equal(t, "foo", entry[FieldStreamSubject])
+	require.Equal(t, "bar", entry[FieldStreamName])
+	require.Equal(t, float64(42), entry[FieldOffset])
+}
+
+// Ensure changing the level or writer of a Logger with fields applies to the
+// Logger it was created from.
+func TestLoggerWithFieldsShared(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(uint32(log.InfoLevel))
+	fields := l.WithFields(Fields{FieldReplica: "a"})
+	fields.SetWriter(&buf)
+	fields.SetLevel(uint32(log.DebugLevel))
+
+	l.Debug("hello")
+	require.Contains(t, buf.String(), "hello")
+	require.Equal(t, &buf, l.Writer())
+}
+
+// Ensure the FileWriter rotates the log file when the roll time elapses.
+func TestFileWriterRollTime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logger_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w := NewFileWriter(FileWriterOptions{
+		Path:     filepath.Join(dir, "liftbridge.log"),
+		RollTime: 10 * time.Millisecond,
+	})
+	defer w.Close()
+
+	_, err = w.Write([]byte("hello\n"))
+	require.NoError(t, err)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		files, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		if len(files) > 1 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Log file was not rotated")
+}
@@ -3,9 +3,11 @@ package server
 import (
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/raft"
 	client "github.com/liftbridge-io/go-liftbridge/liftbridge-grpc"
 	"github.com/nats-io/nats.go"
@@ -32,13 +34,22 @@ type subjectStreams map[string]*stream
 // leaderReport tracks witnesses for a stream leader. Witnesses are replicas
 // which have reported the leader as unresponsive. If a quorum of replicas
 // report the leader within a bounded period of time, the controller will
-// select a new leader.
+// select a new leader. If the stream has no ISR candidates and allows unclean
+// leader elections, the controller will select an out-of-sync replica which
+// reported the leader once the unclean leader election timeout elapses.
 type leaderReport struct {
 	mu              sync.Mutex
 	stream          *stream
 	timer           *time.Timer
 	witnessReplicas map[string]struct{}
 	api             *metadataAPI
+
+	// These track the leader epoch in which the stream has had no ISR
+	// candidates, since when, and the replicas which reported the leader
+	// since then.
+	uncleanEpoch     uint64
+	uncleanSince     time.Time
+	uncleanWitnesses map[string]struct{}
 }
 
 // addWitness adds the given replica to the leaderReport witnesses. If a quorum
@@ -61,7 +72,7 @@ func (l *leaderReport) addWitness(replica string) *status.Status {
 		if l.timer != nil {
 			l.timer.Stop()
 		}
-		return l.api.electNewStreamLeader(l.stream)
+		return l.electNewLeader(replica)
 	}
 
 	if l.timer != nil {
@@ -77,6 +88,39 @@ func (l *leaderReport) addWitness(replica string) *status.Status {
 	return nil
 }
 
+// electNewLeader selects a new leader for the stream from the ISR. If there
+// are no ISR candidates and the stream allows unclean leader elections, it
+// instead selects an out-of-sync replica which has reported the leader once
+// there have been no ISR candidates for the unclean leader election timeout.
+// This must be called with the lock held.
+func (l *leaderReport) electNewLeader(replica string) *status.Status {
+	timeout := time.Duration(l.stream.UncleanLeaderElectionTimeout)
+	if timeout <= 0 || len(l.api.getLeaderCandidates(l.stream)) > 0 {
+		return l.api.electNewStreamLeader(l.stream)
+	}
+
+	_, epoch := l.stream.GetLeader()
+	if l.uncleanSince.IsZero() || l.uncleanEpoch != epoch {
+		l.uncleanEpoch = epoch
+		l.uncleanSince = time.Now()
+		l.uncleanWitnesses = make(map[string]struct{})
+		l.api.logger.Warnf("metadata: Stream %s has no ISR candidates for leader, "+
+			"electing an out-of-sync replica in %s", l.stream, timeout)
+	}
+	l.uncleanWitnesses[replica] = struct{}{}
+
+	if elapsed := time.Since(l.uncleanSince); elapsed < timeout {
+		return status.New(codes.FailedPrecondition, fmt.Sprintf(
+			"No ISR candidates, unclean leader election in %s", timeout-elapsed))
+	}
+
+	witnesses := make([]string, 0, len(l.uncleanWitnesses))
+	for witness := range l.uncleanWitnesses {
+		witnesses = append(witnesses, witness)
+	}
+	return l.api.electUncleanStreamLeader(l.stream, witnesses)
+}
+
 // cancel stops the expiration timer, if there is one.
 func (l *leaderReport) cancel() {
 	l.mu.Lock()
@@ -93,6 +137,7 @@ type metadataAPI struct {
 	streams         map[string]subjectStreams
 	mu              sync.RWMutex
 	leaderReports   map[*stream]*leaderReport
+	cordoned        map[string]struct{}
 	cachedBrokers   []*client.Broker
 	cachedServerIDs map[string]struct{}
 	lastCached      time.Time
@@ -103,6 +148,7 @@ func newMetadataAPI(s *Server) *metadataAPI {
 		Server:        s,
 		streams:       make(map[string]subjectStreams),
 		leaderReports: make(map[*stream]*leaderReport),
+		cordoned:      make(map[string]struct{}),
 	}
 }
 
@@ -271,15 +317,18 @@ func (m *metadataAPI) createMetadataResponse(streams []*client.StreamDescriptor)
 // response. This operation is replicated by Raft. The metadata leader will
 // select replicationFactor nodes to participate in the stream and a leader. If
 // successful, this will return once the stream has been replicated to the
-// cluster and the stream leader has started.
-func (m *metadataAPI) CreateStream(ctx context.Context, req *client.CreateStreamRequest) *status.Status {
+// cluster and the stream leader has started. The given stream holds the
+// stream's subject, name, group, replication factor, and settings, such as a
+// mirror source or flush policy. Its replicas, leader, and ISR are set by the
+// metadata leader.
+func (m *metadataAPI) CreateStream(ctx context.Context, stream *proto.Stream) *status.Status {
 	// Forward the request if we're not the leader.
 	if !m.IsLeader() {
-		return m.propagateCreateStream(ctx, req)
+		return m.propagateCreateStream(ctx, stream)
 	}
 
 	// Select replicationFactor nodes to participate in the stream.
-	replicas, st := m.getStreamReplicas(req.ReplicationFactor)
+	replicas, st := m.getStreamReplicas(stream.ReplicationFactor)
 	if st != nil {
 		return st
 	}
@@ -287,20 +336,14 @@ func (m *metadataAPI) CreateStream(ctx context.Context, req *client.CreateStream
 	// Select a leader at random.
 	leader := selectRandomReplica(replicas)
 
+	stream.Replicas = replicas
+	stream.Leader = leader
+	stream.Isr = replicas
+
 	// Replicate stream create through Raft.
 	op := &proto.RaftLog{
-		Op: proto.Op_CREATE_STREAM,
-		CreateStreamOp: &proto.CreateStreamOp{
-			Stream: &proto.Stream{
-				Subject:           req.Subject,
-				Name:              req.Name,
-				Group:             req.Group,
-				ReplicationFactor: req.ReplicationFactor,
-				Replicas:          replicas,
-				Leader:            leader,
-				Isr:               replicas,
-			},
-		},
+		Op:             proto.Op_CREATE_STREAM,
+		CreateStreamOp: &proto.CreateStreamOp{Stream: stream},
 	}
 
 	// Wait on result of replication.
@@ -320,7 +363,7 @@ func (m *metadataAPI) CreateStream(ctx context.Context, req *client.CreateStream
 	}
 
 	// Wait for leader to create stream (best effort).
-	m.waitForStreamLeader(ctx, req.Subject, req.Name, leader)
+	m.waitForStreamLeader(ctx, stream.Subject, stream.Name, leader)
 
 	return nil
 }
@@ -447,6 +490,242 @@ func (m *metadataAPI) ReportLeader(ctx context.Context, req *proto.ReportLeaderO
 	return reported.addWitness(req.Replica)
 }
 
+// HandOffLeader moves leadership of the specified stream from its current
+// leader to another ISR member if this server is the metadata leader. If it is
+// not, it will forward the request to the leader and return the response. This
+// is used by a stream leader which is shutting down to hand off leadership
+// rather than waiting for its followers to report it as failed.
+func (m *metadataAPI) HandOffLeader(ctx context.Context, req *proto.HandOffLeaderOp) *status.Status {
+	// Forward the request if we're not the leader.
+	if !m.IsLeader() {
+		return m.propagateHandOffLeader(ctx, req)
+	}
+
+	// Verify the stream exists.
+	stream := m.GetStream(req.Subject, req.Name)
+	if stream == nil {
+		return status.New(codes.FailedPrecondition, fmt.Sprintf("No such stream [subject=%s, name=%s]",
+			req.Subject, req.Name))
+	}
+
+	// Check the leader epoch.
+	leader, epoch := stream.GetLeader()
+	if req.Leader != leader || req.LeaderEpoch != epoch {
+		return status.New(
+			codes.FailedPrecondition,
+			fmt.Sprintf("Leader generation mismatch, current leader: %s epoch: %d, got leader: %s epoch: %d",
+				leader, epoch, req.Leader, req.LeaderEpoch))
+	}
+
+	return m.electNewStreamLeader(stream)
+}
+
+// CordonServer marks the specified server as cordoned, or removes the mark, if
+// this server is the metadata leader. If it is not, it will forward the
+// request to the leader and return the response. A cordoned server isn't
+// selected for new stream replicas or elected stream leader. This operation is
+// replicated by Raft.
+func (m *metadataAPI) CordonServer(ctx context.Context, req *proto.CordonServerOp) *status.Status {
+	// Forward the request if we're not the leader.
+	if !m.IsLeader() {
+		return m.propagateCordonServer(ctx, req)
+	}
+
+	// Verify the server exists.
+	if req.Cordoned {
+		if _, st := m.getPeer(req.Id); st != nil {
+			return st
+		}
+	}
+
+	// Replicate cordon through Raft.
+	op := &proto.RaftLog{
+		Op:             proto.Op_CORDON_SERVER,
+		CordonServerOp: req,
+	}
+
+	// Wait on result of replication.
+	if err := m.applyRaftOperation(op).Error(); err != nil {
+		return status.New(codes.Internal, "Failed to cordon server")
+	}
+
+	return nil
+}
+
+// SetCordoned marks the given server as cordoned or removes the mark.
+func (m *metadataAPI) SetCordoned(id string, cordoned bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cordoned {
+		m.cordoned[id] = struct{}{}
+	} else {
+		delete(m.cordoned, id)
+	}
+}
+
+// IsCordoned indicates if the given server is cordoned.
+func (m *metadataAPI) IsCordoned(id string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.cordoned[id]
+	return ok
+}
+
+// GetCordoned returns the IDs of the cordoned servers sorted by ID.
+func (m *metadataAPI) GetCordoned() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ids := make([]string, 0, len(m.cordoned))
+	for id := range m.cordoned {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// PurgeStream removes all messages before the given offset from the stream's
+// log on all replicas. If this server is not the metadata leader, the request
+// is forwarded to it. The purge is replicated through Raft so that each
+// replica advances the start of its log.
+func (m *metadataAPI) PurgeStream(ctx context.Context, req *proto.PurgeStreamOp) *status.Status {
+	// Forward the request if we're not the leader.
+	if !m.IsLeader() {
+		return m.propagatePurgeStream(ctx, req)
+	}
+
+	// Verify the stream exists.
+	if stream := m.GetStream(req.Subject, req.Name); stream == nil {
+		return status.New(codes.NotFound, fmt.Sprintf("No such stream [subject=%s, name=%s]",
+			req.Subject, req.Name))
+	}
+
+	// Replicate stream purge through Raft.
+	op := &proto.RaftLog{
+		Op:            proto.Op_PURGE_STREAM,
+		PurgeStreamOp: req,
+	}
+
+	// Wait on result of replication.
+	if err := m.applyRaftOperation(op).Error(); err != nil {
+		return status.New(codes.Internal, "Failed to purge stream")
+	}
+
+	return nil
+}
+
+// RemoveServer removes the given server from the metadata Raft group so that
+// it's no longer considered for stream replicas and reassigns the replicas of
+// the streams it hosted to other servers. The response holds the number of
+// streams whose replicas were reassigned and the streams whose replicas
+// couldn't be. If this server is not the metadata leader, the request is
+// forwarded to it. The metadata leader cannot remove itself.
+func (m *metadataAPI) RemoveServer(ctx context.Context, req *proto.RemoveServerRequest) (
+	*proto.RemoveServerResponse, *status.Status) {
+
+	// Forward the request if we're not the leader.
+	if !m.IsLeader() {
+		return m.propagateRemoveServer(ctx, req)
+	}
+	if req.Id == m.config.Clustering.ServerID {
+		return nil, status.New(codes.FailedPrecondition, "Cannot remove the metadata leader")
+	}
+	if _, st := m.getPeer(req.Id); st != nil {
+		return nil, st
+	}
+
+	// Remove the server first so that it's not selected as a replacement
+	// replica.
+	if err := m.getRaft().RemoveServer(raft.ServerID(req.Id), 0, 0).Error(); err != nil {
+		return nil, status.New(codes.Internal, fmt.Sprintf("Failed to remove server: %v", err))
+	}
+	m.logger.Infof("metadata: Removed server %s from metadata Raft group", req.Id)
+
+	resp := &proto.RemoveServerResponse{}
+	for _, stream := range m.GetStreams() {
+		ok, st := m.reassignStreamReplicas(stream, req.Id)
+		if st != nil {
+			m.logger.Errorf("metadata: Failed to reassign replica %s for stream %s: %v",
+				req.Id, stream, st.Message())
+			resp.FailedStreams = append(resp.FailedStreams, &proto.FailedReassignment{
+				Subject: stream.Subject,
+				Name:    stream.Name,
+				Error:   st.Message(),
+			})
+			continue
+		}
+		if ok {
+			resp.ReassignedStreams++
+		}
+	}
+
+	return resp, nil
+}
+
+// AddNonVoter adds the given server to the metadata Raft group as a non-voter,
+// which receives the replicated metadata but doesn't vote. Adding a server
+// which is already a voter does not demote it. If this server is not the
+// metadata leader, the request is forwarded to it.
+func (m *metadataAPI) AddNonVoter(ctx context.Context, req *proto.AddNonVoterRequest) *status.Status {
+	// Forward the request if we're not the leader.
+	if !m.IsLeader() {
+		return m.propagateAddNonVoter(ctx, req)
+	}
+	// NATS transport uses ID as addr.
+	if err := m.getRaft().AddNonvoter(raft.ServerID(req.Id), raft.ServerAddress(req.Id), 0, 0).Error(); err != nil {
+		return status.New(codes.Internal, fmt.Sprintf("Failed to add non-voter: %v", err))
+	}
+	m.logger.Infof("metadata: Added server %s to metadata Raft group as non-voter", req.Id)
+	return nil
+}
+
+// PromoteServer promotes the given non-voter in the metadata Raft group to a
+// voter. This does nothing if the server is already a voter. If this server is
+// not the metadata leader, the request is forwarded to it.
+func (m *metadataAPI) PromoteServer(ctx context.Context, req *proto.PromoteServerRequest) *status.Status {
+	// Forward the request if we're not the leader.
+	if !m.IsLeader() {
+		return m.propagatePromoteServer(ctx, req)
+	}
+	server, st := m.getPeer(req.Id)
+	if st != nil {
+		return st
+	}
+	if server.Suffrage == raft.Voter {
+		return nil
+	}
+	if err := m.getRaft().AddVoter(server.ID, server.Address, 0, 0).Error(); err != nil {
+		return status.New(codes.Internal, fmt.Sprintf("Failed to promote server: %v", err))
+	}
+	m.logger.Infof("metadata: Promoted server %s to voter in metadata Raft group", req.Id)
+	return nil
+}
+
+// GetPeers returns the servers in the metadata Raft group along with the ID of
+// the metadata leader, if there is one.
+func (m *metadataAPI) GetPeers() ([]raft.Server, string, error) {
+	future := m.getRaft().GetConfiguration()
+	if err := future.Error(); err != nil {
+		return nil, "", errors.Wrap(err, "failed to get cluster configuration")
+	}
+	// NATS transport uses ID as addr.
+	return future.Configuration().Servers, string(m.getRaft().Leader()), nil
+}
+
+// getPeer returns the server with the given ID in the metadata Raft group. It
+// returns a NotFound status if there is no such server.
+func (m *metadataAPI) getPeer(id string) (raft.Server, *status.Status) {
+	servers, _, err := m.GetPeers()
+	if err != nil {
+		return raft.Server{}, status.New(codes.Internal, err.Error())
+	}
+	for _, server := range servers {
+		if string(server.ID) == id {
+			return server, nil
+		}
+	}
+	return raft.Server{}, status.New(codes.NotFound, fmt.Sprintf("No such server %s", id))
+}
+
 // AddStream adds the given stream to the metadata store. It returns
 // ErrStreamExists if there already exists a stream with the given subject and
 // name. If the stream is recovered, this will not start the stream until
@@ -525,6 +804,7 @@ func (m *metadataAPI) Reset() error {
 		report.cancel()
 	}
 	m.leaderReports = make(map[*stream]*leaderReport)
+	m.cordoned = make(map[string]struct{})
 	return nil
 }
 
@@ -543,7 +823,7 @@ func (m *metadataAPI) LostLeadership() {
 func (m *metadataAPI) getStreamReplicas(replicationFactor int32) ([]string, *status.Status) {
 	// TODO: Currently this selection is random but could be made more
 	// intelligent, e.g. selecting based on current load.
-	ids, err := m.getClusterServerIDs()
+	ids, err := m.getPlacementServerIDs()
 	if err != nil {
 		return nil, status.New(codes.Internal, err.Error())
 	}
@@ -567,6 +847,22 @@ func (m *metadataAPI) getStreamReplicas(replicationFactor int32) ([]string, *sta
 	return replicas, nil
 }
 
+// getPlacementServerIDs returns a list of the broker IDs in the cluster which
+// aren't cordoned and can be selected for new stream replicas.
+func (m *metadataAPI) getPlacementServerIDs() ([]string, error) {
+	ids, err := m.getClusterServerIDs()
+	if err != nil {
+		return nil, err
+	}
+	placement := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !m.IsCordoned(id) {
+			placement = append(placement, id)
+		}
+	}
+	return placement, nil
+}
+
 // getClusterServerIDs returns a list of all the broker IDs in the cluster.
 func (m *metadataAPI) getClusterServerIDs() ([]string, error) {
 	future := m.getRaft().GetConfiguration()
@@ -587,29 +883,75 @@ func (m *metadataAPI) getClusterServerIDs() ([]string, error) {
 // update to the Raft group, and notifies the replica set. This will fail if
 // the current broker is not the metadata leader.
 func (m *metadataAPI) electNewStreamLeader(stream *stream) *status.Status {
-	isr := stream.GetISR()
-	// TODO: add support for "unclean" leader elections.
-	if len(isr) <= 1 {
+	candidates := m.getLeaderCandidates(stream)
+	if len(candidates) == 0 {
 		return status.New(codes.FailedPrecondition, "No ISR candidates")
 	}
+
+	// Select a new leader at random.
+	return m.changeStreamLeader(stream, selectRandomReplica(candidates), false)
+}
+
+// electUncleanStreamLeader selects a new leader for the given stream from the
+// given out-of-sync replicas, applies this update to the Raft group, and
+// notifies the replica set. Committed messages which weren't replicated to the
+// new leader are lost. This will fail if the current broker is not the
+// metadata leader.
+func (m *metadataAPI) electUncleanStreamLeader(stream *stream, replicas []string) *status.Status {
 	var (
-		candidates = make([]string, 0, len(isr)-1)
+		candidates = make([]string, 0, len(replicas))
 		leader, _  = stream.GetLeader()
 	)
-	for _, candidate := range isr {
-		if candidate == leader {
+	for _, candidate := range replicas {
+		if candidate == leader || m.IsCordoned(candidate) {
 			continue
 		}
 		candidates = append(candidates, candidate)
 	}
 
 	if len(candidates) == 0 {
-		return status.New(codes.FailedPrecondition, "No ISR candidates")
+		return status.New(codes.FailedPrecondition, "No ISR or out-of-sync candidates")
 	}
 
 	// Select a new leader at random.
 	leader = selectRandomReplica(candidates)
 
+	m.logger.Errorf("metadata: Electing out-of-sync replica %s as leader for stream %s "+
+		"since it has no ISR candidates, committed messages may be lost", leader, stream)
+
+	if st := m.changeStreamLeader(stream, leader, true); st != nil {
+		return st
+	}
+
+	m.metrics.IncrCounterWithLabels([]string{"stream", "unclean", "leader", "elections"}, 1,
+		[]metrics.Label{
+			{Name: "subject", Value: stream.Subject},
+			{Name: "name", Value: stream.Name},
+		})
+	return nil
+}
+
+// getLeaderCandidates returns the ISR members eligible to become the given
+// stream's leader, which excludes the current leader and cordoned servers.
+func (m *metadataAPI) getLeaderCandidates(stream *stream) []string {
+	var (
+		isr        = stream.GetISR()
+		candidates = make([]string, 0, len(isr))
+		leader, _  = stream.GetLeader()
+	)
+	for _, candidate := range isr {
+		if candidate == leader || m.IsCordoned(candidate) {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// changeStreamLeader applies a change of the given stream's leader to the
+// given replica to the Raft group. If unclean is true, the replica is
+// out-of-sync and the ISR is reset to just the new leader.
+func (m *metadataAPI) changeStreamLeader(stream *stream, leader string, unclean bool) *status.Status {
 	// Replicate leader change through Raft.
 	op := &proto.RaftLog{
 		Op: proto.Op_CHANGE_LEADER,
@@ -617,6 +959,7 @@ func (m *metadataAPI) electNewStreamLeader(stream *stream) *status.Status {
 			Subject: stream.Subject,
 			Name:    stream.Name,
 			Leader:  leader,
+			Unclean: unclean,
 		},
 	}
 
@@ -628,12 +971,83 @@ func (m *metadataAPI) electNewStreamLeader(stream *stream) *status.Status {
 	return nil
 }
 
+// reassignStreamReplicas replaces the removed server in the stream's replicas
+// with a randomly selected server which isn't already a replica, if there is
+// one, and selects a new leader from the ISR if the removed server was the
+// leader. It applies this update to the Raft group, so this will fail if the
+// current broker is not the metadata leader. It returns false if the removed
+// server wasn't a replica for the stream.
+func (m *metadataAPI) reassignStreamReplicas(stream *stream, removed string) (bool, *status.Status) {
+	var (
+		replicas    = stream.GetReplicas()
+		current     = make(map[string]struct{}, len(replicas))
+		newReplicas = make([]string, 0, len(replicas))
+		leader, _   = stream.GetLeader()
+	)
+	for _, replica := range replicas {
+		current[replica] = struct{}{}
+		if replica != removed {
+			newReplicas = append(newReplicas, replica)
+		}
+	}
+	if _, ok := current[removed]; !ok {
+		return false, nil
+	}
+
+	if leader == removed {
+		candidates := make([]string, 0, len(newReplicas))
+		for _, candidate := range stream.GetISR() {
+			if candidate != removed && !m.IsCordoned(candidate) {
+				candidates = append(candidates, candidate)
+			}
+		}
+		if len(candidates) == 0 {
+			return true, status.New(codes.FailedPrecondition, "No ISR candidates")
+		}
+		leader = selectRandomReplica(candidates)
+	}
+
+	// Replace the removed server if there's a server which isn't already a
+	// replica. It's added to the ISR once it catches up with the leader.
+	ids, err := m.getPlacementServerIDs()
+	if err != nil {
+		return true, status.New(codes.Internal, err.Error())
+	}
+	spares := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := current[id]; !ok && id != removed {
+			spares = append(spares, id)
+		}
+	}
+	if len(spares) > 0 {
+		newReplicas = append(newReplicas, selectRandomReplica(spares))
+	}
+
+	// Replicate replica reassignment through Raft.
+	op := &proto.RaftLog{
+		Op: proto.Op_REASSIGN_REPLICAS,
+		ReassignReplicasOp: &proto.ReassignReplicasOp{
+			Subject:  stream.Subject,
+			Name:     stream.Name,
+			Replicas: newReplicas,
+			Leader:   leader,
+		},
+	}
+
+	// Wait on result of replication.
+	if err := m.applyRaftOperation(op).Error(); err != nil {
+		return true, status.New(codes.Internal, "Failed to replicate replica reassignment")
+	}
+
+	return true, nil
+}
+
 // propagateCreateStream forwards a CreateStream request to the metadata leader
 // and returns the response.
-func (m *metadataAPI) propagateCreateStream(ctx context.Context, req *client.CreateStreamRequest) *status.Status {
+func (m *metadataAPI) propagateCreateStream(ctx context.Context, stream *proto.Stream) *status.Status {
 	propagate := &proto.PropagatedRequest{
-		Op:             proto.Op_CREATE_STREAM,
-		CreateStreamOp: req,
+		Op:           proto.Op_CREATE_STREAM,
+		CreateStream: stream,
 	}
 	return m.propagateRequest(ctx, propagate)
 }
@@ -668,12 +1082,90 @@ func (m *metadataAPI) propagateReportLeader(ctx context.Context, req *proto.Repo
 	return m.propagateRequest(ctx, propagate)
 }
 
+// propagatePurgeStream forwards a PurgeStream request to the metadata leader
+// and returns the response.
+func (m *metadataAPI) propagatePurgeStream(ctx context.Context, req *proto.PurgeStreamOp) *status.Status {
+	propagate := &proto.PropagatedRequest{
+		Op:            proto.Op_PURGE_STREAM,
+		PurgeStreamOp: req,
+	}
+	return m.propagateRequest(ctx, propagate)
+}
+
+// propagateHandOffLeader forwards a HandOffLeader request to the metadata
+// leader and returns the response.
+func (m *metadataAPI) propagateHandOffLeader(ctx context.Context, req *proto.HandOffLeaderOp) *status.Status {
+	propagate := &proto.PropagatedRequest{
+		Op:              proto.Op_HAND_OFF_LEADER,
+		HandOffLeaderOp: req,
+	}
+	return m.propagateRequest(ctx, propagate)
+}
+
+// propagateCordonServer forwards a CordonServer request to the metadata leader
+// and returns the response.
+func (m *metadataAPI) propagateCordonServer(ctx context.Context, req *proto.CordonServerOp) *status.Status {
+	propagate := &proto.PropagatedRequest{
+		Op:             proto.Op_CORDON_SERVER,
+		CordonServerOp: req,
+	}
+	return m.propagateRequest(ctx, propagate)
+}
+
+// propagateRemoveServer forwards a RemoveServer request to the metadata leader
+// and returns the response.
+func (m *metadataAPI) propagateRemoveServer(ctx context.Context, req *proto.RemoveServerRequest) (
+	*proto.RemoveServerResponse, *status.Status) {
+
+	propagate := &proto.PropagatedRequest{
+		Op:             proto.Op_REMOVE_SERVER,
+		RemoveServerOp: req,
+	}
+	resp, st := m.sendPropagatedRequest(ctx, propagate)
+	if st != nil {
+		return nil, st
+	}
+	if resp.RemoveServerResp == nil {
+		return &proto.RemoveServerResponse{}, nil
+	}
+	return resp.RemoveServerResp, nil
+}
+
+// propagateAddNonVoter forwards an AddNonVoter request to the metadata leader
+// and returns the response.
+func (m *metadataAPI) propagateAddNonVoter(ctx context.Context, req *proto.AddNonVoterRequest) *status.Status {
+	propagate := &proto.PropagatedRequest{
+		Op:            proto.Op_ADD_NON_VOTER,
+		AddNonVoterOp: req,
+	}
+	return m.propagateRequest(ctx, propagate)
+}
+
+// propagatePromoteServer forwards a PromoteServer request to the metadata
+// leader and returns the response.
+func (m *metadataAPI) propagatePromoteServer(ctx context.Context, req *proto.PromoteServerRequest) *status.Status {
+	propagate := &proto.PropagatedRequest{
+		Op:              proto.Op_PROMOTE_SERVER,
+		PromoteServerOp: req,
+	}
+	return m.propagateRequest(ctx, propagate)
+}
+
 // propagateRequest forwards a metadata request to the metadata leader and
 // returns the response.
 func (m *metadataAPI) propagateRequest(ctx context.Context, req *proto.PropagatedRequest) *status.Status {
+	_, st := m.sendPropagatedRequest(ctx, req)
+	return st
+}
+
+// sendPropagatedRequest forwards a metadata request to the metadata leader and
+// returns the leader's response, or a status if the request failed.
+func (m *metadataAPI) sendPropagatedRequest(ctx context.Context, req *proto.PropagatedRequest) (
+	*proto.PropagatedResponse, *status.Status) {
+
 	// Fail fast if there is no known metadata leader currently.
 	if m.getRaft().Leader() == "" {
-		return status.New(codes.Internal, "No known metadata leader")
+		return nil, status.New(codes.Internal, "No known metadata leader")
 	}
 
 	data, err := req.Marshal()
@@ -689,19 +1181,19 @@ func (m *metadataAPI) propagateRequest(ctx context.Context, req *proto.Propagate
 
 	resp, err := m.nc.RequestWithContext(ctx, m.getPropagateInbox(), data)
 	if err != nil {
-		return status.New(codes.Internal, err.Error())
+		return nil, status.New(codes.Internal, err.Error())
 	}
 
 	r := &proto.PropagatedResponse{}
 	if err := r.Unmarshal(resp.Data); err != nil {
 		m.logger.Errorf("metadata: Invalid response for propagated request: %v", err)
-		return status.New(codes.Internal, "invalid response")
+		return nil, status.New(codes.Internal, "invalid response")
 	}
 	if r.Error != nil {
-		return status.New(codes.Code(r.Error.Code), r.Error.Msg)
+		return nil, status.New(codes.Code(r.Error.Code), r.Error.Msg)
 	}
 
-	return nil
+	return r, nil
 }
 
 // waitForStreamLeader does a best-effort wait for the leader of the given
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/pkg/errors"
+
+	"github.com/liftbridge-io/liftbridge/server/proto"
+)
+
+const (
+	// metricsServiceName prefixes the name of every metric.
+	metricsServiceName = "liftbridge"
+
+	// metricsInterval is the interval metrics are aggregated over.
+	metricsInterval = 10 * time.Second
+
+	// metricsRetain is how long aggregated metrics are kept.
+	metricsRetain = time.Minute
+)
+
+// setupMetrics creates the server's metrics, which are aggregated in memory.
+func (s *Server) setupMetrics() {
+	s.metricsSink = metrics.NewInmemSink(metricsInterval, metricsRetain)
+	conf := metrics.DefaultConfig(metricsServiceName)
+	conf.EnableHostname = false
+	conf.EnableRuntimeMetrics = false
+	// New only fails for a global Metrics, which this isn't.
+	s.metrics, _ = metrics.New(conf, s.metricsSink)
+}
+
+// startMetricsServer starts an HTTP endpoint serving the server's metrics as
+// JSON at /metrics if a metrics listen address is configured.
+func (s *Server) startMetricsServer() error {
+	if s.config.Metrics.Listen == "" {
+		return nil
+	}
+	l, err := net.Listen("tcp", s.config.Metrics.Listen)
+	if err != nil {
+		return errors.Wrap(err, "failed starting metrics listener")
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	s.metricsServer = &http.Server{Handler: mux}
+	s.logger.Infof("Serving metrics on %s", l.Addr())
+	s.startGoroutine(func() {
+		if err := s.metricsServer.Serve(l); err != http.ErrServerClosed {
+			s.logger.Errorf("Metrics server failed: %v", err)
+		}
+	})
+	return nil
+}
+
+// handleMetrics writes the server's aggregated metrics as JSON.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	data, err := s.metricsSink.DisplayMetrics(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		s.logger.Warnf("Failed to write metrics: %v", err)
+	}
+}
+
+// flushObserver returns a function which records the time it takes to flush
+// the given stream's log to disk.
+func (s *Server) flushObserver(stream *proto.Stream) func(time.Duration) {
+	labels := []metrics.Label{
+		{Name: "subject", Value: stream.Subject},
+		{Name: "name", Value: stream.Name},
+	}
+	return func(d time.Duration) {
+		s.metrics.AddSampleWithLabels([]string{"log", "flush", "latency"},
+			float32(d)/float32(time.Millisecond), labels)
+	}
+}
+
+// monitorConsumerLag is a long-running loop which periodically updates the
+// lag metrics of the named consumers of streams on this server and warns
+// about consumers lagging beyond the configured thresholds.
+func (s *Server) monitorConsumerLag() {
+	interval := s.config.Metrics.ConsumerLagInterval
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.shutdownCh:
+			return
+		case <-ticker.C:
+		}
+		for _, stream := range s.metadata.GetStreams() {
+			stream.checkConsumerLag(s.config.Metrics)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	lift "github.com/liftbridge-io/go-liftbridge"
+	client "github.com/liftbridge-io/go-liftbridge/liftbridge-grpc"
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/liftbridge-io/liftbridge/server/commitlog"
+)
+
+const (
+	// mirrorSourceOffsetHeader is the message header containing the offset of
+	// a mirrored message in the source stream.
+	mirrorSourceOffsetHeader = "mirror.source.offset"
+
+	// mirrorSourceTimestampHeader is the message header containing the
+	// timestamp of a mirrored message in the source stream.
+	mirrorSourceTimestampHeader = "mirror.source.timestamp"
+
+	// mirrorReadTimeout is the amount of time to wait to read the last
+	// mirrored message from the log when determining where to resume from.
+	mirrorReadTimeout = 5 * time.Second
+
+	// mirrorRetryWait is the amount of time to wait before reconnecting to
+	// the source cluster after mirroring fails.
+	mirrorRetryWait = time.Second
+)
+
+// startMirroring starts a long-running goroutine which pulls committed
+// messages from the mirror's source stream and places them on the given
+// channel to be sequenced by the leader. Mirroring resumes after the source
+// offset of the last message in the log. Since the source offset is carried in
+// the message headers, this checkpoint is replicated along with the log, so a
+// new leader resumes where the previous one left off.
+func (s *stream) startMirroring(recvChan chan<- *nats.Msg, stop <-chan struct{}) error {
+	next, err := s.mirrorStartOffset()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine mirror start offset")
+	}
+	s.logger.Debugf("Mirroring stream %s from source [subject=%s, name=%s] starting at offset %d",
+		s, s.Mirror.SourceSubject, s.Mirror.SourceName, next)
+	s.srv.startGoroutine(func() {
+		s.mirrorLoop(next, recvChan, stop)
+		s.shutdown.Done()
+	})
+	return nil
+}
+
+// mirrorStartOffset returns the offset in the source stream to resume
+// mirroring from, which is the offset after that of the last mirrored message
+// in the log. Messages published to the stream directly don't have a source
+// offset, so they are skipped over.
+func (s *stream) mirrorStartOffset() (int64, error) {
+	oldest := s.log.OldestOffset()
+	if oldest < 0 {
+		return 0, nil
+	}
+	for offset := s.log.NewestOffset(); offset >= oldest; offset-- {
+		msg, err := s.readMirrorMessage(offset)
+		if err != nil {
+			return 0, err
+		}
+		sourceOffset, ok := msg.Headers()[mirrorSourceOffsetHeader]
+		if !ok {
+			continue
+		}
+		next, err := strconv.ParseInt(string(sourceOffset), 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid source offset for message %d", offset)
+		}
+		return next + 1, nil
+	}
+	return 0, nil
+}
+
+// readMirrorMessage reads the message at the given offset from the log, or
+// the next message if the offset was removed by compaction.
+func (s *stream) readMirrorMessage(offset int64) (commitlog.Message, error) {
+	reader, err := s.log.NewReader(offset, true)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), mirrorReadTimeout)
+	defer cancel()
+	msg, _, _, _, err := reader.ReadMessage(ctx, make([]byte, 28))
+	return msg, err
+}
+
+// mirrorLoop is a long-running loop which pulls messages from the mirror's
+// source stream, starting at the given source offset, until the stop channel
+// is closed. If mirroring fails, e.g. because the source cluster is
+// unavailable, it resumes after the last message received.
+func (s *stream) mirrorLoop(next int64, recvChan chan<- *nats.Msg, stop <-chan struct{}) {
+	for {
+		var err error
+		next, err = s.mirrorSource(next, recvChan, stop)
+		if err == nil {
+			return
+		}
+		s.logger.Errorf("Failed to mirror stream %s from source [subject=%s, name=%s]: %v",
+			s, s.Mirror.SourceSubject, s.Mirror.SourceName, err)
+		select {
+		case <-stop:
+			return
+		case <-time.After(mirrorRetryWait):
+		}
+	}
+}
+
+// mirrorSource subscribes to the mirror's source stream starting at the given
+// source offset and places received messages on the given channel. It returns
+// nil once the stop channel is closed or an error if the subscription fails,
+// along with the source offset to resume from.
+func (s *stream) mirrorSource(next int64, recvChan chan<- *nats.Msg, stop <-chan struct{}) (int64, error) {
+	c, err := lift.Connect(s.Mirror.SourceAddrs)
+	if err != nil {
+		return next, errors.Wrap(err, "failed to connect to source cluster")
+	}
+	defer c.Close()
+
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		errC        = make(chan error, 1)
+		mu          sync.Mutex
+		failed      bool
+	)
+	defer cancel()
+	err = c.Subscribe(ctx, s.Mirror.SourceSubject, s.Mirror.SourceName,
+		func(msg *client.Message, err error) {
+			if err != nil {
+				select {
+				case errC <- err:
+				default:
+				}
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			// Skip messages which were already received in case the client
+			// resubscribed, and any after a message which failed to convert
+			// so that it's retried first.
+			if msg.Offset < next || failed {
+				return
+			}
+			natsMsg, err := mirroredMessage(msg)
+			if err != nil {
+				failed = true
+				select {
+				case errC <- errors.Wrapf(err, "failed to convert source message %d", msg.Offset):
+				default:
+				}
+				return
+			}
+			select {
+			case recvChan <- natsMsg:
+				next = msg.Offset + 1
+			case <-stop:
+			}
+		}, lift.StartAtOffset(next))
+	if err != nil {
+		return next, errors.Wrap(err, "failed to subscribe to source stream")
+	}
+
+	select {
+	case <-stop:
+		err = nil
+	case err = <-errC:
+	}
+	cancel()
+	mu.Lock()
+	defer mu.Unlock()
+	return next, err
+}
+
+// mirroredMessage converts a message received from a mirror's source stream
+// to a NATS message which can be sequenced by the leader. The message keeps
+// its subject and headers and the source offset and timestamp are added to
+// the headers.
+func mirroredMessage(msg *client.Message) (*nats.Msg, error) {
+	headers := make(map[string][]byte, len(msg.Headers)+2)
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	headers[mirrorSourceOffsetHeader] = []byte(strconv.FormatInt(msg.Offset, 10))
+	headers[mirrorSourceTimestampHeader] = []byte(strconv.FormatInt(msg.Timestamp, 10))
+	m := &client.Message{
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   headers,
+		AckPolicy: client.AckPolicy_NONE,
+	}
+	data, err := m.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &nats.Msg{
+		Subject: msg.Subject,
+		Reply:   msg.Reply,
+		Data:    append(envelopeCookie[:envelopeCookieLen:envelopeCookieLen], data...),
+	}, nil
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	lift "github.com/liftbridge-io/go-liftbridge"
+	client "github.com/liftbridge-io/go-liftbridge/liftbridge-grpc"
+	natsdTest "github.com/nats-io/nats-server/v2/test"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/liftbridge-io/liftbridge/server/proto"
+)
+
+func createMirrorStream(t *testing.T, addr string, req *proto.CreateMirrorStreamRequest) error {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = proto.NewAdminAPIClient(conn).CreateMirrorStream(context.Background(), req)
+	return err
+}
+
+// subscribeFromOffset reads num messages from the given stream starting at the
+// given offset.
+func subscribeFromOffset(t *testing.T, c lift.Client, subject, name string, offset int64,
+	num int) []*client.Message {
+
+	var (
+		msgs        = make(chan *client.Message, num)
+		i           = 0
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	defer cancel()
+	err := c.Subscribe(ctx, subject, name, func(msg *client.Message, err error) {
+		if i == num {
+			return
+		}
+		require.NoError(t, err)
+		msgs <- msg
+		i++
+	}, lift.StartAtOffset(offset))
+	require.NoError(t, err)
+	received := make([]*client.Message, num)
+	for i := 0; i < num; i++ {
+		select {
+		case received[i] = <-msgs:
+		case <-time.After(10 * time.Second):
+			t.Fatal("Did not receive all expected messages")
+		}
+	}
+	return received
+}
+
+// Ensure a mirror stream pulls committed messages from a source stream in
+// another cluster, records the source offsets and timestamps in headers, and
+// resumes where it left off after a restart.
+func TestMirrorStream(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server for the source cluster.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure source cluster server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	// Configure mirror cluster server using a separate NATS server.
+	s2Config := getTestConfig("b", true, 5051)
+	s2Config.Clustering.Namespace = "mirror"
+	s2Config.EmbeddedNATS = EmbeddedNATSConfig{Enabled: true, Host: "localhost", Port: 4223}
+	s2 := runServerWithConfig(t, s2Config)
+	defer s2.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+	getMetadataLeader(t, 10*time.Second, s2)
+
+	sourceClient, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer sourceClient.Close()
+
+	mirrorClient, err := lift.Connect([]string{"localhost:5051"})
+	require.NoError(t, err)
+	defer mirrorClient.Close()
+
+	name := "foo"
+	subject := "foo"
+	err = sourceClient.CreateStream(context.Background(), subject, name)
+	require.NoError(t, err)
+
+	publish := func(start, num int) {
+		for i := start; i < start+num; i++ {
+			_, err := sourceClient.Publish(context.Background(), subject,
+				[]byte(strconv.Itoa(i)), lift.Key([]byte(strconv.Itoa(i))))
+			require.NoError(t, err)
+		}
+	}
+	assertMirrored := func(msgs []*client.Message, start int) {
+		for i, msg := range msgs {
+			offset := start + i
+			require.Equal(t, int64(offset), msg.Offset)
+			require.Equal(t, []byte(strconv.Itoa(offset)), msg.Value)
+			require.Equal(t, subject, msg.Subject)
+			require.Equal(t, []byte(strconv.Itoa(offset)), msg.Key)
+			require.Equal(t, []byte(strconv.Itoa(offset)), msg.Headers[mirrorSourceOffsetHeader])
+			_, err := strconv.ParseInt(string(msg.Headers[mirrorSourceTimestampHeader]), 10, 64)
+			require.NoError(t, err)
+		}
+	}
+
+	// Publish some messages before the mirror is created.
+	publish(0, 5)
+
+	// Source settings are required.
+	err = createMirrorStream(t, "localhost:5051", &proto.CreateMirrorStreamRequest{
+		Subject: subject,
+		Name:    name,
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	err = createMirrorStream(t, "localhost:5051", &proto.CreateMirrorStreamRequest{
+		Subject:       subject,
+		Name:          name,
+		SourceAddrs:   []string{"localhost:5050"},
+		SourceSubject: subject,
+		SourceName:    name,
+	})
+	require.NoError(t, err)
+
+	// The mirror catches up on existing messages and continues to pull new
+	// ones.
+	assertMirrored(subscribeFromOffset(t, mirrorClient, subject, name, 0, 5), 0)
+	publish(5, 5)
+	assertMirrored(subscribeFromOffset(t, mirrorClient, subject, name, 5, 5), 5)
+
+	// Stop the mirror cluster, publish more messages, then restart it.
+	s2.Stop()
+	publish(10, 5)
+	s2 = runServerWithConfig(t, s2Config)
+	defer s2.Stop()
+	getStreamLeader(t, 10*time.Second, subject, name, s2)
+
+	// The mirror resumes after the last mirrored message.
+	assertMirrored(subscribeFromOffset(t, mirrorClient, subject, name, 0, 15), 0)
+	waitForHW(t, 5*time.Second, subject, name, 14, s2)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int64(14), s2.metadata.GetStream(subject, name).log.NewestOffset())
+}
+
+// Ensure mirroring resumes after the source offset of the last mirrored
+// message in the log, skipping over messages published to the stream
+// directly.
+func TestMirrorStartOffsetSkipsDirectMessages(t *testing.T) {
+	defer cleanupStorage(t)
+	server := createServer(false)
+	s, err := server.newStream(&proto.Stream{
+		Subject:  "foo",
+		Name:     "foo",
+		Replicas: []string{"a"},
+		Leader:   "a",
+		Isr:      []string{"a"},
+		Mirror:   &proto.StreamMirror{SourceSubject: "bar", SourceName: "bar"},
+	}, false)
+	require.NoError(t, err)
+	defer s.Close()
+
+	// An empty log starts at the beginning of the source stream.
+	next, err := s.mirrorStartOffset()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), next)
+
+	_, err = s.log.Append([]*proto.Message{
+		{Value: []byte("a"), Headers: map[string][]byte{mirrorSourceOffsetHeader: []byte("6")}},
+		{Value: []byte("b"), Headers: map[string][]byte{mirrorSourceOffsetHeader: []byte("7")}},
+		{Value: []byte("c")},
+		{Value: []byte("d")},
+	})
+	require.NoError(t, err)
+
+	next, err = s.mirrorStartOffset()
+	require.NoError(t, err)
+	require.Equal(t, int64(8), next)
+}
@@ -0,0 +1,6057 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: server/proto/admin.proto
+
+/*
+	Package proto is a generated protocol buffer package.
+
+	It is generated from these files:
+		server/proto/admin.proto
+
+	It has these top-level messages:
+		ReloadConfigRequest
+		ReloadConfigResponse
+		CreateMirrorStreamRequest
+		CreateMirrorStreamResponse
+		PurgeStreamRequest
+		PurgeStreamResponse
+		GetCleanerStatusRequest
+		StreamCleanerStatus
+		GetCleanerStatusResponse
+		StreamOffsetsRequest
+		FetchStreamOffsetsRequest
+		StreamOffsets
+		FetchStreamOffsetsResponse
+		GetConsumerLagRequest
+		ConsumerLag
+		GetConsumerLagResponse
+		RemoveServerRequest
+		FailedReassignment
+		RemoveServerResponse
+		AddNonVoterRequest
+		AddNonVoterResponse
+		PromoteServerRequest
+		PromoteServerResponse
+		CordonServerRequest
+		CordonServerResponse
+		UncordonServerRequest
+		UncordonServerResponse
+		ListPeersRequest
+		Peer
+		ListPeersResponse
+*/
+package proto
+
+import proto1 "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import context "golang.org/x/net/context"
+import grpc "google.golang.org/grpc"
+
+import io "io"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto1.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto1.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ReloadConfigRequest is sent to reload a server's configuration file.
+type ReloadConfigRequest struct {
+}
+
+func (m *ReloadConfigRequest) Reset()                    { *m = ReloadConfigRequest{} }
+func (m *ReloadConfigRequest) String() string            { return proto1.CompactTextString(m) }
+func (*ReloadConfigRequest) ProtoMessage()               {}
+func (*ReloadConfigRequest) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{0} }
+
+// ReloadConfigResponse is sent in response to a ReloadConfigRequest.
+type ReloadConfigResponse struct {
+	Applied  []string `protobuf:"bytes,1,rep,name=applied" json:"applied,omitempty"`
+	Rejected []string `protobuf:"bytes,2,rep,name=rejected" json:"rejected,omitempty"`
+}
+
+func (m *ReloadConfigResponse) Reset()                    { *m = ReloadConfigResponse{} }
+func (m *ReloadConfigResponse) String() string            { return proto1.CompactTextString(m) }
+func (*ReloadConfigResponse) ProtoMessage()               {}
+func (*ReloadConfigResponse) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{1} }
+
+func (m *ReloadConfigResponse) GetApplied() []string {
+	if m != nil {
+		return m.Applied
+	}
+	return nil
+}
+
+func (m *ReloadConfigResponse) GetRejected() []string {
+	if m != nil {
+		return m.Rejected
+	}
+	return nil
+}
+
+// CreateMirrorStreamRequest is sent to create a stream which mirrors a stream
+// in another cluster.
+type CreateMirrorStreamRequest struct {
+	Subject           string   `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Name              string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ReplicationFactor int32    `protobuf:"varint,3,opt,name=replicationFactor,proto3" json:"replicationFactor,omitempty"`
+	SourceAddrs       []string `protobuf:"bytes,4,rep,name=sourceAddrs" json:"sourceAddrs,omitempty"`
+	SourceSubject     string   `protobuf:"bytes,5,opt,name=sourceSubject,proto3" json:"sourceSubject,omitempty"`
+	SourceName        string   `protobuf:"bytes,6,opt,name=sourceName,proto3" json:"sourceName,omitempty"`
+}
+
+func (m *CreateMirrorStreamRequest) Reset()                    { *m = CreateMirrorStreamRequest{} }
+func (m *CreateMirrorStreamRequest) String() string            { return proto1.CompactTextString(m) }
+func (*CreateMirrorStreamRequest) ProtoMessage()               {}
+func (*CreateMirrorStreamRequest) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{2} }
+
+func (m *CreateMirrorStreamRequest) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *CreateMirrorStreamRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateMirrorStreamRequest) GetReplicationFactor() int32 {
+	if m != nil {
+		return m.ReplicationFactor
+	}
+	return 0
+}
+
+func (m *CreateMirrorStreamRequest) GetSourceAddrs() []string {
+	if m != nil {
+		return m.SourceAddrs
+	}
+	return nil
+}
+
+func (m *CreateMirrorStreamRequest) GetSourceSubject() string {
+	if m != nil {
+		return m.SourceSubject
+	}
+	return ""
+}
+
+func (m *CreateMirrorStreamRequest) GetSourceName() string {
+	if m != nil {
+		return m.SourceName
+	}
+	return ""
+}
+
+// CreateMirrorStreamResponse is sent in response to a
+// CreateMirrorStreamRequest.
+type CreateMirrorStreamResponse struct {
+}
+
+func (m *CreateMirrorStreamResponse) Reset()                    { *m = CreateMirrorStreamResponse{} }
+func (m *CreateMirrorStreamResponse) String() string            { return proto1.CompactTextString(m) }
+func (*CreateMirrorStreamResponse) ProtoMessage()               {}
+func (*CreateMirrorStreamResponse) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{3} }
+
+// PurgeStreamRequest is sent to remove all messages before an offset or
+// timestamp from a stream.
+type PurgeStreamRequest struct {
+	Subject   string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Offset    int64  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Timestamp int64  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (m *PurgeStreamRequest) Reset()                    { *m = PurgeStreamRequest{} }
+func (m *PurgeStreamRequest) String() string            { return proto1.CompactTextString(m) }
+func (*PurgeStreamRequest) ProtoMessage()               {}
+func (*PurgeStreamRequest) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{4} }
+
+func (m *PurgeStreamRequest) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *PurgeStreamRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PurgeStreamRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *PurgeStreamRequest) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+// PurgeStreamResponse is sent in response to a PurgeStreamRequest.
+type PurgeStreamResponse struct {
+}
+
+func (m *PurgeStreamResponse) Reset()                    { *m = PurgeStreamResponse{} }
+func (m *PurgeStreamResponse) String() string            { return proto1.CompactTextString(m) }
+func (*PurgeStreamResponse) ProtoMessage()               {}
+func (*PurgeStreamResponse) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{5} }
+
+// GetCleanerStatusRequest is sent to get the progress of cleaning the stream
+// logs on a server.
+type GetCleanerStatusRequest struct {
+}
+
+func (m *GetCleanerStatusRequest) Reset()                    { *m = GetCleanerStatusRequest{} }
+func (m *GetCleanerStatusRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetCleanerStatusRequest) ProtoMessage()               {}
+func (*GetCleanerStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{6} }
+
+// StreamCleanerStatus describes the progress of cleaning a stream's log.
+type StreamCleanerStatus struct {
+	Subject              string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Name                 string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Queued               bool   `protobuf:"varint,3,opt,name=queued,proto3" json:"queued,omitempty"`
+	QueuedTimestamp      int64  `protobuf:"varint,4,opt,name=queuedTimestamp,proto3" json:"queuedTimestamp,omitempty"`
+	Cleaning             bool   `protobuf:"varint,5,opt,name=cleaning,proto3" json:"cleaning,omitempty"`
+	LastCleanedTimestamp int64  `protobuf:"varint,6,opt,name=lastCleanedTimestamp,proto3" json:"lastCleanedTimestamp,omitempty"`
+	LastCleanDuration    int64  `protobuf:"varint,7,opt,name=lastCleanDuration,proto3" json:"lastCleanDuration,omitempty"`
+	BytesProcessed       int64  `protobuf:"varint,8,opt,name=bytesProcessed,proto3" json:"bytesProcessed,omitempty"`
+}
+
+func (m *StreamCleanerStatus) Reset()                    { *m = StreamCleanerStatus{} }
+func (m *StreamCleanerStatus) String() string            { return proto1.CompactTextString(m) }
+func (*StreamCleanerStatus) ProtoMessage()               {}
+func (*StreamCleanerStatus) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{7} }
+
+func (m *StreamCleanerStatus) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *StreamCleanerStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *StreamCleanerStatus) GetQueued() bool {
+	if m != nil {
+		return m.Queued
+	}
+	return false
+}
+
+func (m *StreamCleanerStatus) GetQueuedTimestamp() int64 {
+	if m != nil {
+		return m.QueuedTimestamp
+	}
+	return 0
+}
+
+func (m *StreamCleanerStatus) GetCleaning() bool {
+	if m != nil {
+		return m.Cleaning
+	}
+	return false
+}
+
+func (m *StreamCleanerStatus) GetLastCleanedTimestamp() int64 {
+	if m != nil {
+		return m.LastCleanedTimestamp
+	}
+	return 0
+}
+
+func (m *StreamCleanerStatus) GetLastCleanDuration() int64 {
+	if m != nil {
+		return m.LastCleanDuration
+	}
+	return 0
+}
+
+func (m *StreamCleanerStatus) GetBytesProcessed() int64 {
+	if m != nil {
+		return m.BytesProcessed
+	}
+	return 0
+}
+
+// GetCleanerStatusResponse is sent in response to a GetCleanerStatusRequest.
+type GetCleanerStatusResponse struct {
+	Backlog int32                  `protobuf:"varint,1,opt,name=backlog,proto3" json:"backlog,omitempty"`
+	Streams []*StreamCleanerStatus `protobuf:"bytes,2,rep,name=streams" json:"streams,omitempty"`
+}
+
+func (m *GetCleanerStatusResponse) Reset()                    { *m = GetCleanerStatusResponse{} }
+func (m *GetCleanerStatusResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetCleanerStatusResponse) ProtoMessage()               {}
+func (*GetCleanerStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{8} }
+
+func (m *GetCleanerStatusResponse) GetBacklog() int32 {
+	if m != nil {
+		return m.Backlog
+	}
+	return 0
+}
+
+func (m *GetCleanerStatusResponse) GetStreams() []*StreamCleanerStatus {
+	if m != nil {
+		return m.Streams
+	}
+	return nil
+}
+
+// StreamOffsetsRequest identifies a stream to fetch offsets for.
+type StreamOffsetsRequest struct {
+	Subject   string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Timestamp int64  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (m *StreamOffsetsRequest) Reset()                    { *m = StreamOffsetsRequest{} }
+func (m *StreamOffsetsRequest) String() string            { return proto1.CompactTextString(m) }
+func (*StreamOffsetsRequest) ProtoMessage()               {}
+func (*StreamOffsetsRequest) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{9} }
+
+func (m *StreamOffsetsRequest) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *StreamOffsetsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *StreamOffsetsRequest) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+// FetchStreamOffsetsRequest is sent to fetch the offsets of one or more
+// streams.
+type FetchStreamOffsetsRequest struct {
+	Streams []*StreamOffsetsRequest `protobuf:"bytes,1,rep,name=streams" json:"streams,omitempty"`
+}
+
+func (m *FetchStreamOffsetsRequest) Reset()                    { *m = FetchStreamOffsetsRequest{} }
+func (m *FetchStreamOffsetsRequest) String() string            { return proto1.CompactTextString(m) }
+func (*FetchStreamOffsetsRequest) ProtoMessage()               {}
+func (*FetchStreamOffsetsRequest) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{10} }
+
+func (m *FetchStreamOffsetsRequest) GetStreams() []*StreamOffsetsRequest {
+	if m != nil {
+		return m.Streams
+	}
+	return nil
+}
+
+// StreamOffsets contains the offsets of a stream.
+type StreamOffsets struct {
+	Subject         string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Name            string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	EarliestOffset  int64  `protobuf:"varint,3,opt,name=earliestOffset,proto3" json:"earliestOffset,omitempty"`
+	LatestOffset    int64  `protobuf:"varint,4,opt,name=latestOffset,proto3" json:"latestOffset,omitempty"`
+	HighWatermark   int64  `protobuf:"varint,5,opt,name=highWatermark,proto3" json:"highWatermark,omitempty"`
+	TimestampOffset int64  `protobuf:"varint,6,opt,name=timestampOffset,proto3" json:"timestampOffset,omitempty"`
+	ErrorCode       uint32 `protobuf:"varint,7,opt,name=errorCode,proto3" json:"errorCode,omitempty"`
+	Error           string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *StreamOffsets) Reset()                    { *m = StreamOffsets{} }
+func (m *StreamOffsets) String() string            { return proto1.CompactTextString(m) }
+func (*StreamOffsets) ProtoMessage()               {}
+func (*StreamOffsets) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{11} }
+
+func (m *StreamOffsets) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *StreamOffsets) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *StreamOffsets) GetEarliestOffset() int64 {
+	if m != nil {
+		return m.EarliestOffset
+	}
+	return 0
+}
+
+func (m *StreamOffsets) GetLatestOffset() int64 {
+	if m != nil {
+		return m.LatestOffset
+	}
+	return 0
+}
+
+func (m *StreamOffsets) GetHighWatermark() int64 {
+	if m != nil {
+		return m.HighWatermark
+	}
+	return 0
+}
+
+func (m *StreamOffsets) GetTimestampOffset() int64 {
+	if m != nil {
+		return m.TimestampOffset
+	}
+	return 0
+}
+
+func (m *StreamOffsets) GetErrorCode() uint32 {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return 0
+}
+
+func (m *StreamOffsets) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// FetchStreamOffsetsResponse is sent in response to a
+// FetchStreamOffsetsRequest.
+type FetchStreamOffsetsResponse struct {
+	Streams []*StreamOffsets `protobuf:"bytes,1,rep,name=streams" json:"streams,omitempty"`
+}
+
+func (m *FetchStreamOffsetsResponse) Reset()         { *m = FetchStreamOffsetsResponse{} }
+func (m *FetchStreamOffsetsResponse) String() string { return proto1.CompactTextString(m) }
+func (*FetchStreamOffsetsResponse) ProtoMessage()    {}
+func (*FetchStreamOffsetsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorAdmin, []int{12}
+}
+
+func (m *FetchStreamOffsetsResponse) GetStreams() []*StreamOffsets {
+	if m != nil {
+		return m.Streams
+	}
+	return nil
+}
+
+// GetConsumerLagRequest is sent to get the lag of the named consumers
+// subscribed to streams on a server.
+type GetConsumerLagRequest struct {
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (m *GetConsumerLagRequest) Reset()                    { *m = GetConsumerLagRequest{} }
+func (m *GetConsumerLagRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetConsumerLagRequest) ProtoMessage()               {}
+func (*GetConsumerLagRequest) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{13} }
+
+func (m *GetConsumerLagRequest) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *GetConsumerLagRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// ConsumerLag describes how far a named consumer is behind a stream's HW.
+type ConsumerLag struct {
+	Subject             string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Name                string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Consumer            string `protobuf:"bytes,3,opt,name=consumer,proto3" json:"consumer,omitempty"`
+	Subscriptions       int32  `protobuf:"varint,4,opt,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	Offset              int64  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	HighWatermark       int64  `protobuf:"varint,6,opt,name=highWatermark,proto3" json:"highWatermark,omitempty"`
+	Lag                 int64  `protobuf:"varint,7,opt,name=lag,proto3" json:"lag,omitempty"`
+	TimeLag             int64  `protobuf:"varint,8,opt,name=timeLag,proto3" json:"timeLag,omitempty"`
+	LastActiveTimestamp int64  `protobuf:"varint,9,opt,name=lastActiveTimestamp,proto3" json:"lastActiveTimestamp,omitempty"`
+}
+
+func (m *ConsumerLag) Reset()                    { *m = ConsumerLag{} }
+func (m *ConsumerLag) String() string            { return proto1.CompactTextString(m) }
+func (*ConsumerLag) ProtoMessage()               {}
+func (*ConsumerLag) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{14} }
+
+func (m *ConsumerLag) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *ConsumerLag) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ConsumerLag) GetConsumer() string {
+	if m != nil {
+		return m.Consumer
+	}
+	return ""
+}
+
+func (m *ConsumerLag) GetSubscriptions() int32 {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return 0
+}
+
+func (m *ConsumerLag) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ConsumerLag) GetHighWatermark() int64 {
+	if m != nil {
+		return m.HighWatermark
+	}
+	return 0
+}
+
+func (m *ConsumerLag) GetLag() int64 {
+	if m != nil {
+		return m.Lag
+	}
+	return 0
+}
+
+func (m *ConsumerLag) GetTimeLag() int64 {
+	if m != nil {
+		return m.TimeLag
+	}
+	return 0
+}
+
+func (m *ConsumerLag) GetLastActiveTimestamp() int64 {
+	if m != nil {
+		return m.LastActiveTimestamp
+	}
+	return 0
+}
+
+// GetConsumerLagResponse is sent in response to a GetConsumerLagRequest.
+type GetConsumerLagResponse struct {
+	Consumers []*ConsumerLag `protobuf:"bytes,1,rep,name=consumers" json:"consumers,omitempty"`
+}
+
+func (m *GetConsumerLagResponse) Reset()                    { *m = GetConsumerLagResponse{} }
+func (m *GetConsumerLagResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetConsumerLagResponse) ProtoMessage()               {}
+func (*GetConsumerLagResponse) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{15} }
+
+func (m *GetConsumerLagResponse) GetConsumers() []*ConsumerLag {
+	if m != nil {
+		return m.Consumers
+	}
+	return nil
+}
+
+// RemoveServerRequest is sent to remove a server from the metadata Raft group.
+type RemoveServerRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *RemoveServerRequest) Reset()                    { *m = RemoveServerRequest{} }
+func (m *RemoveServerRequest) String() string            { return proto1.CompactTextString(m) }
+func (*RemoveServerRequest) ProtoMessage()               {}
+func (*RemoveServerRequest) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{16} }
+
+func (m *RemoveServerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// FailedReassignment identifies a stream for which a removed server's replica
+// couldn't be reassigned.
+type FailedReassignment struct {
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Error   string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *FailedReassignment) Reset()                    { *m = FailedReassignment{} }
+func (m *FailedReassignment) String() string            { return proto1.CompactTextString(m) }
+func (*FailedReassignment) ProtoMessage()               {}
+func (*FailedReassignment) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{17} }
+
+func (m *FailedReassignment) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *FailedReassignment) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FailedReassignment) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// RemoveServerResponse is sent in response to a RemoveServerRequest.
+type RemoveServerResponse struct {
+	ReassignedStreams int32                 `protobuf:"varint,1,opt,name=reassignedStreams,proto3" json:"reassignedStreams,omitempty"`
+	FailedStreams     []*FailedReassignment `protobuf:"bytes,2,rep,name=failedStreams" json:"failedStreams,omitempty"`
+}
+
+func (m *RemoveServerResponse) Reset()                    { *m = RemoveServerResponse{} }
+func (m *RemoveServerResponse) String() string            { return proto1.CompactTextString(m) }
+func (*RemoveServerResponse) ProtoMessage()               {}
+func (*RemoveServerResponse) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{18} }
+
+func (m *RemoveServerResponse) GetReassignedStreams() int32 {
+	if m != nil {
+		return m.ReassignedStreams
+	}
+	return 0
+}
+
+func (m *RemoveServerResponse) GetFailedStreams() []*FailedReassignment {
+	if m != nil {
+		return m.FailedStreams
+	}
+	return nil
+}
+
+// AddNonVoterRequest is sent to add a server to the metadata Raft group as a
+// non-voter.
+type AddNonVoterRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *AddNonVoterRequest) Reset()                    { *m = AddNonVoterRequest{} }
+func (m *AddNonVoterRequest) String() string            { return proto1.CompactTextString(m) }
+func (*AddNonVoterRequest) ProtoMessage()               {}
+func (*AddNonVoterRequest) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{19} }
+
+func (m *AddNonVoterRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// AddNonVoterResponse is sent in response to an AddNonVoterRequest.
+type AddNonVoterResponse struct {
+}
+
+func (m *AddNonVoterResponse) Reset()                    { *m = AddNonVoterResponse{} }
+func (m *AddNonVoterResponse) String() string            { return proto1.CompactTextString(m) }
+func (*AddNonVoterResponse) ProtoMessage()               {}
+func (*AddNonVoterResponse) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{20} }
+
+// PromoteServerRequest is sent to promote a non-voter in the metadata Raft
+// group to a voter.
+type PromoteServerRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *PromoteServerRequest) Reset()                    { *m = PromoteServerRequest{} }
+func (m *PromoteServerRequest) String() string            { return proto1.CompactTextString(m) }
+func (*PromoteServerRequest) ProtoMessage()               {}
+func (*PromoteServerRequest) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{21} }
+
+func (m *PromoteServerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// PromoteServerResponse is sent in response to a PromoteServerRequest.
+type PromoteServerResponse struct {
+}
+
+func (m *PromoteServerResponse) Reset()                    { *m = PromoteServerResponse{} }
+func (m *PromoteServerResponse) String() string            { return proto1.CompactTextString(m) }
+func (*PromoteServerResponse) ProtoMessage()               {}
+func (*PromoteServerResponse) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{22} }
+
+// CordonServerRequest is sent to exclude a server from new stream replicas
+// and stream leader elections.
+type CordonServerRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *CordonServerRequest) Reset()                    { *m = CordonServerRequest{} }
+func (m *CordonServerRequest) String() string            { return proto1.CompactTextString(m) }
+func (*CordonServerRequest) ProtoMessage()               {}
+func (*CordonServerRequest) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{23} }
+
+func (m *CordonServerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// CordonServerResponse is sent in response to a CordonServerRequest.
+type CordonServerResponse struct {
+}
+
+func (m *CordonServerResponse) Reset()                    { *m = CordonServerResponse{} }
+func (m *CordonServerResponse) String() string            { return proto1.CompactTextString(m) }
+func (*CordonServerResponse) ProtoMessage()               {}
+func (*CordonServerResponse) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{24} }
+
+// UncordonServerRequest is sent to include a cordoned server in new stream
+// replicas and stream leader elections again.
+type UncordonServerRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *UncordonServerRequest) Reset()                    { *m = UncordonServerRequest{} }
+func (m *UncordonServerRequest) String() string            { return proto1.CompactTextString(m) }
+func (*UncordonServerRequest) ProtoMessage()               {}
+func (*UncordonServerRequest) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{25} }
+
+func (m *UncordonServerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// UncordonServerResponse is sent in response to an UncordonServerRequest.
+type UncordonServerResponse struct {
+}
+
+func (m *UncordonServerResponse) Reset()                    { *m = UncordonServerResponse{} }
+func (m *UncordonServerResponse) String() string            { return proto1.CompactTextString(m) }
+func (*UncordonServerResponse) ProtoMessage()               {}
+func (*UncordonServerResponse) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{26} }
+
+// ListPeersRequest is sent to list the servers in the metadata Raft group.
+type ListPeersRequest struct {
+}
+
+func (m *ListPeersRequest) Reset()                    { *m = ListPeersRequest{} }
+func (m *ListPeersRequest) String() string            { return proto1.CompactTextString(m) }
+func (*ListPeersRequest) ProtoMessage()               {}
+func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{27} }
+
+// Peer describes a server in the metadata Raft group.
+type Peer struct {
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Voter    bool   `protobuf:"varint,2,opt,name=voter,proto3" json:"voter,omitempty"`
+	Leader   bool   `protobuf:"varint,3,opt,name=leader,proto3" json:"leader,omitempty"`
+	Cordoned bool   `protobuf:"varint,4,opt,name=cordoned,proto3" json:"cordoned,omitempty"`
+}
+
+func (m *Peer) Reset()                    { *m = Peer{} }
+func (m *Peer) String() string            { return proto1.CompactTextString(m) }
+func (*Peer) ProtoMessage()               {}
+func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{28} }
+
+func (m *Peer) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Peer) GetVoter() bool {
+	if m != nil {
+		return m.Voter
+	}
+	return false
+}
+
+func (m *Peer) GetLeader() bool {
+	if m != nil {
+		return m.Leader
+	}
+	return false
+}
+
+func (m *Peer) GetCordoned() bool {
+	if m != nil {
+		return m.Cordoned
+	}
+	return false
+}
+
+// ListPeersResponse is sent in response to a ListPeersRequest.
+type ListPeersResponse struct {
+	Peers []*Peer `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+}
+
+func (m *ListPeersResponse) Reset()                    { *m = ListPeersResponse{} }
+func (m *ListPeersResponse) String() string            { return proto1.CompactTextString(m) }
+func (*ListPeersResponse) ProtoMessage()               {}
+func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptorAdmin, []int{29} }
+
+func (m *ListPeersResponse) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func init() {
+	proto1.RegisterType((*ReloadConfigRequest)(nil), "proto.ReloadConfigRequest")
+	proto1.RegisterType((*ReloadConfigResponse)(nil), "proto.ReloadConfigResponse")
+	proto1.RegisterType((*CreateMirrorStreamRequest)(nil), "proto.CreateMirrorStreamRequest")
+	proto1.RegisterType((*CreateMirrorStreamResponse)(nil), "proto.CreateMirrorStreamResponse")
+	proto1.RegisterType((*PurgeStreamRequest)(nil), "proto.PurgeStreamRequest")
+	proto1.RegisterType((*PurgeStreamResponse)(nil), "proto.PurgeStreamResponse")
+	proto1.RegisterType((*GetCleanerStatusRequest)(nil), "proto.GetCleanerStatusRequest")
+	proto1.RegisterType((*StreamCleanerStatus)(nil), "proto.StreamCleanerStatus")
+	proto1.RegisterType((*GetCleanerStatusResponse)(nil), "proto.GetCleanerStatusResponse")
+	proto1.RegisterType((*StreamOffsetsRequest)(nil), "proto.StreamOffsetsRequest")
+	proto1.RegisterType((*FetchStreamOffsetsRequest)(nil), "proto.FetchStreamOffsetsRequest")
+	proto1.RegisterType((*StreamOffsets)(nil), "proto.StreamOffsets")
+	proto1.RegisterType((*FetchStreamOffsetsResponse)(nil), "proto.FetchStreamOffsetsResponse")
+	proto1.RegisterType((*GetConsumerLagRequest)(nil), "proto.GetConsumerLagRequest")
+	proto1.RegisterType((*ConsumerLag)(nil), "proto.ConsumerLag")
+	proto1.RegisterType((*GetConsumerLagResponse)(nil), "proto.GetConsumerLagResponse")
+	proto1.RegisterType((*RemoveServerRequest)(nil), "proto.RemoveServerRequest")
+	proto1.RegisterType((*FailedReassignment)(nil), "proto.FailedReassignment")
+	proto1.RegisterType((*RemoveServerResponse)(nil), "proto.RemoveServerResponse")
+	proto1.RegisterType((*AddNonVoterRequest)(nil), "proto.AddNonVoterRequest")
+	proto1.RegisterType((*AddNonVoterResponse)(nil), "proto.AddNonVoterResponse")
+	proto1.RegisterType((*PromoteServerRequest)(nil), "proto.PromoteServerRequest")
+	proto1.RegisterType((*PromoteServerResponse)(nil), "proto.PromoteServerResponse")
+	proto1.RegisterType((*CordonServerRequest)(nil), "proto.CordonServerRequest")
+	proto1.RegisterType((*CordonServerResponse)(nil), "proto.CordonServerResponse")
+	proto1.RegisterType((*UncordonServerRequest)(nil), "proto.UncordonServerRequest")
+	proto1.RegisterType((*UncordonServerResponse)(nil), "proto.UncordonServerResponse")
+	proto1.RegisterType((*ListPeersRequest)(nil), "proto.ListPeersRequest")
+	proto1.RegisterType((*Peer)(nil), "proto.Peer")
+	proto1.RegisterType((*ListPeersResponse)(nil), "proto.ListPeersResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for AdminAPI service
+
+type AdminAPIClient interface {
+	// ReloadConfig re-parses the server's configuration file and applies any
+	// settings which can be changed without a restart. Changed settings which
+	// cannot be applied live are left as they are and reported as rejected.
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
+	// CreateMirrorStream creates a stream which continuously pulls committed
+	// messages from a stream in another cluster. It returns an AlreadyExists
+	// status code if a stream with the given subject and name already exists.
+	CreateMirrorStream(ctx context.Context, in *CreateMirrorStreamRequest, opts ...grpc.CallOption) (*CreateMirrorStreamResponse, error)
+	// GetCleanerStatus returns the progress of cleaning, i.e. applying
+	// retention and compaction to, each stream log on the server along with
+	// the number of logs waiting to be cleaned.
+	GetCleanerStatus(ctx context.Context, in *GetCleanerStatusRequest, opts ...grpc.CallOption) (*GetCleanerStatusResponse, error)
+	// PurgeStream removes all messages before the given offset or timestamp
+	// from a stream's log on all of its replicas, advancing the start of the
+	// log, while leaving the stream itself intact. It returns a NotFound
+	// status code if the stream doesn't exist.
+	PurgeStream(ctx context.Context, in *PurgeStreamRequest, opts ...grpc.CallOption) (*PurgeStreamResponse, error)
+	// FetchStreamOffsets returns the earliest offset, latest offset, and HW of
+	// each of the given streams along with, if a timestamp is given, the
+	// offset for the timestamp. Offsets are only returned by the stream
+	// leader. For a stream which doesn't exist or isn't led by this server,
+	// the error code is set to NotFound or FailedPrecondition, respectively.
+	FetchStreamOffsets(ctx context.Context, in *FetchStreamOffsetsRequest, opts ...grpc.CallOption) (*FetchStreamOffsetsResponse, error)
+	// GetConsumerLag returns the lag of each named consumer subscribed to
+	// streams on the server, optionally limited to a stream subject and name.
+	// Consumers are tracked by the stream leader they subscribe to, so only
+	// the consumers of streams led by this server are returned.
+	GetConsumerLag(ctx context.Context, in *GetConsumerLagRequest, opts ...grpc.CallOption) (*GetConsumerLagResponse, error)
+	// RemoveServer removes a server from the metadata Raft group so that it's
+	// no longer considered for new stream replicas, and reassigns the replicas
+	// of the streams it hosted to other servers. The response lists the
+	// streams whose replicas couldn't be reassigned. If the server receiving
+	// the request isn't the metadata leader, it forwards it to the leader. The
+	// metadata leader cannot remove itself, which returns a FailedPrecondition
+	// status code. It returns a NotFound status code if the server isn't in
+	// the group.
+	RemoveServer(ctx context.Context, in *RemoveServerRequest, opts ...grpc.CallOption) (*RemoveServerResponse, error)
+	// AddNonVoter adds a server to the metadata Raft group as a non-voter,
+	// which receives the replicated metadata but doesn't vote. If the server
+	// receiving the request isn't the metadata leader, it forwards it to the
+	// leader.
+	AddNonVoter(ctx context.Context, in *AddNonVoterRequest, opts ...grpc.CallOption) (*AddNonVoterResponse, error)
+	// PromoteServer promotes a non-voter in the metadata Raft group to a
+	// voter. If the server receiving the request isn't the metadata leader, it
+	// forwards it to the leader. It returns a NotFound status code if the
+	// server isn't in the group.
+	PromoteServer(ctx context.Context, in *PromoteServerRequest, opts ...grpc.CallOption) (*PromoteServerResponse, error)
+	// ListPeers returns the servers in the metadata Raft group, whether each
+	// is a voter or cordoned, and which is the metadata leader.
+	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
+	// CordonServer marks a server as cordoned for maintenance. A cordoned
+	// server keeps serving the stream replicas it hosts, but it isn't
+	// selected for new stream replicas or elected stream leader. It returns
+	// a NotFound status code if the server isn't in the metadata Raft group.
+	CordonServer(ctx context.Context, in *CordonServerRequest, opts ...grpc.CallOption) (*CordonServerResponse, error)
+	// UncordonServer removes the cordon from a server so that it's considered
+	// for new stream replicas and stream leader elections again.
+	UncordonServer(ctx context.Context, in *UncordonServerRequest, opts ...grpc.CallOption) (*UncordonServerResponse, error)
+}
+
+type adminAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminAPIClient(cc *grpc.ClientConn) AdminAPIClient {
+	return &adminAPIClient{cc}
+}
+
+func (c *adminAPIClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	out := new(ReloadConfigResponse)
+	err := grpc.Invoke(ctx, "/proto.AdminAPI/ReloadConfig", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) CreateMirrorStream(ctx context.Context, in *CreateMirrorStreamRequest, opts ...grpc.CallOption) (*CreateMirrorStreamResponse, error) {
+	out := new(CreateMirrorStreamResponse)
+	err := grpc.Invoke(ctx, "/proto.AdminAPI/CreateMirrorStream", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) GetCleanerStatus(ctx context.Context, in *GetCleanerStatusRequest, opts ...grpc.CallOption) (*GetCleanerStatusResponse, error) {
+	out := new(GetCleanerStatusResponse)
+	err := grpc.Invoke(ctx, "/proto.AdminAPI/GetCleanerStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) PurgeStream(ctx context.Context, in *PurgeStreamRequest, opts ...grpc.CallOption) (*PurgeStreamResponse, error) {
+	out := new(PurgeStreamResponse)
+	err := grpc.Invoke(ctx, "/proto.AdminAPI/PurgeStream", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) FetchStreamOffsets(ctx context.Context, in *FetchStreamOffsetsRequest, opts ...grpc.CallOption) (*FetchStreamOffsetsResponse, error) {
+	out := new(FetchStreamOffsetsResponse)
+	err := grpc.Invoke(ctx, "/proto.AdminAPI/FetchStreamOffsets", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) GetConsumerLag(ctx context.Context, in *GetConsumerLagRequest, opts ...grpc.CallOption) (*GetConsumerLagResponse, error) {
+	out := new(GetConsumerLagResponse)
+	err := grpc.Invoke(ctx, "/proto.AdminAPI/GetConsumerLag", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) RemoveServer(ctx context.Context, in *RemoveServerRequest, opts ...grpc.CallOption) (*RemoveServerResponse, error) {
+	out := new(RemoveServerResponse)
+	err := grpc.Invoke(ctx, "/proto.AdminAPI/RemoveServer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) AddNonVoter(ctx context.Context, in *AddNonVoterRequest, opts ...grpc.CallOption) (*AddNonVoterResponse, error) {
+	out := new(AddNonVoterResponse)
+	err := grpc.Invoke(ctx, "/proto.AdminAPI/AddNonVoter", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) PromoteServer(ctx context.Context, in *PromoteServerRequest, opts ...grpc.CallOption) (*PromoteServerResponse, error) {
+	out := new(PromoteServerResponse)
+	err := grpc.Invoke(ctx, "/proto.AdminAPI/PromoteServer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error) {
+	out := new(ListPeersResponse)
+	err := grpc.Invoke(ctx, "/proto.AdminAPI/ListPeers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) CordonServer(ctx context.Context, in *CordonServerRequest, opts ...grpc.CallOption) (*CordonServerResponse, error) {
+	out := new(CordonServerResponse)
+	err := grpc.Invoke(ctx, "/proto.AdminAPI/CordonServer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminAPIClient) UncordonServer(ctx context.Context, in *UncordonServerRequest, opts ...grpc.CallOption) (*UncordonServerResponse, error) {
+	out := new(UncordonServerResponse)
+	err := grpc.Invoke(ctx, "/proto.AdminAPI/UncordonServer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for AdminAPI service
+
+type AdminAPIServer interface {
+	// ReloadConfig re-parses the server's configuration file and applies any
+	// settings which can be changed without a restart. Changed settings which
+	// cannot be applied live are left as they are and reported as rejected.
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
+	// CreateMirrorStream creates a stream which continuously pulls committed
+	// messages from a stream in another cluster. It returns an AlreadyExists
+	// status code if a stream with the given subject and name already exists.
+	CreateMirrorStream(context.Context, *CreateMirrorStreamRequest) (*CreateMirrorStreamResponse, error)
+	// GetCleanerStatus returns the progress of cleaning, i.e. applying
+	// retention and compaction to, each stream log on the server along with
+	// the number of logs waiting to be cleaned.
+	GetCleanerStatus(context.Context, *GetCleanerStatusRequest) (*GetCleanerStatusResponse, error)
+	// PurgeStream removes all messages before the given offset or timestamp
+	// from a stream's log on all of its replicas, advancing the start of the
+	// log, while leaving the stream itself intact. It returns a NotFound
+	// status code if the stream doesn't exist.
+	PurgeStream(context.Context, *PurgeStreamRequest) (*PurgeStreamResponse, error)
+	// FetchStreamOffsets returns the earliest offset, latest offset, and HW of
+	// each of the given streams along with, if a timestamp is given, the
+	// offset for the timestamp. Offsets are only returned by the stream
+	// leader. For a stream which doesn't exist or isn't led by this server,
+	// the error code is set to NotFound or FailedPrecondition, respectively.
+	FetchStreamOffsets(context.Context, *FetchStreamOffsetsRequest) (*FetchStreamOffsetsResponse, error)
+	// GetConsumerLag returns the lag of each named consumer subscribed to
+	// streams on the server, optionally limited to a stream subject and name.
+	// Consumers are tracked by the stream leader they subscribe to, so only
+	// the consumers of streams led by this server are returned.
+	GetConsumerLag(context.Context, *GetConsumerLagRequest) (*GetConsumerLagResponse, error)
+	// RemoveServer removes a server from the metadata Raft group so that it's
+	// no longer considered for new stream replicas, and reassigns the replicas
+	// of the streams it hosted to other servers. The response lists the
+	// streams whose replicas couldn't be reassigned. If the server receiving
+	// the request isn't the metadata leader, it forwards it to the leader. The
+	// metadata leader cannot remove itself, which returns a FailedPrecondition
+	// status code. It returns a NotFound status code if the server isn't in
+	// the group.
+	RemoveServer(context.Context, *RemoveServerRequest) (*RemoveServerResponse, error)
+	// AddNonVoter adds a server to the metadata Raft group as a non-voter,
+	// which receives the replicated metadata but doesn't vote. If the server
+	// receiving the request isn't the metadata leader, it forwards it to the
+	// leader.
+	AddNonVoter(context.Context, *AddNonVoterRequest) (*AddNonVoterResponse, error)
+	// PromoteServer promotes a non-voter in the metadata Raft group to a
+	// voter. If the server receiving the request isn't the metadata leader, it
+	// forwards it to the leader. It returns a NotFound status code if the
+	// server isn't in the group.
+	PromoteServer(context.Context, *PromoteServerRequest) (*PromoteServerResponse, error)
+	// ListPeers returns the servers in the metadata Raft group, whether each
+	// is a voter or cordoned, and which is the metadata leader.
+	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
+	// CordonServer marks a server as cordoned for maintenance. A cordoned
+	// server keeps serving the stream replicas it hosts, but it isn't
+	// selected for new stream replicas or elected stream leader. It returns
+	// a NotFound status code if the server isn't in the metadata Raft group.
+	CordonServer(context.Context, *CordonServerRequest) (*CordonServerResponse, error)
+	// UncordonServer removes the cordon from a server so that it's considered
+	// for new stream replicas and stream leader elections again.
+	UncordonServer(context.Context, *UncordonServerRequest) (*UncordonServerResponse, error)
+}
+
+func RegisterAdminAPIServer(s *grpc.Server, srv AdminAPIServer) {
+	s.RegisterService(&_AdminAPI_serviceDesc, srv)
+}
+
+func _AdminAPI_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminAPI/ReloadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_CreateMirrorStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMirrorStreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).CreateMirrorStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminAPI/CreateMirrorStream",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).CreateMirrorStream(ctx, req.(*CreateMirrorStreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_GetCleanerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCleanerStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).GetCleanerStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminAPI/GetCleanerStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).GetCleanerStatus(ctx, req.(*GetCleanerStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_PurgeStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeStreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).PurgeStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminAPI/PurgeStream",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).PurgeStream(ctx, req.(*PurgeStreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_FetchStreamOffsets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchStreamOffsetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).FetchStreamOffsets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminAPI/FetchStreamOffsets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).FetchStreamOffsets(ctx, req.(*FetchStreamOffsetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_GetConsumerLag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsumerLagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).GetConsumerLag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminAPI/GetConsumerLag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).GetConsumerLag(ctx, req.(*GetConsumerLagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_RemoveServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).RemoveServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminAPI/RemoveServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).RemoveServer(ctx, req.(*RemoveServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_AddNonVoter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddNonVoterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).AddNonVoter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminAPI/AddNonVoter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).AddNonVoter(ctx, req.(*AddNonVoterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_PromoteServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).PromoteServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminAPI/PromoteServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).PromoteServer(ctx, req.(*PromoteServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).ListPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminAPI/ListPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).ListPeers(ctx, req.(*ListPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_CordonServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CordonServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).CordonServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminAPI/CordonServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).CordonServer(ctx, req.(*CordonServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminAPI_UncordonServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UncordonServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).UncordonServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.AdminAPI/UncordonServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).UncordonServer(ctx, req.(*UncordonServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AdminAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.AdminAPI",
+	HandlerType: (*AdminAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _AdminAPI_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "CreateMirrorStream",
+			Handler:    _AdminAPI_CreateMirrorStream_Handler,
+		},
+		{
+			MethodName: "GetCleanerStatus",
+			Handler:    _AdminAPI_GetCleanerStatus_Handler,
+		},
+		{
+			MethodName: "PurgeStream",
+			Handler:    _AdminAPI_PurgeStream_Handler,
+		},
+		{
+			MethodName: "FetchStreamOffsets",
+			Handler:    _AdminAPI_FetchStreamOffsets_Handler,
+		},
+		{
+			MethodName: "GetConsumerLag",
+			Handler:    _AdminAPI_GetConsumerLag_Handler,
+		},
+		{
+			MethodName: "RemoveServer",
+			Handler:    _AdminAPI_RemoveServer_Handler,
+		},
+		{
+			MethodName: "AddNonVoter",
+			Handler:    _AdminAPI_AddNonVoter_Handler,
+		},
+		{
+			MethodName: "PromoteServer",
+			Handler:    _AdminAPI_PromoteServer_Handler,
+		},
+		{
+			MethodName: "ListPeers",
+			Handler:    _AdminAPI_ListPeers_Handler,
+		},
+		{
+			MethodName: "CordonServer",
+			Handler:    _AdminAPI_CordonServer_Handler,
+		},
+		{
+			MethodName: "UncordonServer",
+			Handler:    _AdminAPI_UncordonServer_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "server/proto/admin.proto",
+}
+
+func (m *ReloadConfigRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReloadConfigRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ReloadConfigResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReloadConfigResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Applied) > 0 {
+		for _, s := range m.Applied {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Rejected) > 0 {
+		for _, s := range m.Rejected {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *CreateMirrorStreamRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateMirrorStreamRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Subject) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.ReplicationFactor != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.ReplicationFactor))
+	}
+	if len(m.SourceAddrs) > 0 {
+		for _, s := range m.SourceAddrs {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.SourceSubject) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.SourceSubject)))
+		i += copy(dAtA[i:], m.SourceSubject)
+	}
+	if len(m.SourceName) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.SourceName)))
+		i += copy(dAtA[i:], m.SourceName)
+	}
+	return i, nil
+}
+
+func (m *CreateMirrorStreamResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateMirrorStreamResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *PurgeStreamRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PurgeStreamRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Subject) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.Offset != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.Offset))
+	}
+	if m.Timestamp != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.Timestamp))
+	}
+	return i, nil
+}
+
+func (m *PurgeStreamResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PurgeStreamResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *GetCleanerStatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetCleanerStatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *StreamCleanerStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamCleanerStatus) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Subject) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.Queued {
+		dAtA[i] = 0x18
+		i++
+		if m.Queued {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.QueuedTimestamp != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.QueuedTimestamp))
+	}
+	if m.Cleaning {
+		dAtA[i] = 0x28
+		i++
+		if m.Cleaning {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.LastCleanedTimestamp != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.LastCleanedTimestamp))
+	}
+	if m.LastCleanDuration != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.LastCleanDuration))
+	}
+	if m.BytesProcessed != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.BytesProcessed))
+	}
+	return i, nil
+}
+
+func (m *GetCleanerStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetCleanerStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Backlog != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.Backlog))
+	}
+	if len(m.Streams) > 0 {
+		for _, msg := range m.Streams {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintAdmin(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *StreamOffsetsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamOffsetsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Subject) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.Timestamp != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.Timestamp))
+	}
+	return i, nil
+}
+
+func (m *FetchStreamOffsetsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FetchStreamOffsetsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Streams) > 0 {
+		for _, msg := range m.Streams {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintAdmin(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *StreamOffsets) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamOffsets) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Subject) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.EarliestOffset != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.EarliestOffset))
+	}
+	if m.LatestOffset != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.LatestOffset))
+	}
+	if m.HighWatermark != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.HighWatermark))
+	}
+	if m.TimestampOffset != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.TimestampOffset))
+	}
+	if m.ErrorCode != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.ErrorCode))
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	return i, nil
+}
+
+func (m *FetchStreamOffsetsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FetchStreamOffsetsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Streams) > 0 {
+		for _, msg := range m.Streams {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintAdmin(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *GetConsumerLagRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetConsumerLagRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Subject) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	return i, nil
+}
+
+func (m *ConsumerLag) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConsumerLag) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Subject) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Consumer) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Consumer)))
+		i += copy(dAtA[i:], m.Consumer)
+	}
+	if m.Subscriptions != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.Subscriptions))
+	}
+	if m.Offset != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.Offset))
+	}
+	if m.HighWatermark != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.HighWatermark))
+	}
+	if m.Lag != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.Lag))
+	}
+	if m.TimeLag != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.TimeLag))
+	}
+	if m.LastActiveTimestamp != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.LastActiveTimestamp))
+	}
+	return i, nil
+}
+
+func (m *GetConsumerLagResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetConsumerLagResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Consumers) > 0 {
+		for _, msg := range m.Consumers {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintAdmin(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *RemoveServerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveServerRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	return i, nil
+}
+
+func (m *FailedReassignment) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FailedReassignment) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Subject) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	return i, nil
+}
+
+func (m *RemoveServerResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveServerResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ReassignedStreams != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(m.ReassignedStreams))
+	}
+	if len(m.FailedStreams) > 0 {
+		for _, msg := range m.FailedStreams {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintAdmin(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *AddNonVoterRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddNonVoterRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	return i, nil
+}
+
+func (m *AddNonVoterResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddNonVoterResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *PromoteServerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PromoteServerRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	return i, nil
+}
+
+func (m *PromoteServerResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PromoteServerResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *CordonServerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CordonServerRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	return i, nil
+}
+
+func (m *CordonServerResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CordonServerResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *UncordonServerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UncordonServerRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	return i, nil
+}
+
+func (m *UncordonServerResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UncordonServerResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ListPeersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListPeersRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *Peer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Peer) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if m.Voter {
+		dAtA[i] = 0x10
+		i++
+		if m.Voter {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Leader {
+		dAtA[i] = 0x18
+		i++
+		if m.Leader {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Cordoned {
+		dAtA[i] = 0x20
+		i++
+		if m.Cordoned {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *ListPeersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListPeersResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Peers) > 0 {
+		for _, msg := range m.Peers {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintAdmin(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func encodeVarintAdmin(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *ReloadConfigRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ReloadConfigResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Applied) > 0 {
+		for _, s := range m.Applied {
+			l = len(s)
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	if len(m.Rejected) > 0 {
+		for _, s := range m.Rejected {
+			l = len(s)
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CreateMirrorStreamRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	if m.ReplicationFactor != 0 {
+		n += 1 + sovAdmin(uint64(m.ReplicationFactor))
+	}
+	if len(m.SourceAddrs) > 0 {
+		for _, s := range m.SourceAddrs {
+			l = len(s)
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	l = len(m.SourceSubject)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.SourceName)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateMirrorStreamResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *PurgeStreamRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovAdmin(uint64(m.Offset))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovAdmin(uint64(m.Timestamp))
+	}
+	return n
+}
+
+func (m *PurgeStreamResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetCleanerStatusRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *StreamCleanerStatus) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	if m.Queued {
+		n += 2
+	}
+	if m.QueuedTimestamp != 0 {
+		n += 1 + sovAdmin(uint64(m.QueuedTimestamp))
+	}
+	if m.Cleaning {
+		n += 2
+	}
+	if m.LastCleanedTimestamp != 0 {
+		n += 1 + sovAdmin(uint64(m.LastCleanedTimestamp))
+	}
+	if m.LastCleanDuration != 0 {
+		n += 1 + sovAdmin(uint64(m.LastCleanDuration))
+	}
+	if m.BytesProcessed != 0 {
+		n += 1 + sovAdmin(uint64(m.BytesProcessed))
+	}
+	return n
+}
+
+func (m *GetCleanerStatusResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Backlog != 0 {
+		n += 1 + sovAdmin(uint64(m.Backlog))
+	}
+	if len(m.Streams) > 0 {
+		for _, e := range m.Streams {
+			l = e.Size()
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *StreamOffsetsRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovAdmin(uint64(m.Timestamp))
+	}
+	return n
+}
+
+func (m *FetchStreamOffsetsRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Streams) > 0 {
+		for _, e := range m.Streams {
+			l = e.Size()
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *StreamOffsets) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	if m.EarliestOffset != 0 {
+		n += 1 + sovAdmin(uint64(m.EarliestOffset))
+	}
+	if m.LatestOffset != 0 {
+		n += 1 + sovAdmin(uint64(m.LatestOffset))
+	}
+	if m.HighWatermark != 0 {
+		n += 1 + sovAdmin(uint64(m.HighWatermark))
+	}
+	if m.TimestampOffset != 0 {
+		n += 1 + sovAdmin(uint64(m.TimestampOffset))
+	}
+	if m.ErrorCode != 0 {
+		n += 1 + sovAdmin(uint64(m.ErrorCode))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *FetchStreamOffsetsResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Streams) > 0 {
+		for _, e := range m.Streams {
+			l = e.Size()
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetConsumerLagRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *ConsumerLag) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Consumer)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	if m.Subscriptions != 0 {
+		n += 1 + sovAdmin(uint64(m.Subscriptions))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovAdmin(uint64(m.Offset))
+	}
+	if m.HighWatermark != 0 {
+		n += 1 + sovAdmin(uint64(m.HighWatermark))
+	}
+	if m.Lag != 0 {
+		n += 1 + sovAdmin(uint64(m.Lag))
+	}
+	if m.TimeLag != 0 {
+		n += 1 + sovAdmin(uint64(m.TimeLag))
+	}
+	if m.LastActiveTimestamp != 0 {
+		n += 1 + sovAdmin(uint64(m.LastActiveTimestamp))
+	}
+	return n
+}
+
+func (m *GetConsumerLagResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Consumers) > 0 {
+		for _, e := range m.Consumers {
+			l = e.Size()
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RemoveServerRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *FailedReassignment) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *RemoveServerResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.ReassignedStreams != 0 {
+		n += 1 + sovAdmin(uint64(m.ReassignedStreams))
+	}
+	if len(m.FailedStreams) > 0 {
+		for _, e := range m.FailedStreams {
+			l = e.Size()
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AddNonVoterRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *AddNonVoterResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *PromoteServerRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *PromoteServerResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *CordonServerRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *CordonServerResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *UncordonServerRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	return n
+}
+
+func (m *UncordonServerResponse) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListPeersRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *Peer) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	if m.Voter {
+		n += 2
+	}
+	if m.Leader {
+		n += 2
+	}
+	if m.Cordoned {
+		n += 2
+	}
+	return n
+}
+
+func (m *ListPeersResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovAdmin(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovAdmin(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozAdmin(x uint64) (n int) {
+	return sovAdmin(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ReloadConfigRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReloadConfigRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReloadConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReloadConfigResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReloadConfigResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReloadConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Applied", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Applied = append(m.Applied, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rejected", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rejected = append(m.Rejected, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateMirrorStreamRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateMirrorStreamRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateMirrorStreamRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicationFactor", wireType)
+			}
+			m.ReplicationFactor = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplicationFactor |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceAddrs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceAddrs = append(m.SourceAddrs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceSubject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceSubject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateMirrorStreamResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateMirrorStreamResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateMirrorStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PurgeStreamRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PurgeStreamRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PurgeStreamRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PurgeStreamResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PurgeStreamResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PurgeStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetCleanerStatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetCleanerStatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetCleanerStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamCleanerStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamCleanerStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamCleanerStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Queued", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Queued = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueuedTimestamp", wireType)
+			}
+			m.QueuedTimestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.QueuedTimestamp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cleaning", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Cleaning = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCleanedTimestamp", wireType)
+			}
+			m.LastCleanedTimestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastCleanedTimestamp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCleanDuration", wireType)
+			}
+			m.LastCleanDuration = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastCleanDuration |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BytesProcessed", wireType)
+			}
+			m.BytesProcessed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BytesProcessed |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetCleanerStatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetCleanerStatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetCleanerStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Backlog", wireType)
+			}
+			m.Backlog = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Backlog |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Streams", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Streams = append(m.Streams, &StreamCleanerStatus{})
+			if err := m.Streams[len(m.Streams)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamOffsetsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamOffsetsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamOffsetsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FetchStreamOffsetsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FetchStreamOffsetsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FetchStreamOffsetsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Streams", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Streams = append(m.Streams, &StreamOffsetsRequest{})
+			if err := m.Streams[len(m.Streams)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamOffsets) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamOffsets: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamOffsets: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EarliestOffset", wireType)
+			}
+			m.EarliestOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EarliestOffset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LatestOffset", wireType)
+			}
+			m.LatestOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LatestOffset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HighWatermark", wireType)
+			}
+			m.HighWatermark = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HighWatermark |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimestampOffset", wireType)
+			}
+			m.TimestampOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimestampOffset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorCode", wireType)
+			}
+			m.ErrorCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ErrorCode |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FetchStreamOffsetsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FetchStreamOffsetsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FetchStreamOffsetsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Streams", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Streams = append(m.Streams, &StreamOffsets{})
+			if err := m.Streams[len(m.Streams)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetConsumerLagRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetConsumerLagRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetConsumerLagRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConsumerLag) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConsumerLag: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConsumerLag: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Consumer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Consumer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subscriptions", wireType)
+			}
+			m.Subscriptions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Subscriptions |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HighWatermark", wireType)
+			}
+			m.HighWatermark = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HighWatermark |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lag", wireType)
+			}
+			m.Lag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Lag |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeLag", wireType)
+			}
+			m.TimeLag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeLag |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastActiveTimestamp", wireType)
+			}
+			m.LastActiveTimestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastActiveTimestamp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetConsumerLagResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetConsumerLagResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetConsumerLagResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Consumers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Consumers = append(m.Consumers, &ConsumerLag{})
+			if err := m.Consumers[len(m.Consumers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveServerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveServerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveServerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FailedReassignment) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FailedReassignment: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FailedReassignment: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveServerResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveServerResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveServerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReassignedStreams", wireType)
+			}
+			m.ReassignedStreams = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReassignedStreams |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FailedStreams", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FailedStreams = append(m.FailedStreams, &FailedReassignment{})
+			if err := m.FailedStreams[len(m.FailedStreams)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddNonVoterRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddNonVoterRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddNonVoterRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddNonVoterResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddNonVoterResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddNonVoterResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PromoteServerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PromoteServerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PromoteServerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PromoteServerResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PromoteServerResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PromoteServerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CordonServerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CordonServerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CordonServerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CordonServerResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CordonServerResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CordonServerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UncordonServerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UncordonServerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UncordonServerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UncordonServerResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UncordonServerResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UncordonServerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListPeersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListPeersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListPeersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Peer) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Peer: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Peer: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Voter", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Voter = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Leader = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cordoned", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Cordoned = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListPeersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListPeersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListPeersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, &Peer{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipAdmin(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			iNdEx += length
+			if length < 0 {
+				return 0, ErrInvalidLengthAdmin
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowAdmin
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipAdmin(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthAdmin = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowAdmin   = fmt.Errorf("proto: integer overflow")
+)
+
+func init() { proto1.RegisterFile("server/proto/admin.proto", fileDescriptorAdmin) }
+
+var fileDescriptorAdmin = []byte{
+	// 1158 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcd, 0x72, 0x1b, 0xc5,
+	0x13, 0x8f, 0x24, 0xcb, 0x96, 0xda, 0xb1, 0xff, 0xce, 0x58, 0xb6, 0xd7, 0x6b, 0x47, 0x7f, 0x7b,
+	0x2b, 0x31, 0x3e, 0x50, 0x4e, 0xca, 0x7c, 0x5c, 0xc1, 0x18, 0x4c, 0x85, 0x12, 0xb1, 0x6b, 0x45,
+	0x80, 0x2a, 0x2e, 0x8c, 0x76, 0xdb, 0xf2, 0x26, 0xab, 0x1d, 0x65, 0x66, 0xe4, 0x82, 0x07, 0xe0,
+	0xc8, 0x1d, 0x9e, 0x81, 0x77, 0xe0, 0xcc, 0x91, 0x37, 0x80, 0x32, 0x2f, 0x42, 0xcd, 0xec, 0xec,
+	0xf7, 0xca, 0xe0, 0x70, 0x92, 0xfa, 0x63, 0x7b, 0x7a, 0xfa, 0xd7, 0xbf, 0xee, 0x01, 0x4b, 0x20,
+	0xbf, 0x46, 0xfe, 0x64, 0xca, 0x99, 0x64, 0x4f, 0xa8, 0x3f, 0x09, 0xa2, 0x23, 0xfd, 0x9f, 0xb4,
+	0xf5, 0x8f, 0xb3, 0x01, 0xeb, 0x2e, 0x86, 0x8c, 0xfa, 0xa7, 0x2c, 0xba, 0x0c, 0xc6, 0x2e, 0xbe,
+	0x9e, 0xa1, 0x90, 0xce, 0x00, 0x7a, 0x45, 0xb5, 0x98, 0xb2, 0x48, 0x20, 0xb1, 0x60, 0x89, 0x4e,
+	0xa7, 0x61, 0x80, 0xbe, 0xd5, 0xd8, 0x6b, 0x1d, 0x76, 0xdd, 0x44, 0x24, 0x36, 0x74, 0x38, 0xbe,
+	0x44, 0x4f, 0xa2, 0x6f, 0x35, 0xb5, 0x29, 0x95, 0x9d, 0x3f, 0x1a, 0xb0, 0x7d, 0xca, 0x91, 0x4a,
+	0xfc, 0x3c, 0xe0, 0x9c, 0xf1, 0xa1, 0xe4, 0x48, 0x27, 0xe6, 0x2c, 0x15, 0x53, 0xcc, 0x46, 0xca,
+	0xd5, 0x6a, 0xec, 0x35, 0x54, 0x4c, 0x23, 0x12, 0x02, 0x0b, 0x11, 0x9d, 0xa0, 0xd5, 0xd4, 0x6a,
+	0xfd, 0x9f, 0xbc, 0x0d, 0x0f, 0x38, 0x4e, 0xc3, 0xc0, 0xa3, 0x32, 0x60, 0xd1, 0x19, 0xf5, 0x24,
+	0xe3, 0x56, 0x6b, 0xaf, 0x71, 0xd8, 0x76, 0xab, 0x06, 0xb2, 0x07, 0xcb, 0x82, 0xcd, 0xb8, 0x87,
+	0x27, 0xbe, 0xcf, 0x85, 0xb5, 0xa0, 0x13, 0xcb, 0xab, 0xc8, 0x23, 0x58, 0x89, 0xc5, 0xa1, 0xc9,
+	0xa1, 0xad, 0x0f, 0x2b, 0x2a, 0x49, 0x1f, 0x20, 0x56, 0x3c, 0x57, 0xf9, 0x2c, 0x6a, 0x97, 0x9c,
+	0xc6, 0xd9, 0x05, 0xbb, 0xee, 0x82, 0x71, 0xd5, 0x9c, 0xef, 0x80, 0x5c, 0xcc, 0xf8, 0x18, 0xff,
+	0xcb, 0xbd, 0x37, 0x61, 0x91, 0x5d, 0x5e, 0x0a, 0x94, 0xfa, 0xb2, 0x2d, 0xd7, 0x48, 0x64, 0x17,
+	0xba, 0x32, 0x98, 0xa0, 0x90, 0x74, 0x32, 0xb5, 0x16, 0xb4, 0x29, 0x53, 0x28, 0x78, 0x0b, 0x27,
+	0x9b, 0x84, 0xb6, 0x61, 0xeb, 0x53, 0x94, 0xa7, 0x21, 0xd2, 0x08, 0xf9, 0x50, 0x52, 0x39, 0x13,
+	0x09, 0xf2, 0xbf, 0x34, 0x61, 0x3d, 0xf6, 0x2e, 0x98, 0xef, 0x9e, 0xed, 0xeb, 0x19, 0xce, 0xd0,
+	0xd7, 0xd9, 0x76, 0x5c, 0x23, 0x91, 0x43, 0xf8, 0x5f, 0xfc, 0xef, 0x8b, 0x52, 0xce, 0x65, 0xb5,
+	0xea, 0x27, 0x4f, 0x25, 0x10, 0x44, 0x63, 0x0d, 0x49, 0xc7, 0x4d, 0x65, 0x72, 0x0c, 0xbd, 0x90,
+	0x0a, 0x93, 0x7f, 0x2e, 0xd4, 0xa2, 0x0e, 0x55, 0x6b, 0x53, 0x7d, 0x93, 0xea, 0x3f, 0x9e, 0x71,
+	0xdd, 0x24, 0xd6, 0x92, 0xfe, 0xa0, 0x6a, 0x20, 0x07, 0xb0, 0x3a, 0xfa, 0x5e, 0xa2, 0xb8, 0xe0,
+	0xcc, 0x43, 0x21, 0xd0, 0xb7, 0x3a, 0xda, 0xb5, 0xa4, 0x75, 0x5e, 0x82, 0x55, 0x2d, 0x64, 0xc6,
+	0x95, 0x11, 0xf5, 0x5e, 0x85, 0x6c, 0xac, 0x2b, 0xd6, 0x76, 0x13, 0x91, 0xbc, 0x0b, 0x4b, 0x42,
+	0x97, 0x58, 0x68, 0xaa, 0x2c, 0x1f, 0xdb, 0x31, 0x29, 0x8f, 0x6a, 0x0a, 0xef, 0x26, 0xae, 0xce,
+	0x08, 0x7a, 0xb1, 0xfd, 0x5c, 0x23, 0x2f, 0xde, 0xac, 0x8f, 0x0a, 0xfd, 0xd2, 0x2a, 0xf7, 0x8b,
+	0x0b, 0xdb, 0x67, 0x28, 0xbd, 0xab, 0xda, 0x83, 0xde, 0xcb, 0xd2, 0x6e, 0xe8, 0xb4, 0x77, 0x0a,
+	0x69, 0x17, 0xbd, 0xb3, 0xbc, 0x7f, 0x6c, 0xc2, 0x4a, 0xc1, 0xe3, 0x8e, 0x19, 0x1f, 0xc0, 0x2a,
+	0x52, 0x1e, 0x06, 0x28, 0xe4, 0x79, 0x9e, 0x01, 0x25, 0x2d, 0x71, 0xe0, 0x7e, 0x48, 0x65, 0xe6,
+	0x15, 0x37, 0x56, 0x41, 0xa7, 0xd8, 0x7e, 0x15, 0x8c, 0xaf, 0xbe, 0xa2, 0x12, 0xf9, 0x84, 0xf2,
+	0x57, 0xba, 0xb5, 0x5a, 0x6e, 0x51, 0xa9, 0xba, 0x34, 0x2d, 0x89, 0x09, 0x16, 0xb7, 0x56, 0x59,
+	0xad, 0xaa, 0x89, 0x8a, 0xf0, 0xa7, 0xcc, 0x47, 0xdd, 0x4d, 0x2b, 0x6e, 0xa6, 0x20, 0x3d, 0x68,
+	0x6b, 0x41, 0x37, 0x4f, 0xd7, 0x8d, 0x05, 0x67, 0x00, 0x76, 0x5d, 0x8d, 0x4d, 0xd7, 0x1c, 0x95,
+	0x8b, 0xdc, 0xab, 0x2d, 0x72, 0x5a, 0xdd, 0x4f, 0x60, 0x43, 0x75, 0x20, 0x8b, 0xc4, 0x6c, 0x82,
+	0x7c, 0x40, 0xc7, 0x6f, 0xd4, 0x16, 0xce, 0xcf, 0x4d, 0x58, 0xce, 0x05, 0xb9, 0x23, 0x44, 0x8a,
+	0xac, 0xe6, 0x63, 0x0d, 0x4e, 0xd7, 0x4d, 0x65, 0x3d, 0x60, 0x67, 0x23, 0xe1, 0xf1, 0x60, 0xaa,
+	0xa8, 0x25, 0x34, 0x2e, 0x6d, 0xb7, 0xa8, 0xcc, 0x8d, 0xb7, 0x76, 0x61, 0xbc, 0x55, 0x00, 0x5b,
+	0xac, 0x03, 0x6c, 0x0d, 0x5a, 0x21, 0x1d, 0x1b, 0x3a, 0xb7, 0xc2, 0x38, 0x7f, 0x85, 0xd5, 0x80,
+	0x8e, 0x0d, 0x73, 0x13, 0x91, 0x3c, 0x85, 0x75, 0xc5, 0xf7, 0x13, 0x4f, 0x06, 0xd7, 0x98, 0xcd,
+	0x8e, 0xae, 0xf6, 0xaa, 0x33, 0x39, 0x9f, 0xc1, 0x66, 0xb9, 0xc4, 0x06, 0xac, 0xa7, 0xd0, 0x4d,
+	0xee, 0x99, 0xc0, 0x45, 0x0c, 0x5c, 0x79, 0xf7, 0xcc, 0xc9, 0x79, 0xac, 0xf6, 0xed, 0x84, 0x5d,
+	0xe3, 0x50, 0x2f, 0xe6, 0x04, 0xac, 0x55, 0x68, 0x06, 0xbe, 0xa9, 0x74, 0x33, 0xf0, 0x9d, 0xaf,
+	0x81, 0x9c, 0xd1, 0x20, 0x44, 0xdf, 0x45, 0x2a, 0x44, 0x30, 0x8e, 0x26, 0x18, 0xdd, 0x95, 0xe9,
+	0x69, 0xf7, 0xb5, 0xf2, 0xdd, 0xf7, 0x43, 0x03, 0x7a, 0xc5, 0x0c, 0xcc, 0x5d, 0xf4, 0x62, 0x8d,
+	0x0f, 0x43, 0x7f, 0x98, 0xb6, 0xa0, 0x59, 0xac, 0x25, 0x03, 0xf9, 0x00, 0x56, 0x2e, 0x75, 0x82,
+	0xc3, 0xc2, 0x20, 0xdb, 0x36, 0xb7, 0xaf, 0x26, 0xef, 0x16, 0xfd, 0x9d, 0x47, 0x40, 0x4e, 0x7c,
+	0xff, 0x39, 0x8b, 0xbe, 0x64, 0x72, 0x7e, 0x1d, 0x36, 0x60, 0xbd, 0xe0, 0x65, 0xf6, 0xd7, 0x01,
+	0xf4, 0x2e, 0x38, 0x9b, 0x30, 0xf9, 0x0f, 0x65, 0xdc, 0x82, 0x8d, 0x92, 0x9f, 0x09, 0xf0, 0x18,
+	0xd6, 0x4f, 0x19, 0xf7, 0x59, 0x74, 0xfb, 0xf7, 0x9b, 0xd0, 0x2b, 0xba, 0x99, 0xcf, 0xdf, 0x82,
+	0x8d, 0x17, 0x91, 0xf7, 0x2f, 0x02, 0x58, 0xb0, 0x59, 0x76, 0x34, 0x21, 0x08, 0xac, 0x0d, 0x02,
+	0x21, 0x2f, 0x10, 0x79, 0xba, 0x7b, 0xbf, 0x85, 0x05, 0x25, 0x97, 0xa3, 0x28, 0x24, 0xaf, 0xd5,
+	0xfd, 0x35, 0xbc, 0x1d, 0x37, 0x16, 0x14, 0x65, 0x42, 0xa4, 0xbe, 0xa1, 0x5c, 0xc7, 0x35, 0x52,
+	0x4c, 0x46, 0x75, 0x22, 0xfa, 0xd6, 0x82, 0xd9, 0x9c, 0x46, 0x76, 0xde, 0x87, 0x07, 0xb9, 0x53,
+	0x0d, 0xf2, 0xfb, 0xd0, 0x9e, 0x62, 0xd6, 0xc1, 0xcb, 0x06, 0x43, 0xe5, 0xe4, 0xc6, 0x96, 0xe3,
+	0x5f, 0x97, 0xa0, 0x73, 0xa2, 0x5e, 0x8f, 0x27, 0x17, 0xcf, 0xc8, 0x33, 0xb8, 0x9f, 0x7f, 0x1c,
+	0x92, 0x64, 0x7b, 0xd5, 0x3c, 0x24, 0xed, 0x9d, 0x5a, 0x9b, 0xa9, 0xc1, 0x3d, 0xf2, 0x0d, 0x90,
+	0xea, 0xbb, 0x89, 0xec, 0x25, 0x1c, 0x9a, 0xf7, 0x66, 0xb4, 0xf7, 0x6f, 0xf1, 0x48, 0x83, 0xbf,
+	0x80, 0xb5, 0xf2, 0x72, 0x26, 0x7d, 0xf3, 0xe1, 0x9c, 0xe7, 0x8f, 0xfd, 0xff, 0xb9, 0xf6, 0x34,
+	0xec, 0x19, 0x2c, 0xe7, 0xde, 0x54, 0x24, 0x69, 0xf9, 0xea, 0x0b, 0xcf, 0xb6, 0xeb, 0x4c, 0xf9,
+	0xbb, 0x57, 0xf7, 0x40, 0x7a, 0xf7, 0xb9, 0x6b, 0xd8, 0xde, 0xbf, 0xc5, 0x23, 0x0d, 0x7e, 0x0e,
+	0xab, 0xc5, 0x99, 0x45, 0x76, 0x73, 0x37, 0xab, 0x6c, 0x0b, 0xfb, 0xe1, 0x1c, 0x6b, 0x1a, 0x50,
+	0x83, 0x9e, 0x8d, 0x8d, 0x1c, 0xe8, 0x95, 0x69, 0x66, 0xef, 0xd4, 0xda, 0xf2, 0x05, 0xcc, 0x91,
+	0x3a, 0x2d, 0x60, 0x75, 0x1c, 0xd8, 0x76, 0x9d, 0x29, 0x8d, 0x33, 0x80, 0x95, 0x02, 0xbb, 0x49,
+	0x72, 0x6e, 0xdd, 0x6c, 0xb0, 0x77, 0xeb, 0x8d, 0x69, 0xb4, 0x0f, 0xa1, 0x9b, 0x52, 0x83, 0x6c,
+	0x19, 0xe7, 0x32, 0x45, 0x6d, 0xab, 0x6a, 0xc8, 0x97, 0x28, 0x3f, 0x2d, 0xd2, 0x12, 0xd5, 0x4c,
+	0x1a, 0x7b, 0xa7, 0xd6, 0x96, 0x87, 0xaf, 0x38, 0x37, 0x52, 0xf8, 0x6a, 0xe7, 0x8e, 0xfd, 0x70,
+	0x8e, 0x35, 0x09, 0xf8, 0xd1, 0xda, 0x6f, 0x37, 0xfd, 0xc6, 0xef, 0x37, 0xfd, 0xc6, 0x9f, 0x37,
+	0xfd, 0xc6, 0x4f, 0x7f, 0xf5, 0xef, 0x8d, 0x16, 0xf5, 0x17, 0xef, 0xfc, 0x3d, 0x00, 0x5a, 0x0c,
+	0x14, 0x27, 0x23, 0x0e, 0x00, 0x00,
+}
@@ -15,15 +15,25 @@
 		ExpandISROp
 		ReportLeaderOp
 		ChangeLeaderOp
+		HandOffLeaderOp
+		CordonServerOp
+		PurgeStreamOp
+		ReassignReplicasOp
 		Stream
+		FlushPolicy
+		StreamMirror
 		RaftJoinRequest
 		RaftJoinResponse
 		MetadataSnapshot
 		ReplicationRequest
 		LeaderEpochOffsetRequest
 		LeaderEpochOffsetResponse
+		ForwardedPublishRequest
+		ForwardedPublishResponse
 		PropagatedRequest
 		Error
+		AckError
+		AckExtension
 		PropagatedResponse
 		ServerInfoRequest
 		ServerInfoResponse
@@ -53,26 +63,47 @@ const _ = proto1.ProtoPackageIsVersion2 // please upgrade the proto package
 type Op int32
 
 const (
-	Op_CREATE_STREAM Op = 0
-	Op_SHRINK_ISR    Op = 1
-	Op_REPORT_LEADER Op = 2
-	Op_CHANGE_LEADER Op = 3
-	Op_EXPAND_ISR    Op = 4
+	Op_CREATE_STREAM     Op = 0
+	Op_SHRINK_ISR        Op = 1
+	Op_REPORT_LEADER     Op = 2
+	Op_CHANGE_LEADER     Op = 3
+	Op_EXPAND_ISR        Op = 4
+	Op_PURGE_STREAM      Op = 5
+	Op_REASSIGN_REPLICAS Op = 6
+	Op_HAND_OFF_LEADER   Op = 7
+	Op_CORDON_SERVER     Op = 8
+	Op_REMOVE_SERVER     Op = 9
+	Op_ADD_NON_VOTER     Op = 10
+	Op_PROMOTE_SERVER    Op = 11
 )
 
 var Op_name = map[int32]string{
-	0: "CREATE_STREAM",
-	1: "SHRINK_ISR",
-	2: "REPORT_LEADER",
-	3: "CHANGE_LEADER",
-	4: "EXPAND_ISR",
+	0:  "CREATE_STREAM",
+	1:  "SHRINK_ISR",
+	2:  "REPORT_LEADER",
+	3:  "CHANGE_LEADER",
+	4:  "EXPAND_ISR",
+	5:  "PURGE_STREAM",
+	6:  "REASSIGN_REPLICAS",
+	7:  "HAND_OFF_LEADER",
+	8:  "CORDON_SERVER",
+	9:  "REMOVE_SERVER",
+	10: "ADD_NON_VOTER",
+	11: "PROMOTE_SERVER",
 }
 var Op_value = map[string]int32{
-	"CREATE_STREAM": 0,
-	"SHRINK_ISR":    1,
-	"REPORT_LEADER": 2,
-	"CHANGE_LEADER": 3,
-	"EXPAND_ISR":    4,
+	"CREATE_STREAM":     0,
+	"SHRINK_ISR":        1,
+	"REPORT_LEADER":     2,
+	"CHANGE_LEADER":     3,
+	"EXPAND_ISR":        4,
+	"PURGE_STREAM":      5,
+	"REASSIGN_REPLICAS": 6,
+	"HAND_OFF_LEADER":   7,
+	"CORDON_SERVER":     8,
+	"REMOVE_SERVER":     9,
+	"ADD_NON_VOTER":     10,
+	"PROMOTE_SERVER":    11,
 }
 
 func (x Op) String() string {
@@ -80,6 +111,28 @@ func (x Op) String() string {
 }
 func (Op) EnumDescriptor() ([]byte, []int) { return fileDescriptorInternal, []int{0} }
 
+// AckErrorCode identifies why a stream leader rejected a published message.
+type AckErrorCode int32
+
+const (
+	AckErrorCode_ACK_ERROR_UNKNOWN   AckErrorCode = 0
+	AckErrorCode_NOT_ENOUGH_REPLICAS AckErrorCode = 1
+)
+
+var AckErrorCode_name = map[int32]string{
+	0: "ACK_ERROR_UNKNOWN",
+	1: "NOT_ENOUGH_REPLICAS",
+}
+var AckErrorCode_value = map[string]int32{
+	"ACK_ERROR_UNKNOWN":   0,
+	"NOT_ENOUGH_REPLICAS": 1,
+}
+
+func (x AckErrorCode) String() string {
+	return proto1.EnumName(AckErrorCode_name, int32(x))
+}
+func (AckErrorCode) EnumDescriptor() ([]byte, []int) { return fileDescriptorInternal, []int{1} }
+
 type ServerState struct {
 	ServerID string `protobuf:"bytes,1,opt,name=serverID,proto3" json:"serverID,omitempty"`
 }
@@ -97,11 +150,14 @@ func (m *ServerState) GetServerID() string {
 }
 
 type RaftLog struct {
-	Op             Op              `protobuf:"varint,1,opt,name=op,proto3,enum=proto.Op" json:"op,omitempty"`
-	CreateStreamOp *CreateStreamOp `protobuf:"bytes,2,opt,name=createStreamOp" json:"createStreamOp,omitempty"`
-	ShrinkISROp    *ShrinkISROp    `protobuf:"bytes,3,opt,name=shrinkISROp" json:"shrinkISROp,omitempty"`
-	ChangeLeaderOp *ChangeLeaderOp `protobuf:"bytes,4,opt,name=changeLeaderOp" json:"changeLeaderOp,omitempty"`
-	ExpandISROp    *ExpandISROp    `protobuf:"bytes,5,opt,name=expandISROp" json:"expandISROp,omitempty"`
+	Op                 Op                  `protobuf:"varint,1,opt,name=op,proto3,enum=proto.Op" json:"op,omitempty"`
+	CreateStreamOp     *CreateStreamOp     `protobuf:"bytes,2,opt,name=createStreamOp" json:"createStreamOp,omitempty"`
+	ShrinkISROp        *ShrinkISROp        `protobuf:"bytes,3,opt,name=shrinkISROp" json:"shrinkISROp,omitempty"`
+	ChangeLeaderOp     *ChangeLeaderOp     `protobuf:"bytes,4,opt,name=changeLeaderOp" json:"changeLeaderOp,omitempty"`
+	ExpandISROp        *ExpandISROp        `protobuf:"bytes,5,opt,name=expandISROp" json:"expandISROp,omitempty"`
+	PurgeStreamOp      *PurgeStreamOp      `protobuf:"bytes,6,opt,name=purgeStreamOp" json:"purgeStreamOp,omitempty"`
+	ReassignReplicasOp *ReassignReplicasOp `protobuf:"bytes,7,opt,name=reassignReplicasOp" json:"reassignReplicasOp,omitempty"`
+	CordonServerOp     *CordonServerOp     `protobuf:"bytes,8,opt,name=cordonServerOp" json:"cordonServerOp,omitempty"`
 }
 
 func (m *RaftLog) Reset()                    { *m = RaftLog{} }
@@ -144,6 +200,27 @@ func (m *RaftLog) GetExpandISROp() *ExpandISROp {
 	return nil
 }
 
+func (m *RaftLog) GetPurgeStreamOp() *PurgeStreamOp {
+	if m != nil {
+		return m.PurgeStreamOp
+	}
+	return nil
+}
+
+func (m *RaftLog) GetReassignReplicasOp() *ReassignReplicasOp {
+	if m != nil {
+		return m.ReassignReplicasOp
+	}
+	return nil
+}
+
+func (m *RaftLog) GetCordonServerOp() *CordonServerOp {
+	if m != nil {
+		return m.CordonServerOp
+	}
+	return nil
+}
+
 type CreateStreamOp struct {
 	Stream *Stream `protobuf:"bytes,1,opt,name=stream" json:"stream,omitempty"`
 }
@@ -308,6 +385,7 @@ type ChangeLeaderOp struct {
 	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
 	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Leader  string `protobuf:"bytes,3,opt,name=leader,proto3" json:"leader,omitempty"`
+	Unclean bool   `protobuf:"varint,4,opt,name=unclean,proto3" json:"unclean,omitempty"`
 }
 
 func (m *ChangeLeaderOp) Reset()                    { *m = ChangeLeaderOp{} }
@@ -336,22 +414,179 @@ func (m *ChangeLeaderOp) GetLeader() string {
 	return ""
 }
 
+func (m *ChangeLeaderOp) GetUnclean() bool {
+	if m != nil {
+		return m.Unclean
+	}
+	return false
+}
+
+// HandOffLeaderOp asks the metadata leader to move leadership of a stream from
+// its current leader to another ISR member.
+type HandOffLeaderOp struct {
+	Subject     string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Leader      string `protobuf:"bytes,3,opt,name=leader,proto3" json:"leader,omitempty"`
+	LeaderEpoch uint64 `protobuf:"varint,4,opt,name=leaderEpoch,proto3" json:"leaderEpoch,omitempty"`
+}
+
+func (m *HandOffLeaderOp) Reset()                    { *m = HandOffLeaderOp{} }
+func (m *HandOffLeaderOp) String() string            { return proto1.CompactTextString(m) }
+func (*HandOffLeaderOp) ProtoMessage()               {}
+func (*HandOffLeaderOp) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{7} }
+
+func (m *HandOffLeaderOp) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *HandOffLeaderOp) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HandOffLeaderOp) GetLeader() string {
+	if m != nil {
+		return m.Leader
+	}
+	return ""
+}
+
+func (m *HandOffLeaderOp) GetLeaderEpoch() uint64 {
+	if m != nil {
+		return m.LeaderEpoch
+	}
+	return 0
+}
+
+// CordonServerOp marks a server as cordoned, which excludes it from new stream
+// replica placements and leader elections, or removes the mark.
+type CordonServerOp struct {
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Cordoned bool   `protobuf:"varint,2,opt,name=cordoned,proto3" json:"cordoned,omitempty"`
+}
+
+func (m *CordonServerOp) Reset()                    { *m = CordonServerOp{} }
+func (m *CordonServerOp) String() string            { return proto1.CompactTextString(m) }
+func (*CordonServerOp) ProtoMessage()               {}
+func (*CordonServerOp) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{8} }
+
+func (m *CordonServerOp) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CordonServerOp) GetCordoned() bool {
+	if m != nil {
+		return m.Cordoned
+	}
+	return false
+}
+
+// PurgeStreamOp removes all messages before an offset from a stream's log.
+// Purges by timestamp are resolved to an offset by the stream leader before
+// they are proposed.
+type PurgeStreamOp struct {
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Offset  int64  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (m *PurgeStreamOp) Reset()                    { *m = PurgeStreamOp{} }
+func (m *PurgeStreamOp) String() string            { return proto1.CompactTextString(m) }
+func (*PurgeStreamOp) ProtoMessage()               {}
+func (*PurgeStreamOp) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{9} }
+
+func (m *PurgeStreamOp) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *PurgeStreamOp) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PurgeStreamOp) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+// ReassignReplicasOp changes the set of replicas for a stream and its leader.
+// Replicas which are no longer assigned to the stream are removed from the
+// ISR.
+type ReassignReplicasOp struct {
+	Subject  string   `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Name     string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Replicas []string `protobuf:"bytes,3,rep,name=replicas" json:"replicas,omitempty"`
+	Leader   string   `protobuf:"bytes,4,opt,name=leader,proto3" json:"leader,omitempty"`
+}
+
+func (m *ReassignReplicasOp) Reset()                    { *m = ReassignReplicasOp{} }
+func (m *ReassignReplicasOp) String() string            { return proto1.CompactTextString(m) }
+func (*ReassignReplicasOp) ProtoMessage()               {}
+func (*ReassignReplicasOp) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{10} }
+
+func (m *ReassignReplicasOp) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *ReassignReplicasOp) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReassignReplicasOp) GetReplicas() []string {
+	if m != nil {
+		return m.Replicas
+	}
+	return nil
+}
+
+func (m *ReassignReplicasOp) GetLeader() string {
+	if m != nil {
+		return m.Leader
+	}
+	return ""
+}
+
 type Stream struct {
-	Subject           string   `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
-	Name              string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Group             string   `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
-	ReplicationFactor int32    `protobuf:"varint,4,opt,name=replicationFactor,proto3" json:"replicationFactor,omitempty"`
-	Replicas          []string `protobuf:"bytes,5,rep,name=replicas" json:"replicas,omitempty"`
-	Leader            string   `protobuf:"bytes,6,opt,name=leader,proto3" json:"leader,omitempty"`
-	Isr               []string `protobuf:"bytes,7,rep,name=isr" json:"isr,omitempty"`
-	LeaderEpoch       uint64   `protobuf:"varint,8,opt,name=leaderEpoch,proto3" json:"leaderEpoch,omitempty"`
-	Epoch             uint64   `protobuf:"varint,9,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Subject                      string        `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Name                         string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Group                        string        `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
+	ReplicationFactor            int32         `protobuf:"varint,4,opt,name=replicationFactor,proto3" json:"replicationFactor,omitempty"`
+	Replicas                     []string      `protobuf:"bytes,5,rep,name=replicas" json:"replicas,omitempty"`
+	Leader                       string        `protobuf:"bytes,6,opt,name=leader,proto3" json:"leader,omitempty"`
+	Isr                          []string      `protobuf:"bytes,7,rep,name=isr" json:"isr,omitempty"`
+	LeaderEpoch                  uint64        `protobuf:"varint,8,opt,name=leaderEpoch,proto3" json:"leaderEpoch,omitempty"`
+	Epoch                        uint64        `protobuf:"varint,9,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Mirror                       *StreamMirror `protobuf:"bytes,10,opt,name=mirror" json:"mirror,omitempty"`
+	FlushPolicy                  *FlushPolicy  `protobuf:"bytes,11,opt,name=flushPolicy" json:"flushPolicy,omitempty"`
+	UncleanLeaderElectionTimeout int64         `protobuf:"varint,12,opt,name=uncleanLeaderElectionTimeout,proto3" json:"uncleanLeaderElectionTimeout,omitempty"`
+	StartOffset                  int64         `protobuf:"varint,13,opt,name=startOffset,proto3" json:"startOffset,omitempty"`
 }
 
 func (m *Stream) Reset()                    { *m = Stream{} }
 func (m *Stream) String() string            { return proto1.CompactTextString(m) }
 func (*Stream) ProtoMessage()               {}
-func (*Stream) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{7} }
+func (*Stream) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{11} }
 
 func (m *Stream) GetSubject() string {
 	if m != nil {
@@ -416,16 +651,105 @@ func (m *Stream) GetEpoch() uint64 {
 	return 0
 }
 
+func (m *Stream) GetMirror() *StreamMirror {
+	if m != nil {
+		return m.Mirror
+	}
+	return nil
+}
+
+func (m *Stream) GetFlushPolicy() *FlushPolicy {
+	if m != nil {
+		return m.FlushPolicy
+	}
+	return nil
+}
+
+func (m *Stream) GetUncleanLeaderElectionTimeout() int64 {
+	if m != nil {
+		return m.UncleanLeaderElectionTimeout
+	}
+	return 0
+}
+
+func (m *Stream) GetStartOffset() int64 {
+	if m != nil {
+		return m.StartOffset
+	}
+	return 0
+}
+
+// FlushPolicy overrides the server's policy for flushing a stream's log to
+// disk.
+type FlushPolicy struct {
+	Messages int64 `protobuf:"varint,1,opt,name=messages,proto3" json:"messages,omitempty"`
+	Interval int64 `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (m *FlushPolicy) Reset()                    { *m = FlushPolicy{} }
+func (m *FlushPolicy) String() string            { return proto1.CompactTextString(m) }
+func (*FlushPolicy) ProtoMessage()               {}
+func (*FlushPolicy) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{12} }
+
+func (m *FlushPolicy) GetMessages() int64 {
+	if m != nil {
+		return m.Messages
+	}
+	return 0
+}
+
+func (m *FlushPolicy) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+// StreamMirror identifies the source stream, in another cluster, a mirror
+// stream replicates messages from.
+type StreamMirror struct {
+	SourceAddrs   []string `protobuf:"bytes,1,rep,name=sourceAddrs" json:"sourceAddrs,omitempty"`
+	SourceSubject string   `protobuf:"bytes,2,opt,name=sourceSubject,proto3" json:"sourceSubject,omitempty"`
+	SourceName    string   `protobuf:"bytes,3,opt,name=sourceName,proto3" json:"sourceName,omitempty"`
+}
+
+func (m *StreamMirror) Reset()                    { *m = StreamMirror{} }
+func (m *StreamMirror) String() string            { return proto1.CompactTextString(m) }
+func (*StreamMirror) ProtoMessage()               {}
+func (*StreamMirror) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{13} }
+
+func (m *StreamMirror) GetSourceAddrs() []string {
+	if m != nil {
+		return m.SourceAddrs
+	}
+	return nil
+}
+
+func (m *StreamMirror) GetSourceSubject() string {
+	if m != nil {
+		return m.SourceSubject
+	}
+	return ""
+}
+
+func (m *StreamMirror) GetSourceName() string {
+	if m != nil {
+		return m.SourceName
+	}
+	return ""
+}
+
 // RaftJoinRequest is a request to join a Raft group.
 type RaftJoinRequest struct {
 	NodeID   string `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
 	NodeAddr string `protobuf:"bytes,2,opt,name=nodeAddr,proto3" json:"nodeAddr,omitempty"`
+	NonVoter bool   `protobuf:"varint,3,opt,name=nonVoter,proto3" json:"nonVoter,omitempty"`
 }
 
 func (m *RaftJoinRequest) Reset()                    { *m = RaftJoinRequest{} }
 func (m *RaftJoinRequest) String() string            { return proto1.CompactTextString(m) }
 func (*RaftJoinRequest) ProtoMessage()               {}
-func (*RaftJoinRequest) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{8} }
+func (*RaftJoinRequest) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{14} }
 
 func (m *RaftJoinRequest) GetNodeID() string {
 	if m != nil {
@@ -441,6 +765,13 @@ func (m *RaftJoinRequest) GetNodeAddr() string {
 	return ""
 }
 
+func (m *RaftJoinRequest) GetNonVoter() bool {
+	if m != nil {
+		return m.NonVoter
+	}
+	return false
+}
+
 // RaftJoinResponse is a response to a RaftJoinRequest.
 type RaftJoinResponse struct {
 	Error string `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
@@ -449,7 +780,7 @@ type RaftJoinResponse struct {
 func (m *RaftJoinResponse) Reset()                    { *m = RaftJoinResponse{} }
 func (m *RaftJoinResponse) String() string            { return proto1.CompactTextString(m) }
 func (*RaftJoinResponse) ProtoMessage()               {}
-func (*RaftJoinResponse) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{9} }
+func (*RaftJoinResponse) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{15} }
 
 func (m *RaftJoinResponse) GetError() string {
 	if m != nil {
@@ -459,13 +790,14 @@ func (m *RaftJoinResponse) GetError() string {
 }
 
 type MetadataSnapshot struct {
-	Streams []*Stream `protobuf:"bytes,1,rep,name=streams" json:"streams,omitempty"`
+	Streams         []*Stream `protobuf:"bytes,1,rep,name=streams" json:"streams,omitempty"`
+	CordonedServers []string  `protobuf:"bytes,2,rep,name=cordonedServers" json:"cordonedServers,omitempty"`
 }
 
 func (m *MetadataSnapshot) Reset()                    { *m = MetadataSnapshot{} }
 func (m *MetadataSnapshot) String() string            { return proto1.CompactTextString(m) }
 func (*MetadataSnapshot) ProtoMessage()               {}
-func (*MetadataSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{10} }
+func (*MetadataSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{16} }
 
 func (m *MetadataSnapshot) GetStreams() []*Stream {
 	if m != nil {
@@ -474,6 +806,13 @@ func (m *MetadataSnapshot) GetStreams() []*Stream {
 	return nil
 }
 
+func (m *MetadataSnapshot) GetCordonedServers() []string {
+	if m != nil {
+		return m.CordonedServers
+	}
+	return nil
+}
+
 type ReplicationRequest struct {
 	ReplicaID string `protobuf:"bytes,1,opt,name=replicaID,proto3" json:"replicaID,omitempty"`
 	Offset    int64  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
@@ -482,7 +821,7 @@ type ReplicationRequest struct {
 func (m *ReplicationRequest) Reset()                    { *m = ReplicationRequest{} }
 func (m *ReplicationRequest) String() string            { return proto1.CompactTextString(m) }
 func (*ReplicationRequest) ProtoMessage()               {}
-func (*ReplicationRequest) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{11} }
+func (*ReplicationRequest) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{17} }
 
 func (m *ReplicationRequest) GetReplicaID() string {
 	if m != nil {
@@ -506,7 +845,7 @@ func (m *LeaderEpochOffsetRequest) Reset()         { *m = LeaderEpochOffsetReque
 func (m *LeaderEpochOffsetRequest) String() string { return proto1.CompactTextString(m) }
 func (*LeaderEpochOffsetRequest) ProtoMessage()    {}
 func (*LeaderEpochOffsetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorInternal, []int{12}
+	return fileDescriptorInternal, []int{18}
 }
 
 func (m *LeaderEpochOffsetRequest) GetLeaderEpoch() uint64 {
@@ -524,7 +863,7 @@ func (m *LeaderEpochOffsetResponse) Reset()         { *m = LeaderEpochOffsetResp
 func (m *LeaderEpochOffsetResponse) String() string { return proto1.CompactTextString(m) }
 func (*LeaderEpochOffsetResponse) ProtoMessage()    {}
 func (*LeaderEpochOffsetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorInternal, []int{13}
+	return fileDescriptorInternal, []int{19}
 }
 
 func (m *LeaderEpochOffsetResponse) GetEndOffset() int64 {
@@ -534,18 +873,88 @@ func (m *LeaderEpochOffsetResponse) GetEndOffset() int64 {
 	return 0
 }
 
+// ForwardedPublishRequest is a message published through a server which isn't
+// the stream leader, forwarded to the leader.
+type ForwardedPublishRequest struct {
+	Message    *proto2.Message `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
+	WaitForAck bool            `protobuf:"varint,2,opt,name=waitForAck,proto3" json:"waitForAck,omitempty"`
+	Timeout    int64           `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (m *ForwardedPublishRequest) Reset()         { *m = ForwardedPublishRequest{} }
+func (m *ForwardedPublishRequest) String() string { return proto1.CompactTextString(m) }
+func (*ForwardedPublishRequest) ProtoMessage()    {}
+func (*ForwardedPublishRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorInternal, []int{20}
+}
+
+func (m *ForwardedPublishRequest) GetMessage() *proto2.Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *ForwardedPublishRequest) GetWaitForAck() bool {
+	if m != nil {
+		return m.WaitForAck
+	}
+	return false
+}
+
+func (m *ForwardedPublishRequest) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+// ForwardedPublishResponse is the response to a ForwardedPublishRequest.
+type ForwardedPublishResponse struct {
+	Ack   *proto2.Ack `protobuf:"bytes,1,opt,name=ack" json:"ack,omitempty"`
+	Error *Error      `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *ForwardedPublishResponse) Reset()         { *m = ForwardedPublishResponse{} }
+func (m *ForwardedPublishResponse) String() string { return proto1.CompactTextString(m) }
+func (*ForwardedPublishResponse) ProtoMessage()    {}
+func (*ForwardedPublishResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorInternal, []int{21}
+}
+
+func (m *ForwardedPublishResponse) GetAck() *proto2.Ack {
+	if m != nil {
+		return m.Ack
+	}
+	return nil
+}
+
+func (m *ForwardedPublishResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 type PropagatedRequest struct {
-	Op             Op                          `protobuf:"varint,1,opt,name=op,proto3,enum=proto.Op" json:"op,omitempty"`
-	CreateStreamOp *proto2.CreateStreamRequest `protobuf:"bytes,2,opt,name=createStreamOp" json:"createStreamOp,omitempty"`
-	ShrinkISROp    *ShrinkISROp                `protobuf:"bytes,3,opt,name=shrinkISROp" json:"shrinkISROp,omitempty"`
-	ReportLeaderOp *ReportLeaderOp             `protobuf:"bytes,4,opt,name=reportLeaderOp" json:"reportLeaderOp,omitempty"`
-	ExpandISROp    *ExpandISROp                `protobuf:"bytes,5,opt,name=expandISROp" json:"expandISROp,omitempty"`
+	Op              Op                           `protobuf:"varint,1,opt,name=op,proto3,enum=proto.Op" json:"op,omitempty"`
+	CreateStreamOp  *proto2.CreateStreamRequest  `protobuf:"bytes,2,opt,name=createStreamOp" json:"createStreamOp,omitempty"`
+	ShrinkISROp     *ShrinkISROp                 `protobuf:"bytes,3,opt,name=shrinkISROp" json:"shrinkISROp,omitempty"`
+	ReportLeaderOp  *ReportLeaderOp              `protobuf:"bytes,4,opt,name=reportLeaderOp" json:"reportLeaderOp,omitempty"`
+	ExpandISROp     *ExpandISROp                 `protobuf:"bytes,5,opt,name=expandISROp" json:"expandISROp,omitempty"`
+	PurgeStreamOp   *PurgeStreamOp               `protobuf:"bytes,7,opt,name=purgeStreamOp" json:"purgeStreamOp,omitempty"`
+	HandOffLeaderOp *HandOffLeaderOp             `protobuf:"bytes,9,opt,name=handOffLeaderOp" json:"handOffLeaderOp,omitempty"`
+	CordonServerOp  *CordonServerOp              `protobuf:"bytes,10,opt,name=cordonServerOp" json:"cordonServerOp,omitempty"`
+	CreateStream    *Stream                      `protobuf:"bytes,12,opt,name=createStream" json:"createStream,omitempty"`
+	RemoveServerOp  *RemoveServerRequest  `protobuf:"bytes,13,opt,name=removeServerOp" json:"removeServerOp,omitempty"`
+	AddNonVoterOp   *AddNonVoterRequest   `protobuf:"bytes,14,opt,name=addNonVoterOp" json:"addNonVoterOp,omitempty"`
+	PromoteServerOp *PromoteServerRequest `protobuf:"bytes,15,opt,name=promoteServerOp" json:"promoteServerOp,omitempty"`
 }
 
 func (m *PropagatedRequest) Reset()                    { *m = PropagatedRequest{} }
 func (m *PropagatedRequest) String() string            { return proto1.CompactTextString(m) }
 func (*PropagatedRequest) ProtoMessage()               {}
-func (*PropagatedRequest) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{14} }
+func (*PropagatedRequest) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{22} }
 
 func (m *PropagatedRequest) GetOp() Op {
 	if m != nil {
@@ -582,70 +991,176 @@ func (m *PropagatedRequest) GetExpandISROp() *ExpandISROp {
 	return nil
 }
 
-type Error struct {
-	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Msg  string `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+func (m *PropagatedRequest) GetPurgeStreamOp() *PurgeStreamOp {
+	if m != nil {
+		return m.PurgeStreamOp
+	}
+	return nil
 }
 
-func (m *Error) Reset()                    { *m = Error{} }
-func (m *Error) String() string            { return proto1.CompactTextString(m) }
-func (*Error) ProtoMessage()               {}
-func (*Error) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{15} }
-
-func (m *Error) GetCode() uint32 {
+func (m *PropagatedRequest) GetHandOffLeaderOp() *HandOffLeaderOp {
 	if m != nil {
-		return m.Code
+		return m.HandOffLeaderOp
 	}
-	return 0
+	return nil
 }
 
-func (m *Error) GetMsg() string {
+func (m *PropagatedRequest) GetCordonServerOp() *CordonServerOp {
 	if m != nil {
-		return m.Msg
+		return m.CordonServerOp
 	}
-	return ""
+	return nil
 }
 
-type PropagatedResponse struct {
-	Op               Op                           `protobuf:"varint,1,opt,name=op,proto3,enum=proto.Op" json:"op,omitempty"`
-	Error            *Error                       `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
-	CreateStreamResp *proto2.CreateStreamResponse `protobuf:"bytes,3,opt,name=createStreamResp" json:"createStreamResp,omitempty"`
+func (m *PropagatedRequest) GetCreateStream() *Stream {
+	if m != nil {
+		return m.CreateStream
+	}
+	return nil
 }
 
-func (m *PropagatedResponse) Reset()                    { *m = PropagatedResponse{} }
-func (m *PropagatedResponse) String() string            { return proto1.CompactTextString(m) }
-func (*PropagatedResponse) ProtoMessage()               {}
-func (*PropagatedResponse) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{16} }
-
-func (m *PropagatedResponse) GetOp() Op {
+func (m *PropagatedRequest) GetRemoveServerOp() *RemoveServerRequest {
 	if m != nil {
-		return m.Op
+		return m.RemoveServerOp
 	}
-	return Op_CREATE_STREAM
+	return nil
 }
 
-func (m *PropagatedResponse) GetError() *Error {
+func (m *PropagatedRequest) GetAddNonVoterOp() *AddNonVoterRequest {
 	if m != nil {
-		return m.Error
+		return m.AddNonVoterOp
 	}
 	return nil
 }
 
-func (m *PropagatedResponse) GetCreateStreamResp() *proto2.CreateStreamResponse {
+func (m *PropagatedRequest) GetPromoteServerOp() *PromoteServerRequest {
 	if m != nil {
-		return m.CreateStreamResp
+		return m.PromoteServerOp
 	}
 	return nil
 }
 
-type ServerInfoRequest struct {
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+type Error struct {
+	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg  string `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (m *Error) Reset()                    { *m = Error{} }
+func (m *Error) String() string            { return proto1.CompactTextString(m) }
+func (*Error) ProtoMessage()               {}
+func (*Error) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{23} }
+
+func (m *Error) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *Error) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+// AckError carries the reason a stream leader rejected a published message.
+type AckError struct {
+	Code AckErrorCode `protobuf:"varint,1,opt,name=code,proto3,enum=proto.AckErrorCode" json:"code,omitempty"`
+	Msg  string       `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (m *AckError) Reset()                    { *m = AckError{} }
+func (m *AckError) String() string            { return proto1.CompactTextString(m) }
+func (*AckError) ProtoMessage()               {}
+func (*AckError) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{24} }
+
+func (m *AckError) GetCode() AckErrorCode {
+	if m != nil {
+		return m.Code
+	}
+	return AckErrorCode_ACK_ERROR_UNKNOWN
+}
+
+func (m *AckError) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+// AckExtension holds the fields the server adds to the client Ack. It's
+// marshaled after the fields of the Ack it extends, so nacks are sent through
+// the regular ack path and clients which don't know about these fields still
+// decode a normal Ack, with an offset of -1. The field numbers are well above
+// the Ack's so that they won't collide with fields added to it.
+type AckExtension struct {
+	Error *AckError `protobuf:"bytes,100,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *AckExtension) Reset()                    { *m = AckExtension{} }
+func (m *AckExtension) String() string            { return proto1.CompactTextString(m) }
+func (*AckExtension) ProtoMessage()               {}
+func (*AckExtension) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{25} }
+
+func (m *AckExtension) GetError() *AckError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type PropagatedResponse struct {
+	Op               Op                           `protobuf:"varint,1,opt,name=op,proto3,enum=proto.Op" json:"op,omitempty"`
+	Error            *Error                       `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	CreateStreamResp *proto2.CreateStreamResponse `protobuf:"bytes,3,opt,name=createStreamResp" json:"createStreamResp,omitempty"`
+	// Reserving = 4 for shrinkISRResp if needed.
+	// Reserving = 5 for reportLeaderResp if needed.
+	// Reserving = 6 for expandISRResp if needed.
+	RemoveServerResp *RemoveServerResponse `protobuf:"bytes,7,opt,name=removeServerResp" json:"removeServerResp,omitempty"`
+}
+
+func (m *PropagatedResponse) Reset()                    { *m = PropagatedResponse{} }
+func (m *PropagatedResponse) String() string            { return proto1.CompactTextString(m) }
+func (*PropagatedResponse) ProtoMessage()               {}
+func (*PropagatedResponse) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{26} }
+
+func (m *PropagatedResponse) GetOp() Op {
+	if m != nil {
+		return m.Op
+	}
+	return Op_CREATE_STREAM
+}
+
+func (m *PropagatedResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *PropagatedResponse) GetCreateStreamResp() *proto2.CreateStreamResponse {
+	if m != nil {
+		return m.CreateStreamResp
+	}
+	return nil
+}
+
+func (m *PropagatedResponse) GetRemoveServerResp() *RemoveServerResponse {
+	if m != nil {
+		return m.RemoveServerResp
+	}
+	return nil
+}
+
+type ServerInfoRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
 func (m *ServerInfoRequest) Reset()                    { *m = ServerInfoRequest{} }
 func (m *ServerInfoRequest) String() string            { return proto1.CompactTextString(m) }
 func (*ServerInfoRequest) ProtoMessage()               {}
-func (*ServerInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{17} }
+func (*ServerInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{27} }
 
 func (m *ServerInfoRequest) GetId() string {
 	if m != nil {
@@ -663,7 +1178,7 @@ type ServerInfoResponse struct {
 func (m *ServerInfoResponse) Reset()                    { *m = ServerInfoResponse{} }
 func (m *ServerInfoResponse) String() string            { return proto1.CompactTextString(m) }
 func (*ServerInfoResponse) ProtoMessage()               {}
-func (*ServerInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{18} }
+func (*ServerInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{28} }
 
 func (m *ServerInfoResponse) GetId() string {
 	if m != nil {
@@ -694,7 +1209,7 @@ type StreamStatusRequest struct {
 func (m *StreamStatusRequest) Reset()                    { *m = StreamStatusRequest{} }
 func (m *StreamStatusRequest) String() string            { return proto1.CompactTextString(m) }
 func (*StreamStatusRequest) ProtoMessage()               {}
-func (*StreamStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{19} }
+func (*StreamStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{29} }
 
 func (m *StreamStatusRequest) GetSubject() string {
 	if m != nil {
@@ -718,7 +1233,7 @@ type StreamStatusResponse struct {
 func (m *StreamStatusResponse) Reset()                    { *m = StreamStatusResponse{} }
 func (m *StreamStatusResponse) String() string            { return proto1.CompactTextString(m) }
 func (*StreamStatusResponse) ProtoMessage()               {}
-func (*StreamStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{20} }
+func (*StreamStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorInternal, []int{30} }
 
 func (m *StreamStatusResponse) GetExists() bool {
 	if m != nil {
@@ -742,21 +1257,32 @@ func init() {
 	proto1.RegisterType((*ExpandISROp)(nil), "proto.ExpandISROp")
 	proto1.RegisterType((*ReportLeaderOp)(nil), "proto.ReportLeaderOp")
 	proto1.RegisterType((*ChangeLeaderOp)(nil), "proto.ChangeLeaderOp")
+	proto1.RegisterType((*HandOffLeaderOp)(nil), "proto.HandOffLeaderOp")
+	proto1.RegisterType((*CordonServerOp)(nil), "proto.CordonServerOp")
+	proto1.RegisterType((*PurgeStreamOp)(nil), "proto.PurgeStreamOp")
+	proto1.RegisterType((*ReassignReplicasOp)(nil), "proto.ReassignReplicasOp")
 	proto1.RegisterType((*Stream)(nil), "proto.Stream")
+	proto1.RegisterType((*FlushPolicy)(nil), "proto.FlushPolicy")
+	proto1.RegisterType((*StreamMirror)(nil), "proto.StreamMirror")
 	proto1.RegisterType((*RaftJoinRequest)(nil), "proto.RaftJoinRequest")
 	proto1.RegisterType((*RaftJoinResponse)(nil), "proto.RaftJoinResponse")
 	proto1.RegisterType((*MetadataSnapshot)(nil), "proto.MetadataSnapshot")
 	proto1.RegisterType((*ReplicationRequest)(nil), "proto.ReplicationRequest")
 	proto1.RegisterType((*LeaderEpochOffsetRequest)(nil), "proto.LeaderEpochOffsetRequest")
 	proto1.RegisterType((*LeaderEpochOffsetResponse)(nil), "proto.LeaderEpochOffsetResponse")
+	proto1.RegisterType((*ForwardedPublishRequest)(nil), "proto.ForwardedPublishRequest")
+	proto1.RegisterType((*ForwardedPublishResponse)(nil), "proto.ForwardedPublishResponse")
 	proto1.RegisterType((*PropagatedRequest)(nil), "proto.PropagatedRequest")
 	proto1.RegisterType((*Error)(nil), "proto.Error")
+	proto1.RegisterType((*AckError)(nil), "proto.AckError")
+	proto1.RegisterType((*AckExtension)(nil), "proto.AckExtension")
 	proto1.RegisterType((*PropagatedResponse)(nil), "proto.PropagatedResponse")
 	proto1.RegisterType((*ServerInfoRequest)(nil), "proto.ServerInfoRequest")
 	proto1.RegisterType((*ServerInfoResponse)(nil), "proto.ServerInfoResponse")
 	proto1.RegisterType((*StreamStatusRequest)(nil), "proto.StreamStatusRequest")
 	proto1.RegisterType((*StreamStatusResponse)(nil), "proto.StreamStatusResponse")
 	proto1.RegisterEnum("proto.Op", Op_name, Op_value)
+	proto1.RegisterEnum("proto.AckErrorCode", AckErrorCode_name, AckErrorCode_value)
 }
 func (m *ServerState) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -842,6 +1368,36 @@ func (m *RaftLog) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n4
 	}
+	if m.PurgeStreamOp != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.PurgeStreamOp.Size()))
+		n5, err := m.PurgeStreamOp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if m.ReassignReplicasOp != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.ReassignReplicasOp.Size()))
+		n6, err := m.ReassignReplicasOp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if m.CordonServerOp != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.CordonServerOp.Size()))
+		n7, err := m.CordonServerOp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
 	return i, nil
 }
 
@@ -864,11 +1420,11 @@ func (m *CreateStreamOp) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintInternal(dAtA, i, uint64(m.Stream.Size()))
-		n5, err := m.Stream.MarshalTo(dAtA[i:])
+		n8, err := m.Stream.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n5
+		i += n8
 	}
 	return i, nil
 }
@@ -1047,10 +1603,20 @@ func (m *ChangeLeaderOp) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintInternal(dAtA, i, uint64(len(m.Leader)))
 		i += copy(dAtA[i:], m.Leader)
 	}
+	if m.Unclean {
+		dAtA[i] = 0x20
+		i++
+		if m.Unclean {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
-func (m *Stream) Marshal() (dAtA []byte, err error) {
+func (m *HandOffLeaderOp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1060,7 +1626,7 @@ func (m *Stream) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Stream) MarshalTo(dAtA []byte) (int, error) {
+func (m *HandOffLeaderOp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -1077,67 +1643,21 @@ func (m *Stream) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintInternal(dAtA, i, uint64(len(m.Name)))
 		i += copy(dAtA[i:], m.Name)
 	}
-	if len(m.Group) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintInternal(dAtA, i, uint64(len(m.Group)))
-		i += copy(dAtA[i:], m.Group)
-	}
-	if m.ReplicationFactor != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.ReplicationFactor))
-	}
-	if len(m.Replicas) > 0 {
-		for _, s := range m.Replicas {
-			dAtA[i] = 0x2a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
 	if len(m.Leader) > 0 {
-		dAtA[i] = 0x32
+		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintInternal(dAtA, i, uint64(len(m.Leader)))
 		i += copy(dAtA[i:], m.Leader)
 	}
-	if len(m.Isr) > 0 {
-		for _, s := range m.Isr {
-			dAtA[i] = 0x3a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
 	if m.LeaderEpoch != 0 {
-		dAtA[i] = 0x40
+		dAtA[i] = 0x20
 		i++
 		i = encodeVarintInternal(dAtA, i, uint64(m.LeaderEpoch))
 	}
-	if m.Epoch != 0 {
-		dAtA[i] = 0x48
-		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.Epoch))
-	}
 	return i, nil
 }
 
-func (m *RaftJoinRequest) Marshal() (dAtA []byte, err error) {
+func (m *CordonServerOp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1147,27 +1667,31 @@ func (m *RaftJoinRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RaftJoinRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *CordonServerOp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.NodeID) > 0 {
+	if len(m.Id) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(len(m.NodeID)))
-		i += copy(dAtA[i:], m.NodeID)
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
 	}
-	if len(m.NodeAddr) > 0 {
-		dAtA[i] = 0x12
+	if m.Cordoned {
+		dAtA[i] = 0x10
+		i++
+		if m.Cordoned {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(len(m.NodeAddr)))
-		i += copy(dAtA[i:], m.NodeAddr)
 	}
 	return i, nil
 }
 
-func (m *RaftJoinResponse) Marshal() (dAtA []byte, err error) {
+func (m *PurgeStreamOp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1177,21 +1701,32 @@ func (m *RaftJoinResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RaftJoinResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *PurgeStreamOp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Error) > 0 {
+	if len(m.Subject) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(len(m.Error)))
-		i += copy(dAtA[i:], m.Error)
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.Offset != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Offset))
 	}
 	return i, nil
 }
 
-func (m *MetadataSnapshot) Marshal() (dAtA []byte, err error) {
+func (m *ReassignReplicasOp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1201,27 +1736,48 @@ func (m *MetadataSnapshot) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *MetadataSnapshot) MarshalTo(dAtA []byte) (int, error) {
+func (m *ReassignReplicasOp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Streams) > 0 {
-		for _, msg := range m.Streams {
-			dAtA[i] = 0xa
+	if len(m.Subject) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Replicas) > 0 {
+		for _, s := range m.Replicas {
+			dAtA[i] = 0x1a
 			i++
-			i = encodeVarintInternal(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
 			}
-			i += n
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
 	}
+	if len(m.Leader) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Leader)))
+		i += copy(dAtA[i:], m.Leader)
+	}
 	return i, nil
 }
 
-func (m *ReplicationRequest) Marshal() (dAtA []byte, err error) {
+func (m *Stream) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1231,26 +1787,114 @@ func (m *ReplicationRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ReplicationRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Stream) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.ReplicaID) > 0 {
+	if len(m.Subject) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(len(m.ReplicaID)))
-		i += copy(dAtA[i:], m.ReplicaID)
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
 	}
-	if m.Offset != 0 {
-		dAtA[i] = 0x10
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.Offset))
-	}
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Group) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Group)))
+		i += copy(dAtA[i:], m.Group)
+	}
+	if m.ReplicationFactor != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.ReplicationFactor))
+	}
+	if len(m.Replicas) > 0 {
+		for _, s := range m.Replicas {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Leader) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Leader)))
+		i += copy(dAtA[i:], m.Leader)
+	}
+	if len(m.Isr) > 0 {
+		for _, s := range m.Isr {
+			dAtA[i] = 0x3a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.LeaderEpoch != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.LeaderEpoch))
+	}
+	if m.Epoch != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Epoch))
+	}
+	if m.Mirror != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Mirror.Size()))
+		n9, err := m.Mirror.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	if m.FlushPolicy != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.FlushPolicy.Size()))
+		n10, err := m.FlushPolicy.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if m.UncleanLeaderElectionTimeout != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.UncleanLeaderElectionTimeout))
+	}
+	if m.StartOffset != 0 {
+		dAtA[i] = 0x68
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.StartOffset))
+	}
 	return i, nil
 }
 
-func (m *LeaderEpochOffsetRequest) Marshal() (dAtA []byte, err error) {
+func (m *FlushPolicy) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1260,20 +1904,25 @@ func (m *LeaderEpochOffsetRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LeaderEpochOffsetRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *FlushPolicy) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.LeaderEpoch != 0 {
+	if m.Messages != 0 {
 		dAtA[i] = 0x8
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.LeaderEpoch))
+		i = encodeVarintInternal(dAtA, i, uint64(m.Messages))
+	}
+	if m.Interval != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Interval))
 	}
 	return i, nil
 }
 
-func (m *LeaderEpochOffsetResponse) Marshal() (dAtA []byte, err error) {
+func (m *StreamMirror) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1283,20 +1932,42 @@ func (m *LeaderEpochOffsetResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LeaderEpochOffsetResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *StreamMirror) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.EndOffset != 0 {
-		dAtA[i] = 0x8
+	if len(m.SourceAddrs) > 0 {
+		for _, s := range m.SourceAddrs {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.SourceSubject) > 0 {
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.EndOffset))
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.SourceSubject)))
+		i += copy(dAtA[i:], m.SourceSubject)
+	}
+	if len(m.SourceName) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.SourceName)))
+		i += copy(dAtA[i:], m.SourceName)
 	}
 	return i, nil
 }
 
-func (m *PropagatedRequest) Marshal() (dAtA []byte, err error) {
+func (m *RaftJoinRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1306,60 +1977,37 @@ func (m *PropagatedRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PropagatedRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *RaftJoinRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Op != 0 {
-		dAtA[i] = 0x8
+	if len(m.NodeID) > 0 {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.Op))
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.NodeID)))
+		i += copy(dAtA[i:], m.NodeID)
 	}
-	if m.CreateStreamOp != nil {
+	if len(m.NodeAddr) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.CreateStreamOp.Size()))
-		n6, err := m.CreateStreamOp.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n6
-	}
-	if m.ShrinkISROp != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.ShrinkISROp.Size()))
-		n7, err := m.ShrinkISROp.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n7
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.NodeAddr)))
+		i += copy(dAtA[i:], m.NodeAddr)
 	}
-	if m.ReportLeaderOp != nil {
-		dAtA[i] = 0x22
+	if m.NonVoter {
+		dAtA[i] = 0x18
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.ReportLeaderOp.Size()))
-		n8, err := m.ReportLeaderOp.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+		if m.NonVoter {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
-		i += n8
-	}
-	if m.ExpandISROp != nil {
-		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.ExpandISROp.Size()))
-		n9, err := m.ExpandISROp.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n9
 	}
 	return i, nil
 }
 
-func (m *Error) Marshal() (dAtA []byte, err error) {
+func (m *RaftJoinResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1369,26 +2017,21 @@ func (m *Error) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Error) MarshalTo(dAtA []byte) (int, error) {
+func (m *RaftJoinResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Code != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.Code))
-	}
-	if len(m.Msg) > 0 {
-		dAtA[i] = 0x12
+	if len(m.Error) > 0 {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(len(m.Msg)))
-		i += copy(dAtA[i:], m.Msg)
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
 	}
 	return i, nil
 }
 
-func (m *PropagatedResponse) Marshal() (dAtA []byte, err error) {
+func (m *MetadataSnapshot) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1398,40 +2041,42 @@ func (m *PropagatedResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PropagatedResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *MetadataSnapshot) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Op != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.Op))
-	}
-	if m.Error != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.Error.Size()))
-		n10, err := m.Error.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.Streams) > 0 {
+		for _, msg := range m.Streams {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintInternal(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
-		i += n10
 	}
-	if m.CreateStreamResp != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.CreateStreamResp.Size()))
-		n11, err := m.CreateStreamResp.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.CordonedServers) > 0 {
+		for _, s := range m.CordonedServers {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
-		i += n11
 	}
 	return i, nil
 }
 
-func (m *ServerInfoRequest) Marshal() (dAtA []byte, err error) {
+func (m *ReplicationRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1441,21 +2086,26 @@ func (m *ServerInfoRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ServerInfoRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ReplicationRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Id) > 0 {
+	if len(m.ReplicaID) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.ReplicaID)))
+		i += copy(dAtA[i:], m.ReplicaID)
+	}
+	if m.Offset != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Offset))
 	}
 	return i, nil
 }
 
-func (m *ServerInfoResponse) Marshal() (dAtA []byte, err error) {
+func (m *LeaderEpochOffsetRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1465,32 +2115,20 @@ func (m *ServerInfoResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ServerInfoResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *LeaderEpochOffsetRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Id) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintInternal(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
-	}
-	if len(m.Host) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintInternal(dAtA, i, uint64(len(m.Host)))
-		i += copy(dAtA[i:], m.Host)
-	}
-	if m.Port != 0 {
-		dAtA[i] = 0x18
+	if m.LeaderEpoch != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(m.Port))
+		i = encodeVarintInternal(dAtA, i, uint64(m.LeaderEpoch))
 	}
 	return i, nil
 }
 
-func (m *StreamStatusRequest) Marshal() (dAtA []byte, err error) {
+func (m *LeaderEpochOffsetResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1500,27 +2138,20 @@ func (m *StreamStatusRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *StreamStatusRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *LeaderEpochOffsetResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Subject) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintInternal(dAtA, i, uint64(len(m.Subject)))
-		i += copy(dAtA[i:], m.Subject)
-	}
-	if len(m.Name) > 0 {
-		dAtA[i] = 0x12
+	if m.EndOffset != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintInternal(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+		i = encodeVarintInternal(dAtA, i, uint64(m.EndOffset))
 	}
 	return i, nil
 }
 
-func (m *StreamStatusResponse) Marshal() (dAtA []byte, err error) {
+func (m *ForwardedPublishRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1530,292 +2161,498 @@ func (m *StreamStatusResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *StreamStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *ForwardedPublishRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Exists {
-		dAtA[i] = 0x8
+	if m.Message != nil {
+		dAtA[i] = 0xa
 		i++
-		if m.Exists {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+		i = encodeVarintInternal(dAtA, i, uint64(m.Message.Size()))
+		n11, err := m.Message.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		i++
+		i += n11
 	}
-	if m.IsLeader {
+	if m.WaitForAck {
 		dAtA[i] = 0x10
 		i++
-		if m.IsLeader {
+		if m.WaitForAck {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
 		i++
 	}
+	if m.Timeout != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Timeout))
+	}
 	return i, nil
 }
 
-func encodeVarintInternal(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *ForwardedPublishResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *ServerState) Size() (n int) {
+
+func (m *ForwardedPublishResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ServerID)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
+	if m.Ack != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Ack.Size()))
+		n12, err := m.Ack.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
 	}
-	return n
+	if m.Error != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Error.Size()))
+		n13, err := m.Error.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	return i, nil
 }
 
-func (m *RaftLog) Size() (n int) {
+func (m *PropagatedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PropagatedRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Op != 0 {
-		n += 1 + sovInternal(uint64(m.Op))
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Op))
 	}
 	if m.CreateStreamOp != nil {
-		l = m.CreateStreamOp.Size()
-		n += 1 + l + sovInternal(uint64(l))
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.CreateStreamOp.Size()))
+		n14, err := m.CreateStreamOp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
 	}
 	if m.ShrinkISROp != nil {
-		l = m.ShrinkISROp.Size()
-		n += 1 + l + sovInternal(uint64(l))
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.ShrinkISROp.Size()))
+		n15, err := m.ShrinkISROp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
 	}
-	if m.ChangeLeaderOp != nil {
-		l = m.ChangeLeaderOp.Size()
-		n += 1 + l + sovInternal(uint64(l))
+	if m.ReportLeaderOp != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.ReportLeaderOp.Size()))
+		n16, err := m.ReportLeaderOp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
 	}
 	if m.ExpandISROp != nil {
-		l = m.ExpandISROp.Size()
-		n += 1 + l + sovInternal(uint64(l))
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.ExpandISROp.Size()))
+		n17, err := m.ExpandISROp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
 	}
-	return n
+	if m.PurgeStreamOp != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.PurgeStreamOp.Size()))
+		n18, err := m.PurgeStreamOp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
+	}
+	if m.HandOffLeaderOp != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.HandOffLeaderOp.Size()))
+		n19, err := m.HandOffLeaderOp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
+	if m.CordonServerOp != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.CordonServerOp.Size()))
+		n20, err := m.CordonServerOp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
+	}
+	if m.CreateStream != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.CreateStream.Size()))
+		n21, err := m.CreateStream.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
+	}
+	if m.RemoveServerOp != nil {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.RemoveServerOp.Size()))
+		n22, err := m.RemoveServerOp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n22
+	}
+	if m.AddNonVoterOp != nil {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.AddNonVoterOp.Size()))
+		n23, err := m.AddNonVoterOp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
+	if m.PromoteServerOp != nil {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.PromoteServerOp.Size()))
+		n24, err := m.PromoteServerOp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
+	}
+	return i, nil
 }
 
-func (m *CreateStreamOp) Size() (n int) {
-	var l int
-	_ = l
-	if m.Stream != nil {
-		l = m.Stream.Size()
-		n += 1 + l + sovInternal(uint64(l))
+func (m *Error) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ShrinkISROp) Size() (n int) {
+func (m *Error) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Subject)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	l = len(m.ReplicaToRemove)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
+	if m.Code != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Code))
 	}
-	l = len(m.Leader)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
+	if len(m.Msg) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Msg)))
+		i += copy(dAtA[i:], m.Msg)
 	}
-	if m.LeaderEpoch != 0 {
-		n += 1 + sovInternal(uint64(m.LeaderEpoch))
+	return i, nil
+}
+
+func (m *AckError) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ExpandISROp) Size() (n int) {
+func (m *AckError) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Subject)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	l = len(m.ReplicaToAdd)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
+	if m.Code != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Code))
 	}
-	l = len(m.Leader)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
+	if len(m.Msg) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Msg)))
+		i += copy(dAtA[i:], m.Msg)
 	}
-	if m.LeaderEpoch != 0 {
-		n += 1 + sovInternal(uint64(m.LeaderEpoch))
+	return i, nil
+}
+
+func (m *AckExtension) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ReportLeaderOp) Size() (n int) {
+func (m *AckExtension) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Subject)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
+	if m.Error != nil {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Error.Size()))
+		n25, err := m.Error.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n25
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	l = len(m.Replica)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	l = len(m.Leader)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.LeaderEpoch != 0 {
-		n += 1 + sovInternal(uint64(m.LeaderEpoch))
-	}
-	return n
+	return i, nil
 }
 
-func (m *ChangeLeaderOp) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Subject)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	l = len(m.Leader)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
+func (m *PropagatedResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Stream) Size() (n int) {
+func (m *PropagatedResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Subject)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	l = len(m.Group)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.ReplicationFactor != 0 {
-		n += 1 + sovInternal(uint64(m.ReplicationFactor))
+	if m.Op != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Op))
 	}
-	if len(m.Replicas) > 0 {
-		for _, s := range m.Replicas {
-			l = len(s)
-			n += 1 + l + sovInternal(uint64(l))
+	if m.Error != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Error.Size()))
+		n26, err := m.Error.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n26
 	}
-	l = len(m.Leader)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if len(m.Isr) > 0 {
-		for _, s := range m.Isr {
-			l = len(s)
-			n += 1 + l + sovInternal(uint64(l))
+	if m.CreateStreamResp != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.CreateStreamResp.Size()))
+		n27, err := m.CreateStreamResp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n27
 	}
-	if m.LeaderEpoch != 0 {
-		n += 1 + sovInternal(uint64(m.LeaderEpoch))
+	if m.RemoveServerResp != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.RemoveServerResp.Size()))
+		n28, err := m.RemoveServerResp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n28
 	}
-	if m.Epoch != 0 {
-		n += 1 + sovInternal(uint64(m.Epoch))
+	return i, nil
+}
+
+func (m *ServerInfoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *RaftJoinRequest) Size() (n int) {
+func (m *ServerInfoRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.NodeID)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
 	}
-	l = len(m.NodeAddr)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
+	return i, nil
+}
+
+func (m *ServerInfoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *RaftJoinResponse) Size() (n int) {
+func (m *ServerInfoResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Error)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
 	}
-	return n
+	if len(m.Host) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Host)))
+		i += copy(dAtA[i:], m.Host)
+	}
+	if m.Port != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(m.Port))
+	}
+	return i, nil
 }
 
-func (m *MetadataSnapshot) Size() (n int) {
-	var l int
-	_ = l
-	if len(m.Streams) > 0 {
-		for _, e := range m.Streams {
-			l = e.Size()
-			n += 1 + l + sovInternal(uint64(l))
-		}
+func (m *StreamStatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ReplicationRequest) Size() (n int) {
+func (m *StreamStatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ReplicaID)
-	if l > 0 {
-		n += 1 + l + sovInternal(uint64(l))
+	if len(m.Subject) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
 	}
-	if m.Offset != 0 {
-		n += 1 + sovInternal(uint64(m.Offset))
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintInternal(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
 	}
-	return n
+	return i, nil
 }
 
-func (m *LeaderEpochOffsetRequest) Size() (n int) {
+func (m *StreamStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.LeaderEpoch != 0 {
-		n += 1 + sovInternal(uint64(m.LeaderEpoch))
+	if m.Exists {
+		dAtA[i] = 0x8
+		i++
+		if m.Exists {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	return n
+	if m.IsLeader {
+		dAtA[i] = 0x10
+		i++
+		if m.IsLeader {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
 }
 
-func (m *LeaderEpochOffsetResponse) Size() (n int) {
+func encodeVarintInternal(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *ServerState) Size() (n int) {
 	var l int
 	_ = l
-	if m.EndOffset != 0 {
-		n += 1 + sovInternal(uint64(m.EndOffset))
+	l = len(m.ServerID)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
 	}
 	return n
 }
 
-func (m *PropagatedRequest) Size() (n int) {
+func (m *RaftLog) Size() (n int) {
 	var l int
 	_ = l
 	if m.Op != 0 {
@@ -1829,75 +2666,136 @@ func (m *PropagatedRequest) Size() (n int) {
 		l = m.ShrinkISROp.Size()
 		n += 1 + l + sovInternal(uint64(l))
 	}
-	if m.ReportLeaderOp != nil {
-		l = m.ReportLeaderOp.Size()
+	if m.ChangeLeaderOp != nil {
+		l = m.ChangeLeaderOp.Size()
 		n += 1 + l + sovInternal(uint64(l))
 	}
 	if m.ExpandISROp != nil {
 		l = m.ExpandISROp.Size()
 		n += 1 + l + sovInternal(uint64(l))
 	}
-	return n
-}
-
-func (m *Error) Size() (n int) {
-	var l int
+	if m.PurgeStreamOp != nil {
+		l = m.PurgeStreamOp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.ReassignReplicasOp != nil {
+		l = m.ReassignReplicasOp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.CordonServerOp != nil {
+		l = m.CordonServerOp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateStreamOp) Size() (n int) {
+	var l int
 	_ = l
-	if m.Code != 0 {
-		n += 1 + sovInternal(uint64(m.Code))
+	if m.Stream != nil {
+		l = m.Stream.Size()
+		n += 1 + l + sovInternal(uint64(l))
 	}
-	l = len(m.Msg)
+	return n
+}
+
+func (m *ShrinkISROp) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.ReplicaToRemove)
 	if l > 0 {
 		n += 1 + l + sovInternal(uint64(l))
 	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.LeaderEpoch != 0 {
+		n += 1 + sovInternal(uint64(m.LeaderEpoch))
+	}
 	return n
 }
 
-func (m *PropagatedResponse) Size() (n int) {
+func (m *ExpandISROp) Size() (n int) {
 	var l int
 	_ = l
-	if m.Op != 0 {
-		n += 1 + sovInternal(uint64(m.Op))
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
 	}
-	if m.Error != nil {
-		l = m.Error.Size()
+	l = len(m.Name)
+	if l > 0 {
 		n += 1 + l + sovInternal(uint64(l))
 	}
-	if m.CreateStreamResp != nil {
-		l = m.CreateStreamResp.Size()
+	l = len(m.ReplicaToAdd)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.Leader)
+	if l > 0 {
 		n += 1 + l + sovInternal(uint64(l))
 	}
+	if m.LeaderEpoch != 0 {
+		n += 1 + sovInternal(uint64(m.LeaderEpoch))
+	}
 	return n
 }
 
-func (m *ServerInfoRequest) Size() (n int) {
+func (m *ReportLeaderOp) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Id)
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.Replica)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.Leader)
 	if l > 0 {
 		n += 1 + l + sovInternal(uint64(l))
 	}
+	if m.LeaderEpoch != 0 {
+		n += 1 + sovInternal(uint64(m.LeaderEpoch))
+	}
 	return n
 }
 
-func (m *ServerInfoResponse) Size() (n int) {
+func (m *ChangeLeaderOp) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Id)
+	l = len(m.Subject)
 	if l > 0 {
 		n += 1 + l + sovInternal(uint64(l))
 	}
-	l = len(m.Host)
+	l = len(m.Name)
 	if l > 0 {
 		n += 1 + l + sovInternal(uint64(l))
 	}
-	if m.Port != 0 {
-		n += 1 + sovInternal(uint64(m.Port))
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Unclean {
+		n += 2
 	}
 	return n
 }
 
-func (m *StreamStatusRequest) Size() (n int) {
+func (m *HandOffLeaderOp) Size() (n int) {
 	var l int
 	_ = l
 	l = len(m.Subject)
@@ -1908,66 +2806,2301 @@ func (m *StreamStatusRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovInternal(uint64(l))
 	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.LeaderEpoch != 0 {
+		n += 1 + sovInternal(uint64(m.LeaderEpoch))
+	}
 	return n
 }
 
-func (m *StreamStatusResponse) Size() (n int) {
+func (m *CordonServerOp) Size() (n int) {
 	var l int
 	_ = l
-	if m.Exists {
-		n += 2
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
 	}
-	if m.IsLeader {
+	if m.Cordoned {
 		n += 2
 	}
 	return n
 }
 
-func sovInternal(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+func (m *PurgeStreamOp) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovInternal(uint64(m.Offset))
+	}
+	return n
+}
+
+func (m *ReassignReplicasOp) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if len(m.Replicas) > 0 {
+		for _, s := range m.Replicas {
+			l = len(s)
+			n += 1 + l + sovInternal(uint64(l))
 		}
 	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
 	return n
 }
-func sozInternal(x uint64) (n int) {
-	return sovInternal(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *Stream) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.Group)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.ReplicationFactor != 0 {
+		n += 1 + sovInternal(uint64(m.ReplicationFactor))
+	}
+	if len(m.Replicas) > 0 {
+		for _, s := range m.Replicas {
+			l = len(s)
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if len(m.Isr) > 0 {
+		for _, s := range m.Isr {
+			l = len(s)
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
+	if m.LeaderEpoch != 0 {
+		n += 1 + sovInternal(uint64(m.LeaderEpoch))
+	}
+	if m.Epoch != 0 {
+		n += 1 + sovInternal(uint64(m.Epoch))
+	}
+	if m.Mirror != nil {
+		l = m.Mirror.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.FlushPolicy != nil {
+		l = m.FlushPolicy.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.UncleanLeaderElectionTimeout != 0 {
+		n += 1 + sovInternal(uint64(m.UncleanLeaderElectionTimeout))
+	}
+	if m.StartOffset != 0 {
+		n += 1 + sovInternal(uint64(m.StartOffset))
+	}
+	return n
 }
-func (m *ServerState) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowInternal
+
+func (m *FlushPolicy) Size() (n int) {
+	var l int
+	_ = l
+	if m.Messages != 0 {
+		n += 1 + sovInternal(uint64(m.Messages))
+	}
+	if m.Interval != 0 {
+		n += 1 + sovInternal(uint64(m.Interval))
+	}
+	return n
+}
+
+func (m *StreamMirror) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.SourceAddrs) > 0 {
+		for _, s := range m.SourceAddrs {
+			l = len(s)
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
+	l = len(m.SourceSubject)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.SourceName)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	return n
+}
+
+func (m *RaftJoinRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.NodeID)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.NodeAddr)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.NonVoter {
+		n += 2
+	}
+	return n
+}
+
+func (m *RaftJoinResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	return n
+}
+
+func (m *MetadataSnapshot) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Streams) > 0 {
+		for _, e := range m.Streams {
+			l = e.Size()
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
+	if len(m.CordonedServers) > 0 {
+		for _, s := range m.CordonedServers {
+			l = len(s)
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ReplicationRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.ReplicaID)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovInternal(uint64(m.Offset))
+	}
+	return n
+}
+
+func (m *LeaderEpochOffsetRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.LeaderEpoch != 0 {
+		n += 1 + sovInternal(uint64(m.LeaderEpoch))
+	}
+	return n
+}
+
+func (m *LeaderEpochOffsetResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.EndOffset != 0 {
+		n += 1 + sovInternal(uint64(m.EndOffset))
+	}
+	return n
+}
+
+func (m *ForwardedPublishRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Message != nil {
+		l = m.Message.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.WaitForAck {
+		n += 2
+	}
+	if m.Timeout != 0 {
+		n += 1 + sovInternal(uint64(m.Timeout))
+	}
+	return n
+}
+
+func (m *ForwardedPublishResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Ack != nil {
+		l = m.Ack.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	return n
+}
+
+func (m *PropagatedRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Op != 0 {
+		n += 1 + sovInternal(uint64(m.Op))
+	}
+	if m.CreateStreamOp != nil {
+		l = m.CreateStreamOp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.ShrinkISROp != nil {
+		l = m.ShrinkISROp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.ReportLeaderOp != nil {
+		l = m.ReportLeaderOp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.ExpandISROp != nil {
+		l = m.ExpandISROp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.PurgeStreamOp != nil {
+		l = m.PurgeStreamOp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.HandOffLeaderOp != nil {
+		l = m.HandOffLeaderOp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.CordonServerOp != nil {
+		l = m.CordonServerOp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.CreateStream != nil {
+		l = m.CreateStream.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.RemoveServerOp != nil {
+		l = m.RemoveServerOp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.AddNonVoterOp != nil {
+		l = m.AddNonVoterOp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.PromoteServerOp != nil {
+		l = m.PromoteServerOp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	return n
+}
+
+func (m *Error) Size() (n int) {
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovInternal(uint64(m.Code))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	return n
+}
+
+func (m *AckError) Size() (n int) {
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovInternal(uint64(m.Code))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	return n
+}
+
+func (m *AckExtension) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	return n
+}
+
+func (m *PropagatedResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Op != 0 {
+		n += 1 + sovInternal(uint64(m.Op))
+	}
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.CreateStreamResp != nil {
+		l = m.CreateStreamResp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.RemoveServerResp != nil {
+		l = m.RemoveServerResp.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	return n
+}
+
+func (m *ServerInfoRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	return n
+}
+
+func (m *ServerInfoResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.Host)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovInternal(uint64(m.Port))
+	}
+	return n
+}
+
+func (m *StreamStatusRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	return n
+}
+
+func (m *StreamStatusResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Exists {
+		n += 2
+	}
+	if m.IsLeader {
+		n += 2
+	}
+	return n
+}
+
+func sovInternal(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozInternal(x uint64) (n int) {
+	return sovInternal(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ServerState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServerState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServerState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServerID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftLog) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftLog: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftLog: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
+			}
+			m.Op = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Op |= (Op(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateStreamOp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CreateStreamOp == nil {
+				m.CreateStreamOp = &CreateStreamOp{}
+			}
+			if err := m.CreateStreamOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShrinkISROp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ShrinkISROp == nil {
+				m.ShrinkISROp = &ShrinkISROp{}
+			}
+			if err := m.ShrinkISROp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeLeaderOp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChangeLeaderOp == nil {
+				m.ChangeLeaderOp = &ChangeLeaderOp{}
+			}
+			if err := m.ChangeLeaderOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpandISROp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExpandISROp == nil {
+				m.ExpandISROp = &ExpandISROp{}
+			}
+			if err := m.ExpandISROp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PurgeStreamOp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PurgeStreamOp == nil {
+				m.PurgeStreamOp = &PurgeStreamOp{}
+			}
+			if err := m.PurgeStreamOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReassignReplicasOp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReassignReplicasOp == nil {
+				m.ReassignReplicasOp = &ReassignReplicasOp{}
+			}
+			if err := m.ReassignReplicasOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CordonServerOp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CordonServerOp == nil {
+				m.CordonServerOp = &CordonServerOp{}
+			}
+			if err := m.CordonServerOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateStreamOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateStreamOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateStreamOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stream", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Stream == nil {
+				m.Stream = &Stream{}
+			}
+			if err := m.Stream.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShrinkISROp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShrinkISROp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShrinkISROp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaToRemove", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReplicaToRemove = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Leader = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderEpoch", wireType)
+			}
+			m.LeaderEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderEpoch |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExpandISROp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExpandISROp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExpandISROp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaToAdd", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReplicaToAdd = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Leader = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderEpoch", wireType)
+			}
+			m.LeaderEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderEpoch |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReportLeaderOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReportLeaderOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReportLeaderOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replica", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Replica = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Leader = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderEpoch", wireType)
+			}
+			m.LeaderEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderEpoch |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChangeLeaderOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChangeLeaderOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChangeLeaderOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Leader = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unclean", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Unclean = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HandOffLeaderOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HandOffLeaderOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HandOffLeaderOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Leader = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderEpoch", wireType)
+			}
+			m.LeaderEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderEpoch |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CordonServerOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CordonServerOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CordonServerOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cordoned", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Cordoned = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PurgeStreamOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PurgeStreamOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PurgeStreamOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReassignReplicasOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReassignReplicasOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReassignReplicasOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Replicas = append(m.Replicas, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Leader = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Stream) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Stream: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Stream: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
 			}
-			if iNdEx >= l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Group = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicationFactor", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ServerState: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServerState: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.ReplicationFactor = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplicationFactor |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServerID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1992,63 +5125,90 @@ func (m *ServerState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ServerID = string(dAtA[iNdEx:postIndex])
+			m.Replicas = append(m.Replicas, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipInternal(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
-			if skippy < 0 {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RaftLog) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowInternal
+			m.Leader = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Isr", wireType)
 			}
-			if iNdEx >= l {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Isr = append(m.Isr, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderEpoch", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RaftLog: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftLog: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.LeaderEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderEpoch |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
 			}
-			m.Op = 0
+			m.Epoch = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -2058,14 +5218,14 @@ func (m *RaftLog) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Op |= (Op(b) & 0x7F) << shift
+				m.Epoch |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateStreamOp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Mirror", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2089,16 +5249,16 @@ func (m *RaftLog) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.CreateStreamOp == nil {
-				m.CreateStreamOp = &CreateStreamOp{}
+			if m.Mirror == nil {
+				m.Mirror = &StreamMirror{}
 			}
-			if err := m.CreateStreamOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Mirror.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShrinkISROp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FlushPolicy", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2122,18 +5282,18 @@ func (m *RaftLog) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ShrinkISROp == nil {
-				m.ShrinkISROp = &ShrinkISROp{}
+			if m.FlushPolicy == nil {
+				m.FlushPolicy = &FlushPolicy{}
 			}
-			if err := m.ShrinkISROp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.FlushPolicy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangeLeaderOp", wireType)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UncleanLeaderElectionTimeout", wireType)
 			}
-			var msglen int
+			m.UncleanLeaderElectionTimeout = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -2143,30 +5303,104 @@ func (m *RaftLog) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.UncleanLeaderElectionTimeout |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartOffset", wireType)
+			}
+			m.StartOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartOffset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangeLeaderOp == nil {
-				m.ChangeLeaderOp = &ChangeLeaderOp{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FlushPolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
 			}
-			if err := m.ChangeLeaderOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FlushPolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FlushPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+			}
+			m.Messages = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Messages |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExpandISROp", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
 			}
-			var msglen int
+			m.Interval = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -2176,25 +5410,11 @@ func (m *RaftLog) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Interval |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthInternal
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ExpandISROp == nil {
-				m.ExpandISROp = &ExpandISROp{}
-			}
-			if err := m.ExpandISROp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipInternal(dAtA[iNdEx:])
@@ -2216,7 +5436,7 @@ func (m *RaftLog) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateStreamOp) Unmarshal(dAtA []byte) error {
+func (m *StreamMirror) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2239,17 +5459,17 @@ func (m *CreateStreamOp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateStreamOp: wiretype end group for non-group")
+			return fmt.Errorf("proto: StreamMirror: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateStreamOp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StreamMirror: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stream", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceAddrs", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -2259,24 +5479,78 @@ func (m *CreateStreamOp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Stream == nil {
-				m.Stream = &Stream{}
+			m.SourceAddrs = append(m.SourceAddrs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceSubject", wireType)
 			}
-			if err := m.Stream.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceSubject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.SourceName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2299,7 +5573,7 @@ func (m *CreateStreamOp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShrinkISROp) Unmarshal(dAtA []byte) error {
+func (m *RaftJoinRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2322,15 +5596,15 @@ func (m *ShrinkISROp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShrinkISROp: wiretype end group for non-group")
+			return fmt.Errorf("proto: RaftJoinRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShrinkISROp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RaftJoinRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2355,11 +5629,11 @@ func (m *ShrinkISROp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Subject = string(dAtA[iNdEx:postIndex])
+			m.NodeID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeAddr", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2384,13 +5658,13 @@ func (m *ShrinkISROp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.NodeAddr = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaToRemove", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NonVoter", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -2400,24 +5674,65 @@ func (m *ShrinkISROp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			m.NonVoter = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ReplicaToRemove = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftJoinResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftJoinResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftJoinResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2442,27 +5757,8 @@ func (m *ShrinkISROp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Leader = string(dAtA[iNdEx:postIndex])
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeaderEpoch", wireType)
-			}
-			m.LeaderEpoch = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowInternal
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.LeaderEpoch |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipInternal(dAtA[iNdEx:])
@@ -2484,7 +5780,7 @@ func (m *ShrinkISROp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExpandISROp) Unmarshal(dAtA []byte) error {
+func (m *MetadataSnapshot) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2507,17 +5803,17 @@ func (m *ExpandISROp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ExpandISROp: wiretype end group for non-group")
+			return fmt.Errorf("proto: MetadataSnapshot: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExpandISROp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MetadataSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Streams", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -2527,24 +5823,26 @@ func (m *ExpandISROp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Subject = string(dAtA[iNdEx:postIndex])
+			m.Streams = append(m.Streams, &Stream{})
+			if err := m.Streams[len(m.Streams)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CordonedServers", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2569,11 +5867,61 @@ func (m *ExpandISROp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.CordonedServers = append(m.CordonedServers, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReplicationRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReplicationRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReplicationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaToAdd", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2598,13 +5946,13 @@ func (m *ExpandISROp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ReplicaToAdd = string(dAtA[iNdEx:postIndex])
+			m.ReplicaID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
 			}
-			var stringLen uint64
+			m.Offset = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -2614,22 +5962,62 @@ func (m *ExpandISROp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Offset |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Leader = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaderEpochOffsetRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaderEpochOffsetRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaderEpochOffsetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field LeaderEpoch", wireType)
 			}
@@ -2669,7 +6057,7 @@ func (m *ExpandISROp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReportLeaderOp) Unmarshal(dAtA []byte) error {
+func (m *LeaderEpochOffsetResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2692,17 +6080,17 @@ func (m *ReportLeaderOp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReportLeaderOp: wiretype end group for non-group")
+			return fmt.Errorf("proto: LeaderEpochOffsetResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReportLeaderOp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LeaderEpochOffsetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndOffset", wireType)
 			}
-			var stringLen uint64
+			m.EndOffset = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -2712,55 +6100,66 @@ func (m *ReportLeaderOp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.EndOffset |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Subject = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowInternal
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthInternal
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ForwardedPublishRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternal
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ForwardedPublishRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ForwardedPublishRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Replica", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -2770,26 +6169,30 @@ func (m *ReportLeaderOp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Replica = string(dAtA[iNdEx:postIndex])
+			if m.Message == nil {
+				m.Message = &proto2.Message{}
+			}
+			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WaitForAck", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -2799,26 +6202,17 @@ func (m *ReportLeaderOp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthInternal
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Leader = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
+			m.WaitForAck = bool(v != 0)
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeaderEpoch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
 			}
-			m.LeaderEpoch = 0
+			m.Timeout = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -2828,7 +6222,7 @@ func (m *ReportLeaderOp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LeaderEpoch |= (uint64(b) & 0x7F) << shift
+				m.Timeout |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2854,7 +6248,7 @@ func (m *ReportLeaderOp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ChangeLeaderOp) Unmarshal(dAtA []byte) error {
+func (m *ForwardedPublishResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2877,17 +6271,17 @@ func (m *ChangeLeaderOp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ChangeLeaderOp: wiretype end group for non-group")
+			return fmt.Errorf("proto: ForwardedPublishResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChangeLeaderOp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ForwardedPublishResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ack", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -2897,55 +6291,30 @@ func (m *ChangeLeaderOp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Subject = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowInternal
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthInternal
+			if m.Ack == nil {
+				m.Ack = &proto2.Ack{}
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if err := m.Ack.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -2955,20 +6324,24 @@ func (m *ChangeLeaderOp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Leader = string(dAtA[iNdEx:postIndex])
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2991,7 +6364,7 @@ func (m *ChangeLeaderOp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Stream) Unmarshal(dAtA []byte) error {
+func (m *PropagatedRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3014,17 +6387,17 @@ func (m *Stream) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Stream: wiretype end group for non-group")
+			return fmt.Errorf("proto: PropagatedRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Stream: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PropagatedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
 			}
-			var stringLen uint64
+			m.Op = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3034,26 +6407,16 @@ func (m *Stream) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Op |= (Op(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthInternal
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Subject = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateStreamOp", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3063,26 +6426,30 @@ func (m *Stream) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			if m.CreateStreamOp == nil {
+				m.CreateStreamOp = &proto2.CreateStreamRequest{}
+			}
+			if err := m.CreateStreamOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ShrinkISROp", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3092,45 +6459,30 @@ func (m *Stream) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Group = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReplicationFactor", wireType)
+			if m.ShrinkISROp == nil {
+				m.ShrinkISROp = &ShrinkISROp{}
 			}
-			m.ReplicationFactor = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowInternal
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ReplicationFactor |= (int32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if err := m.ShrinkISROp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		case 5:
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReportLeaderOp", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3140,55 +6492,30 @@ func (m *Stream) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Replicas = append(m.Replicas, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowInternal
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthInternal
+			if m.ReportLeaderOp == nil {
+				m.ReportLeaderOp = &ReportLeaderOp{}
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if err := m.ReportLeaderOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Leader = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Isr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpandISROp", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3198,114 +6525,30 @@ func (m *Stream) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Isr = append(m.Isr, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeaderEpoch", wireType)
-			}
-			m.LeaderEpoch = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowInternal
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.LeaderEpoch |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
-			}
-			m.Epoch = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowInternal
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Epoch |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if m.ExpandISROp == nil {
+				m.ExpandISROp = &ExpandISROp{}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipInternal(dAtA[iNdEx:])
-			if err != nil {
+			if err := m.ExpandISROp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthInternal
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RaftJoinRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowInternal
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RaftJoinRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftJoinRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PurgeStreamOp", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3315,26 +6558,30 @@ func (m *RaftJoinRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NodeID = string(dAtA[iNdEx:postIndex])
+			if m.PurgeStreamOp == nil {
+				m.PurgeStreamOp = &PurgeStreamOp{}
+			}
+			if err := m.PurgeStreamOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeAddr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HandOffLeaderOp", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3344,76 +6591,30 @@ func (m *RaftJoinRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NodeAddr = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipInternal(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthInternal
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RaftJoinResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowInternal
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.HandOffLeaderOp == nil {
+				m.HandOffLeaderOp = &HandOffLeaderOp{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.HandOffLeaderOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RaftJoinResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftJoinResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CordonServerOp", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3423,74 +6624,28 @@ func (m *RaftJoinResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Error = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipInternal(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthInternal
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *MetadataSnapshot) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowInternal
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.CordonServerOp == nil {
+				m.CordonServerOp = &CordonServerOp{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.CordonServerOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: MetadataSnapshot: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MetadataSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Streams", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateStream", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3514,66 +6669,18 @@ func (m *MetadataSnapshot) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Streams = append(m.Streams, &Stream{})
-			if err := m.Streams[len(m.Streams)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if m.CreateStream == nil {
+				m.CreateStream = &Stream{}
 			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipInternal(dAtA[iNdEx:])
-			if err != nil {
+			if err := m.CreateStream.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthInternal
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ReplicationRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowInternal
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ReplicationRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReplicationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveServerOp", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3583,26 +6690,30 @@ func (m *ReplicationRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ReplicaID = string(dAtA[iNdEx:postIndex])
+			if m.RemoveServerOp == nil {
+				m.RemoveServerOp = &RemoveServerRequest{}
+			}
+			if err := m.RemoveServerOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddNonVoterOp", wireType)
 			}
-			m.Offset = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3612,66 +6723,30 @@ func (m *ReplicationRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Offset |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipInternal(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LeaderEpochOffsetRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowInternal
-			}
-			if iNdEx >= l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LeaderEpochOffsetRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LeaderEpochOffsetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeaderEpoch", wireType)
-			}
-			m.LeaderEpoch = 0
+			if m.AddNonVoterOp == nil {
+				m.AddNonVoterOp = &AddNonVoterRequest{}
+			}
+			if err := m.AddNonVoterOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PromoteServerOp", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3681,11 +6756,25 @@ func (m *LeaderEpochOffsetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LeaderEpoch |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PromoteServerOp == nil {
+				m.PromoteServerOp = &PromoteServerRequest{}
+			}
+			if err := m.PromoteServerOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipInternal(dAtA[iNdEx:])
@@ -3707,7 +6796,7 @@ func (m *LeaderEpochOffsetRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LeaderEpochOffsetResponse) Unmarshal(dAtA []byte) error {
+func (m *Error) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3730,17 +6819,17 @@ func (m *LeaderEpochOffsetResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LeaderEpochOffsetResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: Error: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LeaderEpochOffsetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Error: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndOffset", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			m.EndOffset = 0
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3750,11 +6839,40 @@ func (m *LeaderEpochOffsetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.EndOffset |= (int64(b) & 0x7F) << shift
+				m.Code |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipInternal(dAtA[iNdEx:])
@@ -3776,7 +6894,7 @@ func (m *LeaderEpochOffsetResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PropagatedRequest) Unmarshal(dAtA []byte) error {
+func (m *AckError) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3799,17 +6917,17 @@ func (m *PropagatedRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PropagatedRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: AckError: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PropagatedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AckError: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			m.Op = 0
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3819,115 +6937,16 @@ func (m *PropagatedRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Op |= (Op(b) & 0x7F) << shift
+				m.Code |= (AckErrorCode(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateStreamOp", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowInternal
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthInternal
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.CreateStreamOp == nil {
-				m.CreateStreamOp = &proto2.CreateStreamRequest{}
-			}
-			if err := m.CreateStreamOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShrinkISROp", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowInternal
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthInternal
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ShrinkISROp == nil {
-				m.ShrinkISROp = &ShrinkISROp{}
-			}
-			if err := m.ShrinkISROp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReportLeaderOp", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowInternal
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthInternal
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ReportLeaderOp == nil {
-				m.ReportLeaderOp = &ReportLeaderOp{}
-			}
-			if err := m.ReportLeaderOp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExpandISROp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -3937,24 +6956,20 @@ func (m *PropagatedRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ExpandISROp == nil {
-				m.ExpandISROp = &ExpandISROp{}
-			}
-			if err := m.ExpandISROp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Msg = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3977,7 +6992,7 @@ func (m *PropagatedRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Error) Unmarshal(dAtA []byte) error {
+func (m *AckExtension) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4000,36 +7015,17 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Error: wiretype end group for non-group")
+			return fmt.Errorf("proto: AckExtension: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Error: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AckExtension: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
-			}
-			m.Code = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowInternal
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Code |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
+		case 100:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowInternal
@@ -4039,20 +7035,24 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Msg = string(dAtA[iNdEx:postIndex])
+			if m.Error == nil {
+				m.Error = &AckError{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4189,6 +7189,39 @@ func (m *PropagatedResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveServerResp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RemoveServerResp == nil {
+				m.RemoveServerResp = &RemoveServerResponse{}
+			}
+			if err := m.RemoveServerResp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipInternal(dAtA[iNdEx:])
@@ -4722,64 +7755,109 @@ var (
 func init() { proto1.RegisterFile("server/proto/internal.proto", fileDescriptorInternal) }
 
 var fileDescriptorInternal = []byte{
-	// 944 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0xee, 0xda, 0xb1, 0x13, 0x1f, 0x37, 0xae, 0x33, 0x94, 0x6a, 0x9b, 0x56, 0x91, 0x35, 0x08,
-	0x11, 0x10, 0x89, 0xa5, 0x80, 0x84, 0x10, 0x20, 0xe1, 0x26, 0x4b, 0xeb, 0xe0, 0xc6, 0xd1, 0x38,
-	0x42, 0xdc, 0xa0, 0x68, 0xe2, 0x1d, 0xaf, 0x17, 0xe2, 0x9d, 0x61, 0x66, 0x5c, 0xf5, 0x29, 0xb8,
-	0xe0, 0x0a, 0x24, 0x10, 0xaf, 0xc3, 0x25, 0x8f, 0x80, 0xc2, 0x4b, 0x70, 0x89, 0x66, 0x76, 0xd6,
-	0xbb, 0x6b, 0x87, 0x4a, 0xa6, 0x5c, 0xe5, 0xfc, 0x9f, 0xef, 0x7c, 0x73, 0xf6, 0xc4, 0xf0, 0x48,
-	0x31, 0xf9, 0x82, 0xc9, 0xae, 0x90, 0x5c, 0xf3, 0x6e, 0x9c, 0x68, 0x26, 0x13, 0x7a, 0x7d, 0x68,
-	0x55, 0x54, 0xb3, 0x7f, 0x76, 0x3f, 0x8f, 0x62, 0x3d, 0x9d, 0x5f, 0x1d, 0x8e, 0xf9, 0xac, 0x7b,
-	0x1d, 0x4f, 0xf4, 0x95, 0x8c, 0xc3, 0x88, 0x1d, 0xc4, 0xbc, 0x1b, 0xf1, 0x83, 0xdc, 0x50, 0xf4,
-	0x45, 0x52, 0x8c, 0xbb, 0x54, 0xc4, 0x69, 0x21, 0xfc, 0x2e, 0x34, 0x47, 0xb6, 0xcf, 0x48, 0x53,
-	0xcd, 0xd0, 0x2e, 0x6c, 0xa5, 0x6d, 0xfb, 0x27, 0xbe, 0xd7, 0xf1, 0xf6, 0x1b, 0x64, 0xa1, 0xe3,
-	0x1f, 0x2a, 0xb0, 0x49, 0xe8, 0x44, 0x0f, 0x78, 0x84, 0x1e, 0x42, 0x85, 0x0b, 0x1b, 0xd1, 0x3a,
-	0x6a, 0xa4, 0xa5, 0x0e, 0x87, 0x82, 0x54, 0xb8, 0x40, 0x9f, 0x41, 0x6b, 0x2c, 0x19, 0xd5, 0x6c,
-	0xa4, 0x25, 0xa3, 0xb3, 0xa1, 0xf0, 0x2b, 0x1d, 0x6f, 0xbf, 0x79, 0xf4, 0xa6, 0x0b, 0x3b, 0x2e,
-	0x39, 0xc9, 0x52, 0x30, 0xfa, 0x10, 0x9a, 0x6a, 0x2a, 0xe3, 0xe4, 0xbb, 0xfe, 0x88, 0x0c, 0x85,
-	0x5f, 0xb5, 0xb9, 0xc8, 0xe5, 0x8e, 0x72, 0x0f, 0x29, 0x86, 0xd9, 0xa6, 0x53, 0x9a, 0x44, 0x6c,
-	0xc0, 0x68, 0xc8, 0xe4, 0x50, 0xf8, 0x1b, 0xe5, 0xa6, 0x25, 0x27, 0x59, 0x0a, 0x36, 0x4d, 0xd9,
-	0x4b, 0x41, 0x93, 0x30, 0x6d, 0x5a, 0x2b, 0x35, 0x0d, 0x72, 0x0f, 0x29, 0x86, 0xe1, 0x8f, 0xa0,
-	0x55, 0x1e, 0x06, 0xbd, 0x0d, 0x75, 0x65, 0x65, 0x4b, 0x4d, 0xf3, 0x68, 0x3b, 0xc3, 0x6d, 0x8d,
-	0xc4, 0x39, 0xf1, 0x6f, 0x1e, 0x34, 0x0b, 0xa3, 0x20, 0x1f, 0x36, 0xd5, 0xfc, 0xea, 0x5b, 0x36,
-	0xd6, 0x8e, 0xf4, 0x4c, 0x45, 0x08, 0x36, 0x12, 0x3a, 0x63, 0x96, 0xc2, 0x06, 0xb1, 0x32, 0xda,
-	0x87, 0x7b, 0x92, 0x89, 0xeb, 0x78, 0x4c, 0x2f, 0x38, 0x61, 0x33, 0xfe, 0x82, 0x59, 0x96, 0x1a,
-	0x64, 0xd9, 0x8c, 0x1e, 0x40, 0xfd, 0xda, 0x8e, 0x68, 0xd9, 0x68, 0x10, 0xa7, 0xa1, 0x0e, 0x34,
-	0x53, 0x29, 0x10, 0x7c, 0x3c, 0xb5, 0xe3, 0x6e, 0x90, 0xa2, 0x09, 0xff, 0xec, 0x41, 0xb3, 0x30,
-	0xf7, 0x9a, 0x08, 0x31, 0xdc, 0x5d, 0x40, 0xe9, 0x85, 0xa1, 0x83, 0x57, 0xb2, 0xbd, 0x06, 0xb6,
-	0x1f, 0x3d, 0x68, 0x11, 0x26, 0xb8, 0xd4, 0x8b, 0xf7, 0x5b, 0x0f, 0x9e, 0x0f, 0x9b, 0x0e, 0x8a,
-	0x43, 0x96, 0xa9, 0xaf, 0x01, 0xea, 0x2b, 0x68, 0x95, 0x77, 0x6c, 0x4d, 0x4c, 0x79, 0xe7, 0x6a,
-	0xb1, 0x33, 0xfe, 0xdb, 0x83, 0x7a, 0xba, 0x3d, 0x6b, 0x16, 0xbc, 0x0f, 0xb5, 0x48, 0xf2, 0xb9,
-	0x70, 0xf5, 0x52, 0x05, 0xbd, 0x0f, 0x3b, 0x6e, 0x56, 0x1d, 0xf3, 0xe4, 0x0b, 0x3a, 0xd6, 0x3c,
-	0x9d, 0xb5, 0x46, 0x56, 0x1d, 0xe6, 0x1a, 0x38, 0xa3, 0xf2, 0x6b, 0x9d, 0xaa, 0xb9, 0x06, 0x99,
-	0x5e, 0x00, 0x5c, 0x2f, 0x51, 0xd5, 0x86, 0x6a, 0xac, 0xa4, 0xbf, 0x69, 0xc3, 0x8d, 0xb8, 0x4c,
-	0xde, 0xd6, 0x0a, 0x79, 0x06, 0x2b, 0xb3, 0xbe, 0x86, 0xf5, 0xa5, 0x0a, 0x0e, 0xe0, 0x9e, 0x39,
-	0x37, 0xa7, 0x3c, 0x4e, 0x08, 0xfb, 0x7e, 0xce, 0x94, 0x36, 0x4d, 0x13, 0x1e, 0xb2, 0xc5, 0x71,
-	0x72, 0x9a, 0x01, 0x6a, 0xa4, 0x5e, 0x18, 0x4a, 0x47, 0xc2, 0x42, 0xc7, 0xfb, 0xd0, 0xce, 0xcb,
-	0x28, 0xc1, 0x13, 0x65, 0xc9, 0x61, 0x52, 0x72, 0xe9, 0xca, 0xa4, 0x0a, 0xfe, 0x04, 0xda, 0xcf,
-	0x99, 0xa6, 0x21, 0xd5, 0x74, 0x94, 0x50, 0xa1, 0xa6, 0x5c, 0xa3, 0x77, 0x60, 0x33, 0xfd, 0x68,
-	0x95, 0xef, 0x75, 0xaa, 0xab, 0x9f, 0x74, 0xe6, 0xc5, 0xa7, 0x80, 0x48, 0x4e, 0x60, 0x06, 0xf8,
-	0x31, 0x34, 0x1c, 0x63, 0x0b, 0xcc, 0xb9, 0xc1, 0x8c, 0xc3, 0x27, 0x13, 0xc5, 0xb4, 0x05, 0x5d,
-	0x25, 0x4e, 0xc3, 0x9f, 0x82, 0x3f, 0xc8, 0xe9, 0x19, 0x5a, 0x63, 0x56, 0x71, 0x89, 0x4d, 0x6f,
-	0x75, 0x15, 0x3f, 0x86, 0x87, 0xb7, 0x64, 0xbb, 0xc9, 0x1f, 0x43, 0x83, 0x25, 0x61, 0x6a, 0xb4,
-	0xc9, 0x55, 0x92, 0x1b, 0xf0, 0xaf, 0x15, 0xd8, 0x39, 0x97, 0x5c, 0xd0, 0x88, 0x6a, 0x16, 0x66,
-	0x2d, 0x5f, 0x71, 0xec, 0x9f, 0xfc, 0xcb, 0xb1, 0xdf, 0xbd, 0xe5, 0xd8, 0xbb, 0x72, 0xff, 0xdf,
-	0xc5, 0x97, 0xa5, 0x23, 0xb0, 0x74, 0xf1, 0xcb, 0x17, 0x82, 0x2c, 0x05, 0xff, 0xc7, 0x8b, 0x7f,
-	0x00, 0xb5, 0xc0, 0xac, 0x8a, 0xf9, 0xe2, 0xc6, 0x3c, 0x64, 0x96, 0x94, 0x6d, 0x62, 0x65, 0xb3,
-	0xf9, 0x33, 0x15, 0xb9, 0xfd, 0x33, 0x22, 0xfe, 0xc5, 0x03, 0x54, 0xa4, 0xd3, 0xbd, 0xc1, 0x2b,
-	0xf8, 0xc4, 0xd9, 0x62, 0xa6, 0x34, 0xde, 0xcd, 0x00, 0x19, 0x9b, 0x5b, 0x53, 0xf4, 0x14, 0xda,
-	0xe3, 0x12, 0xad, 0x2a, 0x23, 0xed, 0xd1, 0xad, 0xac, 0xa7, 0x5d, 0xc9, 0x4a, 0x12, 0x7e, 0x0b,
-	0x76, 0xd2, 0xff, 0xfd, 0xfd, 0x64, 0xc2, 0xb3, 0xc7, 0x6e, 0x41, 0x25, 0x0e, 0xdd, 0xaa, 0x56,
-	0xe2, 0x10, 0x0f, 0x00, 0x15, 0x83, 0xdc, 0x08, 0x4b, 0x51, 0x86, 0x8f, 0x29, 0x57, 0x3a, 0xbb,
-	0x40, 0x46, 0x36, 0x36, 0x43, 0xb9, 0xc5, 0x56, 0x23, 0x56, 0xc6, 0xc7, 0xf0, 0x46, 0x0a, 0xc0,
-	0xfc, 0xdc, 0x98, 0xab, 0xac, 0xe9, 0x5a, 0xa7, 0x0d, 0x9f, 0xc2, 0xfd, 0x72, 0x11, 0x07, 0xea,
-	0x01, 0xd4, 0xd9, 0xcb, 0x58, 0x69, 0x65, 0x8b, 0x6c, 0x11, 0xa7, 0x99, 0xeb, 0x10, 0xab, 0xf4,
-	0xe5, 0x6d, 0x9d, 0x2d, 0xb2, 0xd0, 0xdf, 0xfb, 0x06, 0x2a, 0x43, 0x81, 0x76, 0x60, 0xfb, 0x98,
-	0x04, 0xbd, 0x8b, 0xe0, 0x72, 0x74, 0x41, 0x82, 0xde, 0xf3, 0xf6, 0x1d, 0xd4, 0x02, 0x18, 0x3d,
-	0x23, 0xfd, 0xb3, 0x2f, 0x2f, 0xfb, 0x23, 0xd2, 0xf6, 0x4c, 0x08, 0x09, 0xce, 0x87, 0xe4, 0xe2,
-	0x72, 0x10, 0xf4, 0x4e, 0x02, 0xd2, 0xae, 0xd8, 0xac, 0x67, 0xbd, 0xb3, 0xa7, 0x41, 0x66, 0xaa,
-	0x9a, 0xac, 0xe0, 0xeb, 0xf3, 0xde, 0xd9, 0x89, 0xcd, 0xda, 0x78, 0xd2, 0xfe, 0xfd, 0x66, 0xcf,
-	0xfb, 0xe3, 0x66, 0xcf, 0xfb, 0xf3, 0x66, 0xcf, 0xfb, 0xe9, 0xaf, 0xbd, 0x3b, 0x57, 0x75, 0xfb,
-	0x44, 0x1f, 0xfc, 0x13, 0x00, 0x00, 0xff, 0xff, 0xef, 0xe1, 0x1b, 0x7a, 0xdf, 0x09, 0x00, 0x00,
+	// 1658 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xdb, 0x6f, 0xe3, 0x58,
+	0x19, 0x9f, 0xdc, 0x93, 0x2f, 0x37, 0xf7, 0xcc, 0xec, 0xac, 0xa7, 0x33, 0xaa, 0x46, 0x86, 0xd5,
+	0x96, 0x85, 0x99, 0x8a, 0x02, 0x42, 0xa0, 0x05, 0x36, 0xd3, 0xba, 0x6d, 0x3a, 0x6d, 0x1c, 0x9d,
+	0x64, 0x0a, 0x12, 0x48, 0x91, 0x6b, 0x9f, 0x24, 0xa6, 0x89, 0x8f, 0xb1, 0x9d, 0xee, 0xec, 0x03,
+	0xff, 0x04, 0x4f, 0xf0, 0xc4, 0xdf, 0xc2, 0x1b, 0x8f, 0x3c, 0xf0, 0x07, 0xa0, 0x41, 0x48, 0x48,
+	0xfc, 0x13, 0xe8, 0xdc, 0x1c, 0xdb, 0x69, 0x47, 0x1b, 0xcd, 0x3c, 0xc5, 0xdf, 0xf5, 0xf7, 0x9d,
+	0xf3, 0x5d, 0xce, 0xa7, 0xc0, 0xd3, 0x88, 0x84, 0xb7, 0x24, 0x3c, 0x08, 0x42, 0x1a, 0xd3, 0x03,
+	0xcf, 0x8f, 0x49, 0xe8, 0xdb, 0x8b, 0x97, 0x9c, 0x44, 0x15, 0xfe, 0xb3, 0xfb, 0xd5, 0xcc, 0x8b,
+	0xe7, 0xab, 0xeb, 0x97, 0x0e, 0x5d, 0x1e, 0x2c, 0xbc, 0x69, 0x7c, 0x1d, 0x7a, 0xee, 0x8c, 0xbc,
+	0xf0, 0xe8, 0xc1, 0x8c, 0xbe, 0x58, 0x33, 0xd2, 0xb2, 0x59, 0x18, 0x38, 0x07, 0x76, 0xe0, 0x09,
+	0x47, 0xbb, 0x7a, 0x06, 0xc5, 0x76, 0x97, 0x9e, 0x2f, 0x24, 0xc6, 0xf7, 0xa0, 0x39, 0xe2, 0xb2,
+	0x51, 0x6c, 0xc7, 0x04, 0xed, 0x42, 0x5d, 0xa8, 0xf6, 0x8f, 0xf5, 0xc2, 0xf3, 0xc2, 0x7e, 0x03,
+	0x27, 0xb4, 0xf1, 0xcf, 0x12, 0xd4, 0xb0, 0x3d, 0x8d, 0x2f, 0xe8, 0x0c, 0x3d, 0x81, 0x22, 0x0d,
+	0xb8, 0x46, 0xe7, 0xb0, 0x21, 0x5c, 0xbd, 0xb4, 0x02, 0x5c, 0xa4, 0x01, 0xfa, 0x05, 0x74, 0x9c,
+	0x90, 0xd8, 0x31, 0x19, 0xc5, 0x21, 0xb1, 0x97, 0x56, 0xa0, 0x17, 0x9f, 0x17, 0xf6, 0x9b, 0x87,
+	0x9f, 0x48, 0xb5, 0xa3, 0x8c, 0x10, 0xe7, 0x94, 0xd1, 0x8f, 0xa1, 0x19, 0xcd, 0x43, 0xcf, 0xbf,
+	0xe9, 0x8f, 0xb0, 0x15, 0xe8, 0x25, 0x6e, 0x8b, 0xa4, 0xed, 0x68, 0x2d, 0xc1, 0x69, 0x35, 0x0e,
+	0x3a, 0xb7, 0xfd, 0x19, 0xb9, 0x20, 0xb6, 0x4b, 0x42, 0x2b, 0xd0, 0xcb, 0x59, 0xd0, 0x8c, 0x10,
+	0xe7, 0x94, 0x19, 0x28, 0x79, 0x1b, 0xd8, 0xbe, 0x2b, 0x40, 0x2b, 0x19, 0x50, 0x73, 0x2d, 0xc1,
+	0x69, 0x35, 0xf4, 0x73, 0x68, 0x07, 0xab, 0x70, 0xb6, 0x3e, 0x68, 0x95, 0xdb, 0x3d, 0x92, 0x76,
+	0xc3, 0xb4, 0x0c, 0x67, 0x55, 0x51, 0x1f, 0x50, 0x48, 0xec, 0x28, 0xf2, 0x66, 0x3e, 0x26, 0xc1,
+	0xc2, 0x73, 0xec, 0xc8, 0x0a, 0xf4, 0x1a, 0x77, 0xf0, 0x44, 0x3a, 0xc0, 0x1b, 0x0a, 0xf8, 0x0e,
+	0x23, 0x7e, 0x76, 0x1a, 0xba, 0xd4, 0x17, 0x89, 0xb4, 0x02, 0xbd, 0x9e, 0x3d, 0x7b, 0x46, 0x88,
+	0x73, 0xca, 0xc6, 0x4f, 0xa1, 0x93, 0x4d, 0x09, 0xfa, 0x0c, 0xaa, 0x11, 0xff, 0xe6, 0x09, 0x6e,
+	0x1e, 0xb6, 0xd5, 0xed, 0x73, 0x26, 0x96, 0x42, 0xe3, 0xaf, 0x05, 0x68, 0xa6, 0x12, 0x82, 0x74,
+	0xa8, 0x45, 0xab, 0xeb, 0xdf, 0x13, 0x27, 0x96, 0xa5, 0xa3, 0x48, 0x84, 0xa0, 0xec, 0xdb, 0x4b,
+	0xc2, 0x0b, 0xa1, 0x81, 0xf9, 0x37, 0xda, 0x87, 0x6e, 0x28, 0xce, 0x30, 0xa6, 0x98, 0x2c, 0xe9,
+	0x2d, 0xe1, 0xb9, 0x6e, 0xe0, 0x3c, 0x1b, 0x3d, 0x86, 0xea, 0x82, 0x27, 0x8a, 0xe7, 0xb4, 0x81,
+	0x25, 0x85, 0x9e, 0x43, 0x53, 0x7c, 0x99, 0x01, 0x75, 0xe6, 0x3c, 0x69, 0x65, 0x9c, 0x66, 0x19,
+	0x7f, 0x29, 0x40, 0x33, 0x95, 0xbd, 0x2d, 0x23, 0x34, 0xa0, 0x95, 0x84, 0xd2, 0x73, 0x5d, 0x19,
+	0x5e, 0x86, 0xf7, 0x01, 0xb1, 0xfd, 0xa9, 0x00, 0x1d, 0x4c, 0x02, 0x1a, 0xc6, 0x49, 0x15, 0x6e,
+	0x17, 0x9e, 0x0e, 0x35, 0x19, 0x8a, 0x8c, 0x4c, 0x91, 0x1f, 0x10, 0x54, 0x00, 0x9d, 0x6c, 0xa7,
+	0x6c, 0x19, 0xd3, 0x1a, 0xb9, 0x94, 0x41, 0xd6, 0xa1, 0xb6, 0xf2, 0x9d, 0x05, 0xb1, 0x7d, 0x1e,
+	0x52, 0x1d, 0x2b, 0xd2, 0xf8, 0x06, 0xba, 0x67, 0xb6, 0xef, 0x5a, 0xd3, 0xe9, 0x47, 0x86, 0xcc,
+	0x1d, 0xb6, 0xbc, 0x79, 0xd8, 0x2f, 0xa1, 0x93, 0x6d, 0x0d, 0xd4, 0x81, 0xa2, 0xe7, 0x4a, 0xd0,
+	0xa2, 0xe7, 0xb2, 0x69, 0x28, 0x9a, 0x85, 0xb8, 0x1c, 0xb3, 0x8e, 0x13, 0xda, 0xf8, 0x2d, 0xb4,
+	0x33, 0x0d, 0xbe, 0x7d, 0xd8, 0x74, 0x3a, 0x8d, 0x48, 0xcc, 0xc3, 0x2e, 0x61, 0x49, 0x9d, 0x97,
+	0xeb, 0x65, 0xad, 0x62, 0xdc, 0x02, 0xda, 0x6c, 0xfe, 0x2d, 0x11, 0x76, 0xa1, 0x2e, 0x0b, 0x22,
+	0xd2, 0x4b, 0xcf, 0x4b, 0x6c, 0x94, 0x2b, 0xfa, 0xbe, 0x0a, 0x31, 0xfe, 0x56, 0x82, 0xaa, 0x38,
+	0xd0, 0x96, 0x60, 0x8f, 0xa0, 0x32, 0x0b, 0xe9, 0x2a, 0x90, 0x49, 0x10, 0x04, 0xfa, 0x01, 0xec,
+	0x48, 0xc8, 0xd8, 0xa3, 0xfe, 0x89, 0xed, 0xc4, 0x54, 0x20, 0x56, 0xf0, 0xa6, 0x20, 0x13, 0x70,
+	0xe5, 0xde, 0x80, 0xab, 0x99, 0x2c, 0x6b, 0x50, 0xf2, 0xa2, 0x50, 0xaf, 0x71, 0x75, 0xf6, 0x99,
+	0xcf, 0x7b, 0x7d, 0x23, 0xef, 0x2c, 0x56, 0xc2, 0x65, 0x0d, 0x2e, 0x13, 0x04, 0xfa, 0x3e, 0x54,
+	0x97, 0x5e, 0x18, 0xd2, 0x50, 0x07, 0x3e, 0xf4, 0x1e, 0x66, 0x86, 0xde, 0x25, 0x17, 0x61, 0xa9,
+	0xc2, 0xde, 0x8b, 0xe9, 0x62, 0x15, 0xcd, 0x87, 0x74, 0xe1, 0x39, 0xdf, 0xe8, 0xcd, 0xcc, 0x7b,
+	0x71, 0xb2, 0x96, 0xe0, 0xb4, 0x1a, 0x7a, 0x05, 0xcf, 0x64, 0xd9, 0x8b, 0x5a, 0x37, 0x17, 0xc4,
+	0x61, 0xe7, 0x1f, 0x7b, 0x4b, 0x42, 0x57, 0xb1, 0xde, 0xe2, 0x95, 0xf0, 0x5e, 0x1d, 0x76, 0xbc,
+	0x28, 0xb6, 0xc3, 0xd8, 0x12, 0xc5, 0xd3, 0xe6, 0x26, 0x69, 0x96, 0x61, 0x42, 0x33, 0x15, 0x01,
+	0xbb, 0xd5, 0x25, 0x89, 0x22, 0x7b, 0x46, 0x22, 0x9e, 0xc8, 0x12, 0x4e, 0x68, 0x26, 0xe3, 0x1b,
+	0xc7, 0xad, 0xbd, 0xe0, 0xd9, 0x2c, 0xe1, 0x84, 0x36, 0x6e, 0xa1, 0x95, 0x3e, 0x3a, 0x07, 0xa6,
+	0xab, 0xd0, 0x21, 0x3d, 0xd7, 0x0d, 0x99, 0x2b, 0x76, 0xe3, 0x69, 0x16, 0xfa, 0x2e, 0xb4, 0x05,
+	0x39, 0x92, 0x75, 0x23, 0x0a, 0x24, 0xcb, 0x44, 0x7b, 0x00, 0x82, 0x31, 0x60, 0x35, 0x24, 0xca,
+	0x25, 0xc5, 0x31, 0x6c, 0xe8, 0xb2, 0x25, 0xe3, 0x9c, 0x7a, 0x3e, 0x26, 0x7f, 0x58, 0x91, 0x28,
+	0x66, 0xc9, 0xf7, 0xa9, 0x4b, 0x92, 0x95, 0x44, 0x52, 0x2c, 0x7c, 0xf6, 0xc5, 0xd0, 0x25, 0x56,
+	0x42, 0x0b, 0x99, 0x7f, 0x45, 0x63, 0x39, 0x18, 0xea, 0x38, 0xa1, 0x8d, 0x7d, 0xd0, 0xd6, 0x10,
+	0x51, 0x40, 0xfd, 0x88, 0x17, 0x30, 0xe1, 0xd9, 0x17, 0x10, 0x82, 0x30, 0x08, 0x68, 0x97, 0x24,
+	0xb6, 0x5d, 0x3b, 0xb6, 0x47, 0xbe, 0x1d, 0x44, 0x73, 0x1a, 0xa3, 0xcf, 0xa1, 0x26, 0x1e, 0x40,
+	0x71, 0x09, 0x1b, 0xcf, 0xa3, 0x92, 0xb2, 0x17, 0x4e, 0x4d, 0x0b, 0x31, 0x61, 0x22, 0xbd, 0xc8,
+	0x6f, 0x2d, 0xcf, 0x36, 0xce, 0x59, 0xbb, 0x27, 0xed, 0xa0, 0x8e, 0xfd, 0x0c, 0x1a, 0xb2, 0xfe,
+	0x93, 0x93, 0xaf, 0x19, 0xa9, 0x01, 0x52, 0x4c, 0x0f, 0x10, 0xe3, 0x4b, 0xd0, 0x2f, 0xd6, 0xc5,
+	0x2e, 0x6a, 0x42, 0x79, 0xcc, 0xf5, 0x46, 0x61, 0x73, 0x26, 0xfe, 0x0c, 0x9e, 0xdc, 0x61, 0x2d,
+	0xef, 0xe8, 0x19, 0x34, 0x08, 0x1f, 0xd5, 0x0c, 0x55, 0xd4, 0xd2, 0x9a, 0x61, 0xfc, 0x11, 0x3e,
+	0x3d, 0xa1, 0xe1, 0xd7, 0x76, 0xe8, 0x12, 0x77, 0xb8, 0xba, 0x5e, 0x78, 0xd1, 0x5c, 0xe1, 0xee,
+	0x43, 0x4d, 0xd6, 0x9c, 0xdc, 0x28, 0x3a, 0xf2, 0xca, 0x2e, 0x05, 0x17, 0x2b, 0x31, 0xab, 0x8e,
+	0xaf, 0x6d, 0x2f, 0x3e, 0xa1, 0x61, 0xcf, 0xb9, 0x91, 0x33, 0x37, 0xc5, 0x61, 0x53, 0x29, 0x96,
+	0xdd, 0x22, 0xe6, 0xa6, 0x22, 0x8d, 0xdf, 0x81, 0xbe, 0x09, 0x9f, 0x04, 0x5e, 0xb2, 0x9d, 0x1b,
+	0x89, 0x0d, 0x12, 0xbb, 0xe7, 0xdc, 0x60, 0xc6, 0x46, 0x86, 0x4a, 0xbd, 0xd8, 0x53, 0x5b, 0x52,
+	0x6e, 0x32, 0x9e, 0x2a, 0x84, 0xff, 0x55, 0x60, 0x67, 0x18, 0xd2, 0xc0, 0x9e, 0xd9, 0x31, 0x71,
+	0xd5, 0xb9, 0xde, 0xb3, 0x05, 0xbf, 0xba, 0x67, 0x0b, 0xde, 0xbd, 0x63, 0x0b, 0x96, 0xee, 0x3e,
+	0xde, 0x2a, 0x1c, 0x66, 0xf6, 0x8a, 0xdc, 0x2a, 0x9c, 0x5d, 0x3a, 0x70, 0x4e, 0xf9, 0x63, 0xad,
+	0xc2, 0xb5, 0x6f, 0xbf, 0x0a, 0x7f, 0x05, 0xdd, 0x79, 0x76, 0x05, 0xe0, 0x93, 0xb9, 0x79, 0xf8,
+	0x58, 0x5a, 0xe7, 0x16, 0x04, 0x9c, 0x57, 0xbf, 0x63, 0x03, 0x86, 0x2d, 0x36, 0x60, 0xf4, 0x43,
+	0x68, 0xa5, 0x6f, 0x9e, 0xcf, 0xe1, 0x8d, 0xb6, 0xce, 0xa8, 0xb0, 0xf4, 0x86, 0x7c, 0x3b, 0x4d,
+	0x10, 0xdb, 0x99, 0xf4, 0xe2, 0x94, 0x30, 0x49, 0x6f, 0xd6, 0x02, 0xfd, 0x0a, 0xda, 0xb6, 0xeb,
+	0x0e, 0xe4, 0x54, 0xb2, 0x02, 0xbd, 0x93, 0xd9, 0xfe, 0x7b, 0x6b, 0x99, 0xf2, 0x90, 0xd5, 0x47,
+	0x26, 0x74, 0x83, 0x90, 0x2e, 0x69, 0xbc, 0x8e, 0xa2, 0xcb, 0x5d, 0x3c, 0x55, 0xd7, 0x9e, 0x96,
+	0x2a, 0x27, 0x79, 0x9b, 0xf3, 0x72, 0xbd, 0xaa, 0xd5, 0xce, 0xcb, 0xf5, 0xba, 0xd6, 0x38, 0x2f,
+	0xd7, 0x9b, 0x5a, 0xcb, 0x78, 0x01, 0x15, 0x5e, 0xfd, 0xec, 0xa9, 0x77, 0xa8, 0x2b, 0xba, 0xb6,
+	0x8d, 0xf9, 0x37, 0x7b, 0x72, 0x97, 0xd1, 0x4c, 0x0e, 0x5c, 0xf6, 0x69, 0x98, 0x50, 0xef, 0x39,
+	0x37, 0xc2, 0xe2, 0xf3, 0x94, 0x45, 0x27, 0x79, 0x44, 0x95, 0xf8, 0x88, 0xba, 0xe4, 0x5e, 0x37,
+	0x3f, 0x81, 0x16, 0xd3, 0x7b, 0x1b, 0x13, 0x3f, 0xf2, 0xa8, 0x8f, 0x3e, 0x53, 0x7d, 0xe9, 0xf2,
+	0x43, 0x75, 0x73, 0xbe, 0x54, 0x6b, 0xfe, 0xa7, 0x00, 0x28, 0xdd, 0x9a, 0xb2, 0xe7, 0xdf, 0xd3,
+	0x9b, 0xdf, 0xa2, 0xe1, 0xd1, 0x29, 0x68, 0x4e, 0xa6, 0x45, 0x23, 0xd5, 0x80, 0x4f, 0xef, 0xec,
+	0x60, 0x81, 0x8a, 0x37, 0x8c, 0x98, 0xa3, 0x30, 0x53, 0x0c, 0x91, 0x6a, 0x8e, 0xa7, 0x77, 0xd6,
+	0x8a, 0x72, 0x94, 0x37, 0x32, 0xbe, 0x03, 0x3b, 0x82, 0xea, 0xfb, 0x53, 0xaa, 0x26, 0x50, 0x6e,
+	0x63, 0x35, 0x2e, 0x00, 0xa5, 0x95, 0xe4, 0x5d, 0xe4, 0xb4, 0x58, 0x5a, 0xe7, 0x34, 0x52, 0x0f,
+	0x34, 0xff, 0x66, 0x3c, 0x36, 0x07, 0xf8, 0x21, 0x2b, 0x98, 0x7f, 0x1b, 0x47, 0xf0, 0x50, 0x9c,
+	0x84, 0xfd, 0x39, 0xb0, 0x8a, 0x14, 0xe8, 0x56, 0xab, 0xa1, 0x71, 0x0e, 0x8f, 0xb2, 0x4e, 0x64,
+	0x50, 0x8f, 0xa1, 0x4a, 0xde, 0x7a, 0x51, 0x2c, 0xd6, 0x92, 0x3a, 0x96, 0x14, 0x5f, 0x4a, 0x22,
+	0xd1, 0xda, 0x6a, 0xe9, 0x56, 0xf4, 0x17, 0xff, 0x2d, 0x40, 0xd1, 0x0a, 0xd0, 0x0e, 0xb4, 0x8f,
+	0xb0, 0xd9, 0x1b, 0x9b, 0x93, 0xd1, 0x18, 0x9b, 0xbd, 0x4b, 0xed, 0x01, 0xea, 0x00, 0x8c, 0xce,
+	0x70, 0x7f, 0xf0, 0x7a, 0xd2, 0x1f, 0x61, 0xad, 0xc0, 0x54, 0xb0, 0x39, 0xb4, 0xf0, 0x78, 0x72,
+	0x61, 0xf6, 0x8e, 0x4d, 0xac, 0x15, 0xb9, 0xd5, 0x59, 0x6f, 0x70, 0x6a, 0x2a, 0x56, 0x89, 0x59,
+	0x99, 0xbf, 0x19, 0xf6, 0x06, 0xc7, 0xdc, 0xaa, 0x8c, 0x34, 0x68, 0x0d, 0xdf, 0xe0, 0xd3, 0xc4,
+	0x6f, 0x05, 0x7d, 0x02, 0x3b, 0xd8, 0xec, 0x8d, 0x46, 0xfd, 0xd3, 0xc1, 0x04, 0x9b, 0xc3, 0x8b,
+	0xfe, 0x51, 0x6f, 0xa4, 0x55, 0xd1, 0x43, 0xe8, 0x9e, 0x31, 0x33, 0xeb, 0xe4, 0x44, 0x79, 0xab,
+	0x71, 0x00, 0x0b, 0x1f, 0x5b, 0x83, 0xc9, 0xc8, 0xc4, 0x57, 0x26, 0xd6, 0xea, 0x22, 0x8c, 0x4b,
+	0xeb, 0xca, 0x54, 0xac, 0x06, 0x63, 0xf5, 0x8e, 0x8f, 0x27, 0x03, 0x6b, 0x30, 0xb9, 0xb2, 0xc6,
+	0x26, 0xd6, 0x00, 0x21, 0xe8, 0x0c, 0xb1, 0x75, 0x69, 0x8d, 0x13, 0xb5, 0xe6, 0x17, 0xbf, 0x14,
+	0xdd, 0xa0, 0xba, 0x86, 0x05, 0xd2, 0x3b, 0x7a, 0x3d, 0x31, 0x31, 0xb6, 0xf0, 0xe4, 0xcd, 0xe0,
+	0xf5, 0xc0, 0xfa, 0xf5, 0x40, 0x7b, 0x80, 0x3e, 0x85, 0x87, 0x03, 0x6b, 0x3c, 0x31, 0x07, 0xd6,
+	0x9b, 0xd3, 0xb3, 0x75, 0x84, 0x85, 0x57, 0xda, 0xdf, 0xdf, 0xed, 0x15, 0xfe, 0xf1, 0x6e, 0xaf,
+	0xf0, 0xaf, 0x77, 0x7b, 0x85, 0x3f, 0xff, 0x7b, 0xef, 0xc1, 0x75, 0x95, 0x97, 0xdb, 0x8f, 0xfe,
+	0x3f, 0x00, 0xf4, 0xa2, 0x72, 0xd0, 0x73, 0x12, 0x00, 0x00,
 }
@@ -0,0 +1,1112 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: server/proto/streaming.proto
+
+/*
+	Package proto is a generated protocol buffer package.
+
+	It is generated from these files:
+		server/proto/streaming.proto
+
+	It has these top-level messages:
+		PublishAsyncRequest
+		PublishAsyncResponse
+		PublishAsyncError
+		SubscribeFlowRequest
+*/
+package proto
+
+import proto1 "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import proto2 "github.com/liftbridge-io/go-liftbridge/liftbridge-grpc"
+
+import context "golang.org/x/net/context"
+import grpc "google.golang.org/grpc"
+
+import io "io"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto1.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto1.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// PublishAsyncRequest is sent on a PublishAsync stream to publish a message.
+type PublishAsyncRequest struct {
+	Message *proto2.Message `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *PublishAsyncRequest) Reset()                    { *m = PublishAsyncRequest{} }
+func (m *PublishAsyncRequest) String() string            { return proto1.CompactTextString(m) }
+func (*PublishAsyncRequest) ProtoMessage()               {}
+func (*PublishAsyncRequest) Descriptor() ([]byte, []int) { return fileDescriptorStreaming, []int{0} }
+
+func (m *PublishAsyncRequest) GetMessage() *proto2.Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+// PublishAsyncResponse is sent on a PublishAsync stream for each ack received
+// for a published message or for each message which failed to be published.
+type PublishAsyncResponse struct {
+	Ack           *proto2.Ack        `protobuf:"bytes,1,opt,name=ack" json:"ack,omitempty"`
+	Error         *PublishAsyncError `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	CorrelationId string             `protobuf:"bytes,3,opt,name=correlationId,proto3" json:"correlationId,omitempty"`
+}
+
+func (m *PublishAsyncResponse) Reset()                    { *m = PublishAsyncResponse{} }
+func (m *PublishAsyncResponse) String() string            { return proto1.CompactTextString(m) }
+func (*PublishAsyncResponse) ProtoMessage()               {}
+func (*PublishAsyncResponse) Descriptor() ([]byte, []int) { return fileDescriptorStreaming, []int{1} }
+
+func (m *PublishAsyncResponse) GetAck() *proto2.Ack {
+	if m != nil {
+		return m.Ack
+	}
+	return nil
+}
+
+func (m *PublishAsyncResponse) GetError() *PublishAsyncError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *PublishAsyncResponse) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
+// PublishAsyncError describes why a message sent on a PublishAsync stream
+// failed to be published.
+type PublishAsyncError struct {
+	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Msg  string `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (m *PublishAsyncError) Reset()                    { *m = PublishAsyncError{} }
+func (m *PublishAsyncError) String() string            { return proto1.CompactTextString(m) }
+func (*PublishAsyncError) ProtoMessage()               {}
+func (*PublishAsyncError) Descriptor() ([]byte, []int) { return fileDescriptorStreaming, []int{2} }
+
+func (m *PublishAsyncError) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *PublishAsyncError) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+// SubscribeFlowRequest is sent on a flow-controlled Subscribe stream. The first
+// request starts the subscription. Every request grants the server credits to
+// send more messages.
+type SubscribeFlowRequest struct {
+	Subscribe *proto2.SubscribeRequest `protobuf:"bytes,1,opt,name=subscribe" json:"subscribe,omitempty"`
+	Credits   uint32                   `protobuf:"varint,2,opt,name=credits,proto3" json:"credits,omitempty"`
+}
+
+func (m *SubscribeFlowRequest) Reset()                    { *m = SubscribeFlowRequest{} }
+func (m *SubscribeFlowRequest) String() string            { return proto1.CompactTextString(m) }
+func (*SubscribeFlowRequest) ProtoMessage()               {}
+func (*SubscribeFlowRequest) Descriptor() ([]byte, []int) { return fileDescriptorStreaming, []int{3} }
+
+func (m *SubscribeFlowRequest) GetSubscribe() *proto2.SubscribeRequest {
+	if m != nil {
+		return m.Subscribe
+	}
+	return nil
+}
+
+func (m *SubscribeFlowRequest) GetCredits() uint32 {
+	if m != nil {
+		return m.Credits
+	}
+	return 0
+}
+
+func init() {
+	proto1.RegisterType((*PublishAsyncRequest)(nil), "proto.PublishAsyncRequest")
+	proto1.RegisterType((*PublishAsyncResponse)(nil), "proto.PublishAsyncResponse")
+	proto1.RegisterType((*PublishAsyncError)(nil), "proto.PublishAsyncError")
+	proto1.RegisterType((*SubscribeFlowRequest)(nil), "proto.SubscribeFlowRequest")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for StreamingAPI service
+
+type StreamingAPIClient interface {
+	// PublishAsync publishes messages sent on the stream and sends their acks
+	// back on the stream as they're received. Each message whose AckPolicy is
+	// not NONE must have a CorrelationId, which its acks and errors carry, so
+	// many messages can be in flight at once. A message whose ack isn't
+	// received within the ack timeout, set on the ack-timeout metadata key, is
+	// reported with a DeadlineExceeded error.
+	PublishAsync(ctx context.Context, opts ...grpc.CallOption) (StreamingAPI_PublishAsyncClient, error)
+	// Subscribe creates an ephemeral subscription for the given stream like
+	// the client API's Subscribe, but only sends as many messages as the
+	// client has granted credits for. A client which holds back messages by
+	// not granting credits for longer than the server's subscriber stall
+	// timeout is evicted with a ResourceExhausted status code.
+	Subscribe(ctx context.Context, opts ...grpc.CallOption) (StreamingAPI_SubscribeClient, error)
+}
+
+type streamingAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewStreamingAPIClient(cc *grpc.ClientConn) StreamingAPIClient {
+	return &streamingAPIClient{cc}
+}
+
+func (c *streamingAPIClient) PublishAsync(ctx context.Context, opts ...grpc.CallOption) (StreamingAPI_PublishAsyncClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_StreamingAPI_serviceDesc.Streams[0], c.cc, "/proto.StreamingAPI/PublishAsync", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamingAPIPublishAsyncClient{stream}
+	return x, nil
+}
+
+type StreamingAPI_PublishAsyncClient interface {
+	Send(*PublishAsyncRequest) error
+	Recv() (*PublishAsyncResponse, error)
+	grpc.ClientStream
+}
+
+type streamingAPIPublishAsyncClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamingAPIPublishAsyncClient) Send(m *PublishAsyncRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *streamingAPIPublishAsyncClient) Recv() (*PublishAsyncResponse, error) {
+	m := new(PublishAsyncResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *streamingAPIClient) Subscribe(ctx context.Context, opts ...grpc.CallOption) (StreamingAPI_SubscribeClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_StreamingAPI_serviceDesc.Streams[1], c.cc, "/proto.StreamingAPI/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamingAPISubscribeClient{stream}
+	return x, nil
+}
+
+type StreamingAPI_SubscribeClient interface {
+	Send(*SubscribeFlowRequest) error
+	Recv() (*proto2.Message, error)
+	grpc.ClientStream
+}
+
+type streamingAPISubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamingAPISubscribeClient) Send(m *SubscribeFlowRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *streamingAPISubscribeClient) Recv() (*proto2.Message, error) {
+	m := new(proto2.Message)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for StreamingAPI service
+
+type StreamingAPIServer interface {
+	// PublishAsync publishes messages sent on the stream and sends their acks
+	// back on the stream as they're received. Each message whose AckPolicy is
+	// not NONE must have a CorrelationId, which its acks and errors carry, so
+	// many messages can be in flight at once. A message whose ack isn't
+	// received within the ack timeout, set on the ack-timeout metadata key, is
+	// reported with a DeadlineExceeded error.
+	PublishAsync(StreamingAPI_PublishAsyncServer) error
+	// Subscribe creates an ephemeral subscription for the given stream like
+	// the client API's Subscribe, but only sends as many messages as the
+	// client has granted credits for. A client which holds back messages by
+	// not granting credits for longer than the server's subscriber stall
+	// timeout is evicted with a ResourceExhausted status code.
+	Subscribe(StreamingAPI_SubscribeServer) error
+}
+
+func RegisterStreamingAPIServer(s *grpc.Server, srv StreamingAPIServer) {
+	s.RegisterService(&_StreamingAPI_serviceDesc, srv)
+}
+
+func _StreamingAPI_PublishAsync_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StreamingAPIServer).PublishAsync(&streamingAPIPublishAsyncServer{stream})
+}
+
+type StreamingAPI_PublishAsyncServer interface {
+	Send(*PublishAsyncResponse) error
+	Recv() (*PublishAsyncRequest, error)
+	grpc.ServerStream
+}
+
+type streamingAPIPublishAsyncServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamingAPIPublishAsyncServer) Send(m *PublishAsyncResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *streamingAPIPublishAsyncServer) Recv() (*PublishAsyncRequest, error) {
+	m := new(PublishAsyncRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _StreamingAPI_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StreamingAPIServer).Subscribe(&streamingAPISubscribeServer{stream})
+}
+
+type StreamingAPI_SubscribeServer interface {
+	Send(*proto2.Message) error
+	Recv() (*SubscribeFlowRequest, error)
+	grpc.ServerStream
+}
+
+type streamingAPISubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamingAPISubscribeServer) Send(m *proto2.Message) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *streamingAPISubscribeServer) Recv() (*SubscribeFlowRequest, error) {
+	m := new(SubscribeFlowRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _StreamingAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.StreamingAPI",
+	HandlerType: (*StreamingAPIServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PublishAsync",
+			Handler:       _StreamingAPI_PublishAsync_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _StreamingAPI_Subscribe_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "server/proto/streaming.proto",
+}
+
+func (m *PublishAsyncRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PublishAsyncRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Message != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStreaming(dAtA, i, uint64(m.Message.Size()))
+		n1, err := m.Message.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	return i, nil
+}
+
+func (m *PublishAsyncResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PublishAsyncResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Ack != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStreaming(dAtA, i, uint64(m.Ack.Size()))
+		n2, err := m.Ack.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if m.Error != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintStreaming(dAtA, i, uint64(m.Error.Size()))
+		n3, err := m.Error.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if len(m.CorrelationId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintStreaming(dAtA, i, uint64(len(m.CorrelationId)))
+		i += copy(dAtA[i:], m.CorrelationId)
+	}
+	return i, nil
+}
+
+func (m *PublishAsyncError) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PublishAsyncError) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintStreaming(dAtA, i, uint64(m.Code))
+	}
+	if len(m.Msg) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintStreaming(dAtA, i, uint64(len(m.Msg)))
+		i += copy(dAtA[i:], m.Msg)
+	}
+	return i, nil
+}
+
+func (m *SubscribeFlowRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeFlowRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Subscribe != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintStreaming(dAtA, i, uint64(m.Subscribe.Size()))
+		n4, err := m.Subscribe.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if m.Credits != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintStreaming(dAtA, i, uint64(m.Credits))
+	}
+	return i, nil
+}
+
+func encodeVarintStreaming(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *PublishAsyncRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Message != nil {
+		l = m.Message.Size()
+		n += 1 + l + sovStreaming(uint64(l))
+	}
+	return n
+}
+
+func (m *PublishAsyncResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Ack != nil {
+		l = m.Ack.Size()
+		n += 1 + l + sovStreaming(uint64(l))
+	}
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovStreaming(uint64(l))
+	}
+	l = len(m.CorrelationId)
+	if l > 0 {
+		n += 1 + l + sovStreaming(uint64(l))
+	}
+	return n
+}
+
+func (m *PublishAsyncError) Size() (n int) {
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovStreaming(uint64(m.Code))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovStreaming(uint64(l))
+	}
+	return n
+}
+
+func (m *SubscribeFlowRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Subscribe != nil {
+		l = m.Subscribe.Size()
+		n += 1 + l + sovStreaming(uint64(l))
+	}
+	if m.Credits != 0 {
+		n += 1 + sovStreaming(uint64(m.Credits))
+	}
+	return n
+}
+
+func sovStreaming(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozStreaming(x uint64) (n int) {
+	return sovStreaming(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *PublishAsyncRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStreaming
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PublishAsyncRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PublishAsyncRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStreaming
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStreaming
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Message == nil {
+				m.Message = &proto2.Message{}
+			}
+			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStreaming(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStreaming
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PublishAsyncResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStreaming
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PublishAsyncResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PublishAsyncResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ack", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStreaming
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStreaming
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ack == nil {
+				m.Ack = &proto2.Ack{}
+			}
+			if err := m.Ack.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStreaming
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStreaming
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &PublishAsyncError{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CorrelationId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStreaming
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStreaming
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CorrelationId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStreaming(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStreaming
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PublishAsyncError) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStreaming
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PublishAsyncError: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PublishAsyncError: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStreaming
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStreaming
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStreaming
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStreaming(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStreaming
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribeFlowRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStreaming
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeFlowRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeFlowRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subscribe", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStreaming
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStreaming
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Subscribe == nil {
+				m.Subscribe = &proto2.SubscribeRequest{}
+			}
+			if err := m.Subscribe.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Credits", wireType)
+			}
+			m.Credits = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStreaming
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Credits |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStreaming(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthStreaming
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipStreaming(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowStreaming
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowStreaming
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowStreaming
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			iNdEx += length
+			if length < 0 {
+				return 0, ErrInvalidLengthStreaming
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowStreaming
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipStreaming(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthStreaming = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowStreaming   = fmt.Errorf("proto: integer overflow")
+)
+
+func init() { proto1.RegisterFile("server/proto/streaming.proto", fileDescriptorStreaming) }
+
+var fileDescriptorStreaming = []byte{
+	// 380 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x51, 0xcd, 0xce, 0xd2, 0x40,
+	0x14, 0x65, 0x44, 0x24, 0xbd, 0x82, 0xc1, 0x91, 0xc4, 0xa6, 0x90, 0x86, 0x34, 0x2e, 0xba, 0xa1,
+	0x35, 0x18, 0x17, 0x6e, 0x54, 0x4c, 0x34, 0x61, 0x41, 0x42, 0x86, 0x27, 0x68, 0xa7, 0xe3, 0x30,
+	0xa1, 0xed, 0xd4, 0x99, 0x56, 0xe3, 0x2b, 0xf8, 0x04, 0x6e, 0x7c, 0x1f, 0x97, 0x3e, 0x82, 0xe1,
+	0x7b, 0x91, 0x2f, 0xf4, 0x07, 0x0a, 0x1f, 0xab, 0x99, 0x39, 0xe7, 0xdc, 0x73, 0xcf, 0xbd, 0x03,
+	0x53, 0xcd, 0xd4, 0x77, 0xa6, 0xfc, 0x4c, 0xc9, 0x5c, 0xfa, 0x3a, 0x57, 0x2c, 0x48, 0x44, 0xca,
+	0xbd, 0xf2, 0x8d, 0x7b, 0xe5, 0x61, 0x7d, 0xe4, 0x22, 0xdf, 0x15, 0xa1, 0x47, 0x65, 0xe2, 0xc7,
+	0xe2, 0x6b, 0x1e, 0x2a, 0x11, 0x71, 0x36, 0x17, 0xd2, 0xe7, 0x72, 0x7e, 0x06, 0xda, 0x1c, 0x57,
+	0x19, 0xf5, 0x83, 0x4c, 0x54, 0x46, 0xce, 0x07, 0x78, 0xb1, 0x29, 0xc2, 0x58, 0xe8, 0xdd, 0x52,
+	0xff, 0x4c, 0x29, 0x61, 0xdf, 0x0a, 0xa6, 0x73, 0xec, 0x42, 0x3f, 0x61, 0x5a, 0x07, 0x9c, 0x99,
+	0x68, 0x86, 0xdc, 0xa7, 0x8b, 0x67, 0x95, 0xde, 0x5b, 0x57, 0x28, 0x69, 0x68, 0xe7, 0x17, 0x82,
+	0xf1, 0xa5, 0x83, 0xce, 0x64, 0xaa, 0x19, 0x9e, 0x42, 0x37, 0xa0, 0xfb, 0xba, 0x1c, 0xea, 0xf2,
+	0x25, 0xdd, 0x93, 0x23, 0x8c, 0x3d, 0xe8, 0x31, 0xa5, 0xa4, 0x32, 0x1f, 0x95, 0xbc, 0x59, 0xf3,
+	0x6d, 0xa7, 0xcf, 0x47, 0x9e, 0x54, 0x32, 0xfc, 0x0a, 0x86, 0x54, 0x2a, 0xc5, 0xe2, 0x20, 0x17,
+	0x32, 0x5d, 0x45, 0x66, 0x77, 0x86, 0x5c, 0x83, 0x5c, 0x82, 0xce, 0x3b, 0x78, 0xfe, 0xc0, 0x01,
+	0x63, 0x78, 0x4c, 0x65, 0x54, 0x0d, 0x32, 0x24, 0xe5, 0x1d, 0x8f, 0xa0, 0x9b, 0x68, 0x5e, 0x36,
+	0x37, 0xc8, 0xf1, 0xea, 0x70, 0x18, 0x6f, 0x8b, 0x50, 0x53, 0x25, 0x42, 0xf6, 0x25, 0x96, 0x3f,
+	0x9a, 0x4d, 0xbc, 0x05, 0x43, 0x37, 0x78, 0x3d, 0xcc, 0xcb, 0x3a, 0xec, 0x49, 0x5f, 0x6b, 0xc9,
+	0x59, 0x89, 0x4d, 0xe8, 0x53, 0xc5, 0x22, 0x91, 0xeb, 0xb2, 0xc9, 0x90, 0x34, 0xcf, 0xc5, 0x1f,
+	0x04, 0x83, 0x6d, 0xf3, 0x9d, 0xcb, 0xcd, 0x0a, 0xaf, 0x61, 0xd0, 0x0e, 0x8d, 0xad, 0x1b, 0xbb,
+	0xa8, 0x3b, 0x58, 0x93, 0x9b, 0x5c, 0xb5, 0x71, 0xa7, 0xe3, 0xa2, 0xd7, 0x08, 0xbf, 0x07, 0xe3,
+	0x14, 0x0c, 0x4f, 0xae, 0xa3, 0xb6, 0x46, 0xb3, 0xae, 0xfe, 0xb4, 0xaa, 0xff, 0x34, 0xfa, 0x7b,
+	0xb0, 0xd1, 0xbf, 0x83, 0x8d, 0xfe, 0x1f, 0x6c, 0xf4, 0xfb, 0xce, 0xee, 0x84, 0x4f, 0x4a, 0xd9,
+	0x9b, 0xfb, 0x01, 0x00, 0x7f, 0x8d, 0x61, 0xd5, 0x93, 0x02, 0x00, 0x00,
+}
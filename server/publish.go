@@ -0,0 +1,222 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	client "github.com/liftbridge-io/go-liftbridge/liftbridge-grpc"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nuid"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/liftbridge-io/liftbridge/server/proto"
+)
+
+// ackResult is an ack delivered in-process to a publisher waiting on it, along
+// with the status the message was rejected with, if it was.
+type ackResult struct {
+	ack    *client.Ack
+	status *status.Status
+}
+
+// Publish places the message directly on the stream leader's message
+// processing loop rather than publishing it to the stream's NATS subject. If
+// waitForAck is true, this waits for the ack, which is delivered in-process
+// rather than through the NATS AckInbox, and returns it. Otherwise, it returns
+// once the message is queued. This returns a FailedPrecondition status if this
+// server isn't the stream leader.
+func (s *stream) Publish(ctx context.Context, msg *client.Message, waitForAck bool) (
+	*client.Ack, *status.Status) {
+
+	s.mu.RLock()
+	if !s.isLeading {
+		s.mu.RUnlock()
+		return nil, status.New(codes.FailedPrecondition, "Server not stream leader")
+	}
+	if s.Mirror != nil {
+		s.mu.RUnlock()
+		return nil, status.New(codes.FailedPrecondition, "Cannot publish to mirror stream")
+	}
+	var (
+		recvChan = s.recvChan
+		stop     = s.stopLeader
+	)
+	s.mu.RUnlock()
+
+	// The waiter is keyed by a unique token rather than the client's
+	// AckInbox since clients may share one AckInbox between many messages in
+	// flight. The token stands in for the AckInbox on the stored message, and
+	// the client's AckInbox is restored on the ack.
+	var (
+		ackCh    <-chan *ackResult
+		ackInbox = msg.AckInbox
+	)
+	if waitForAck {
+		token := nuid.Next()
+		msg.AckInbox = token
+		ackCh = s.addAckWaiter(token)
+		defer s.removeAckWaiter(token)
+	}
+
+	data, err := marshalEnvelope(msg)
+	msg.AckInbox = ackInbox
+	if err != nil {
+		return nil, status.New(codes.Internal, err.Error())
+	}
+
+	select {
+	case recvChan <- &nats.Msg{Subject: msg.Subject, Data: data}:
+	case <-stop:
+		return nil, status.New(codes.FailedPrecondition, "Server not stream leader")
+	case <-ctx.Done():
+		return nil, status.New(codes.DeadlineExceeded, ctx.Err().Error())
+	}
+
+	if !waitForAck {
+		return nil, nil
+	}
+
+	select {
+	case result := <-ackCh:
+		result.ack.AckInbox = ackInbox
+		return result.ack, result.status
+	case <-ctx.Done():
+		return nil, status.New(codes.DeadlineExceeded, ctx.Err().Error())
+	}
+}
+
+// ForwardPublish forwards the message to the stream leader, which publishes it
+// directly to the stream, and returns the ack if waitForAck is true. This is
+// used when this server isn't the stream leader. If the context has no
+// deadline, the request times out after defaultPropagateTimeout.
+func (s *stream) ForwardPublish(ctx context.Context, msg *client.Message, waitForAck bool) (
+	*client.Ack, *status.Status) {
+
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultPropagateTimeout)
+		defer cancel()
+	}
+	deadline, _ := ctx.Deadline()
+
+	data, err := (&proto.ForwardedPublishRequest{
+		Message:    msg,
+		WaitForAck: waitForAck,
+		Timeout:    int64(time.Until(deadline)),
+	}).Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	respMsg, err := s.srv.ncRepl.RequestWithContext(ctx, s.getPublishInbox(), data)
+	if err != nil {
+		if err == context.DeadlineExceeded || err == nats.ErrTimeout {
+			return nil, status.New(codes.DeadlineExceeded, err.Error())
+		}
+		return nil, status.New(codes.Internal, err.Error())
+	}
+
+	resp := &proto.ForwardedPublishResponse{}
+	if err := resp.Unmarshal(respMsg.Data); err != nil {
+		return nil, status.New(codes.Internal,
+			fmt.Sprintf("Invalid response for forwarded publish: %v", err))
+	}
+	if resp.Error != nil {
+		return nil, status.New(codes.Code(resp.Error.Code), resp.Error.Msg)
+	}
+	return resp.Ack, nil
+}
+
+// handlePublishRequest is a NATS handler that's invoked when the leader
+// receives a message forwarded by another server. It publishes the message to
+// the stream and responds with the ack, if requested, in a separate goroutine
+// since waiting for the ack can take a while.
+func (s *stream) handlePublishRequest(msg *nats.Msg) {
+	req := &proto.ForwardedPublishRequest{}
+	if err := req.Unmarshal(msg.Data); err != nil {
+		s.logger.Errorf("Invalid forwarded publish request for stream %s: %v", s, err)
+		return
+	}
+	if req.Message == nil {
+		s.logger.Errorf("Invalid forwarded publish request for stream %s: message is nil", s)
+		return
+	}
+	s.srv.startGoroutine(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(req.Timeout))
+		defer cancel()
+		resp := &proto.ForwardedPublishResponse{}
+		ack, st := s.Publish(ctx, req.Message, req.WaitForAck)
+		if st != nil {
+			resp.Error = &proto.Error{Code: uint32(st.Code()), Msg: st.Message()}
+		} else {
+			resp.Ack = ack
+		}
+		data, err := resp.Marshal()
+		if err != nil {
+			panic(err)
+		}
+		if err := msg.Respond(data); err != nil {
+			s.logger.Errorf("Failed to respond to forwarded publish request: %v", err)
+		}
+	})
+}
+
+// addAckWaiter registers a publisher waiting in-process for the ack of the
+// message published with the given token as its AckInbox and returns the
+// channel the ack is delivered on. Call removeAckWaiter once the publisher
+// stops waiting.
+func (s *stream) addAckWaiter(token string) <-chan *ackResult {
+	ch := make(chan *ackResult, 1)
+	s.ackWaitersMu.Lock()
+	s.ackWaiters[token] = ch
+	s.ackWaitersMu.Unlock()
+	return ch
+}
+
+// removeAckWaiter removes the publisher waiting for the ack of the message
+// published with the given token.
+func (s *stream) removeAckWaiter(token string) {
+	s.ackWaitersMu.Lock()
+	delete(s.ackWaiters, token)
+	s.ackWaitersMu.Unlock()
+}
+
+// deliverAck passes the ack, and the status the message was rejected with if
+// it was, to the publisher waiting in-process on the token in its AckInbox. It
+// returns false if there is no such publisher, in which case the ack should be
+// published to the AckInbox.
+func (s *stream) deliverAck(ack *client.Ack, st *status.Status) bool {
+	s.ackWaitersMu.Lock()
+	ch, ok := s.ackWaiters[ack.AckInbox]
+	s.ackWaitersMu.Unlock()
+	if !ok {
+		return false
+	}
+	select {
+	case ch <- &ackResult{ack: ack, status: st}:
+	default:
+	}
+	return true
+}
+
+// getPublishInbox returns the NATS subject to forward messages published to
+// the stream to.
+func (s *stream) getPublishInbox() string {
+	return fmt.Sprintf("%s.%s.%s.publish",
+		s.srv.config.Clustering.Namespace, s.subjectHash, s.Name)
+}
+
+// marshalEnvelope serializes the message into a message envelope, which is the
+// envelope cookie followed by the message.
+func marshalEnvelope(msg *client.Message) ([]byte, error) {
+	data, err := msg.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, envelopeCookieLen+len(data))
+	copy(buf[0:], envelopeCookie)
+	copy(buf[envelopeCookieLen:], data)
+	return buf, nil
+}
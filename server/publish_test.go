@@ -0,0 +1,219 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	lift "github.com/liftbridge-io/go-liftbridge"
+	client "github.com/liftbridge-io/go-liftbridge/liftbridge-grpc"
+	natsdTest "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// publishDirect publishes a message directly to the given stream through the
+// server at the given address.
+func publishDirect(t *testing.T, addr, subject, name string, msg *client.Message) (
+	*client.Ack, error) {
+
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx,
+		streamSubjectMetadataKey, subject, streamNameMetadataKey, name)
+	resp, err := client.NewAPIClient(conn).Publish(ctx, &client.PublishRequest{Message: msg})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Ack, nil
+}
+
+// Ensure messages published directly to a stream are appended to only that
+// stream without going through NATS and acked in-process.
+func TestPublishDirect(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	c, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer c.Close()
+
+	// Create two streams attached to the same subject.
+	require.NoError(t, c.CreateStream(context.Background(), "foo", "foo"))
+	require.NoError(t, c.CreateStream(context.Background(), "foo", "bar"))
+
+	// Nothing should be published to NATS.
+	nc, err := nats.GetDefaultOptions().Connect()
+	require.NoError(t, err)
+	defer nc.Close()
+	sub, err := nc.SubscribeSync("foo")
+	require.NoError(t, err)
+	require.NoError(t, nc.Flush())
+
+	for i := 0; i < 3; i++ {
+		ack, err := publishDirect(t, "localhost:5050", "foo", "foo", &client.Message{
+			Subject:       "foo",
+			Value:         []byte("hello"),
+			CorrelationId: "cid",
+			AckPolicy:     client.AckPolicy_ALL,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, ack)
+		require.Equal(t, "foo", ack.StreamName)
+		require.Equal(t, int64(i), ack.Offset)
+		require.Equal(t, "cid", ack.CorrelationId)
+	}
+
+	_, err = sub.NextMsg(200 * time.Millisecond)
+	require.Equal(t, nats.ErrTimeout, err)
+	require.Equal(t, int64(2), s1.metadata.GetStream("foo", "foo").log.NewestOffset())
+	require.Equal(t, int64(-1), s1.metadata.GetStream("foo", "bar").log.NewestOffset())
+
+	// Publishing to a stream which doesn't exist fails.
+	_, err = publishDirect(t, "localhost:5050", "foo", "baz", &client.Message{Subject: "foo"})
+	require.Error(t, err)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// Ensure concurrent direct publishes sharing an AckInbox each receive the ack
+// for their own message.
+func TestPublishDirectSharedAckInbox(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server to batch messages so that the publishes are in flight
+	// at the same time.
+	s1Config := getTestConfig("a", true, 5050)
+	s1Config.BatchWaitTime = 100 * time.Millisecond
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	c, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.CreateStream(context.Background(), "foo", "foo"))
+
+	var (
+		num  = 20
+		wg   sync.WaitGroup
+		acks = make(chan *client.Ack, num)
+		errs = make(chan error, num)
+	)
+	for i := 0; i < num; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ack, err := publishDirect(t, "localhost:5050", "foo", "foo", &client.Message{
+				Subject:       "foo",
+				Value:         []byte("hello"),
+				AckInbox:      "shared",
+				CorrelationId: strconv.Itoa(i),
+				AckPolicy:     client.AckPolicy_LEADER,
+			})
+			if err != nil {
+				errs <- err
+				return
+			}
+			if ack.CorrelationId != strconv.Itoa(i) {
+				errs <- fmt.Errorf("expected ack for %d, got %s", i, ack.CorrelationId)
+				return
+			}
+			acks <- ack
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Len(t, acks, num)
+	for i := 0; i < num; i++ {
+		require.Equal(t, "shared", (<-acks).AckInbox)
+	}
+}
+
+// Ensure messages published directly to a stream through a server which isn't
+// the stream leader are forwarded to the leader and acked.
+func TestPublishDirectForwarded(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure servers.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	s2Config := getTestConfig("b", false, 5051)
+	s2 := runServerWithConfig(t, s2Config)
+	defer s2.Stop()
+
+	servers := []*Server{s1, s2}
+	getMetadataLeader(t, 10*time.Second, servers...)
+
+	// Wait for every server to know the metadata leader so that requests can
+	// be forwarded to it.
+	deadline := time.Now().Add(10 * time.Second)
+	for _, s := range servers {
+		for s.getRaft().Leader() == "" {
+			require.True(t, time.Now().Before(deadline), "Metadata leader not known")
+			time.Sleep(15 * time.Millisecond)
+		}
+	}
+
+	c, err := lift.Connect([]string{"localhost:5050", "localhost:5051"})
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.CreateStream(context.Background(), "foo", "foo"))
+	waitForStream(t, 5*time.Second, "foo", "foo", servers...)
+
+	// Publish through the server which isn't the stream leader.
+	leader := getStreamLeader(t, 10*time.Second, "foo", "foo", servers...)
+	addr := "localhost:5050"
+	if leader == s1 {
+		addr = "localhost:5051"
+	}
+	ack, err := publishDirect(t, addr, "foo", "foo", &client.Message{
+		Subject:   "foo",
+		Value:     []byte("hello"),
+		AckPolicy: client.AckPolicy_ALL,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ack)
+	require.Equal(t, int64(0), ack.Offset)
+	require.Equal(t, int64(0), leader.metadata.GetStream("foo", "foo").log.NewestOffset())
+
+	// Messages whose subject doesn't match the stream are rejected.
+	_, err = publishDirect(t, addr, "foo", "foo", &client.Message{Subject: "bar"})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -116,6 +116,11 @@ func (r *raftLogger) Close() error { return nil }
 // an existing cluster, this will attempt to join for up to 30 seconds before
 // giving up and returning an error.
 func (s *Server) setupMetadataRaft() error {
+	if s.config.Clustering.RaftObserver &&
+		(s.config.Clustering.RaftBootstrapSeed || len(s.config.Clustering.RaftBootstrapPeers) > 0) {
+		return errors.New("raft.observer cannot be used with raft.bootstrap.seed or raft.bootstrap.peers")
+	}
+
 	existingState, err := s.createRaftNode()
 	if err != nil {
 		return err
@@ -136,6 +141,7 @@ func (s *Server) setupMetadataRaft() error {
 		req, err := (&proto.RaftJoinRequest{
 			NodeID:   s.config.Clustering.ServerID,
 			NodeAddr: s.config.Clustering.ServerID, // NATS transport uses ID for addr.
+			NonVoter: s.config.Clustering.RaftObserver,
 		}).Marshal()
 		if err != nil {
 			panic(err)
@@ -323,13 +329,20 @@ func (s *Server) createRaftNode() (bool, error) {
 			return
 		}
 
-		// Add the node as a voter. This is idempotent. No-op if the request
-		// came from ourselves.
+		// Add the node as a voter or, if it's an observer, a non-voter. This
+		// is idempotent. No-op if the request came from ourselves.
 		resp := &proto.RaftJoinResponse{}
 		if req.NodeID != s.config.Clustering.ServerID {
-			future := node.AddVoter(
-				raft.ServerID(req.NodeID),
-				raft.ServerAddress(req.NodeAddr), 0, 0)
+			var future raft.IndexFuture
+			if req.NonVoter {
+				future = node.AddNonvoter(
+					raft.ServerID(req.NodeID),
+					raft.ServerAddress(req.NodeAddr), 0, 0)
+			} else {
+				future = node.AddVoter(
+					raft.ServerID(req.NodeID),
+					raft.ServerAddress(req.NodeAddr), 0, 0)
+			}
 			if err := future.Error(); err != nil {
 				resp.Error = err.Error()
 			}
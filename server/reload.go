@@ -0,0 +1,170 @@
+package server
+
+import (
+	"crypto/tls"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// reloadableSettings contains the settings, named by their Config field path,
+// which can be changed without restarting the server.
+var reloadableSettings = map[string]struct{}{
+	"LogLevel":                     {},
+	"TLSKey":                       {},
+	"TLSCert":                      {},
+	"Log.RetentionMaxBytes":        {},
+	"Log.RetentionMaxMessages":     {},
+	"Log.RetentionMaxAge":          {},
+	"Clustering.ReplicaMaxLagTime": {},
+}
+
+// reloadConfig re-parses the configuration file the server was started with
+// and applies any reloadable settings which have changed. Changed settings
+// which require a restart are left as they are and returned as rejected. If
+// TLS is enabled, the certificate is always reloaded so that it can be rotated
+// in place. An error is returned if the configuration can't be loaded, in
+// which case nothing is applied.
+func (s *Server) reloadConfig() (applied, rejected []string, err error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	current := s.config
+	if current.ConfigFile == "" {
+		return nil, nil, errors.New("server was not started with a configuration file")
+	}
+	config, err := NewConfig(current.ConfigFile)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse configuration file")
+	}
+	if current.ReloadOverrides != nil {
+		if err := current.ReloadOverrides(config); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to apply configuration overrides")
+		}
+	}
+	s.normalizeReloadedConfig(config)
+
+	// TLS can't be enabled or disabled on a running API server, only the
+	// certificate can be swapped out.
+	var (
+		tlsEnabled = current.TLSKey != "" && current.TLSCert != ""
+		tlsToggled = tlsEnabled != (config.TLSKey != "" && config.TLSCert != "")
+		cert       tls.Certificate
+	)
+	if tlsEnabled && !tlsToggled {
+		cert, err = tls.LoadX509KeyPair(config.TLSCert, config.TLSKey)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to load TLS certificate")
+		}
+	}
+
+	var retentionChanged, maxLagTimeChanged bool
+	for _, setting := range configChanges(current, config) {
+		_, ok := reloadableSettings[setting]
+		if !ok || (tlsToggled && strings.HasPrefix(setting, "TLS")) {
+			rejected = append(rejected, setting)
+			continue
+		}
+		applied = append(applied, setting)
+		if strings.HasPrefix(setting, "Log.Retention") {
+			retentionChanged = true
+		}
+		if setting == "Clustering.ReplicaMaxLagTime" {
+			maxLagTimeChanged = true
+		}
+	}
+
+	s.configMu.Lock()
+	current.LogLevel = config.LogLevel
+	current.Log.RetentionMaxBytes = config.Log.RetentionMaxBytes
+	current.Log.RetentionMaxMessages = config.Log.RetentionMaxMessages
+	current.Log.RetentionMaxAge = config.Log.RetentionMaxAge
+	current.Clustering.ReplicaMaxLagTime = config.Clustering.ReplicaMaxLagTime
+	if !tlsToggled {
+		current.TLSKey = config.TLSKey
+		current.TLSCert = config.TLSCert
+	}
+	s.configMu.Unlock()
+
+	s.logger.SetLevel(config.LogLevel)
+	if tlsEnabled && !tlsToggled {
+		s.tlsCert.Store(&cert)
+	}
+	if retentionChanged || maxLagTimeChanged {
+		for _, stream := range s.metadata.GetStreams() {
+			if retentionChanged {
+				stream.log.SetRetention(config.Log.RetentionMaxBytes,
+					config.Log.RetentionMaxMessages, config.Log.RetentionMaxAge)
+			}
+			if maxLagTimeChanged {
+				stream.setReplicaMaxLagTime(config.Clustering.ReplicaMaxLagTime)
+			}
+		}
+	}
+
+	s.logger.Infof("Reloaded configuration from %s\n\tApplied: %s",
+		current.ConfigFile, settingsString(applied))
+	if len(rejected) > 0 {
+		s.logger.Warnf("Configuration settings changed which require a restart to take effect: %s",
+			settingsString(rejected))
+	}
+	return applied, rejected, nil
+}
+
+// normalizeReloadedConfig applies the same adjustments to a reloaded
+// configuration that the server makes to its own on startup so that they
+// aren't mistaken for changes.
+func (s *Server) normalizeReloadedConfig(config *Config) {
+	// The server ID is recovered from the persisted server state, so it
+	// can't be changed by the configuration file once the server has started.
+	config.Clustering.ServerID = s.config.Clustering.ServerID
+	if config.DataDir == "" {
+		config.DataDir = defaultDataDir(config.Clustering.Namespace)
+	}
+	config.Clustering.RaftBootstrapPeers = removePeer(
+		config.Clustering.RaftBootstrapPeers, config.Clustering.ServerID)
+	if logRollTime := config.Log.LogRollTime; logRollTime != 0 && logRollTime < time.Second {
+		config.Log.LogRollTime = time.Second
+	}
+}
+
+// configChanges returns the settings, named by their Config field path, which
+// differ between the two configurations.
+func configChanges(old, new *Config) []string {
+	return structChanges("", reflect.ValueOf(*old), reflect.ValueOf(*new))
+}
+
+// structChanges returns the names of the fields which differ between the two
+// struct values, descending into the nested configuration sections.
+func structChanges(prefix string, old, new reflect.Value) []string {
+	var (
+		changes []string
+		pkgPath = reflect.TypeOf(Config{}).PkgPath()
+	)
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+		switch field.Name {
+		case "ConfigFile", "ReloadOverrides":
+			continue
+		}
+		name := prefix + field.Name
+		if field.Type.Kind() == reflect.Struct && field.Type.PkgPath() == pkgPath {
+			changes = append(changes, structChanges(name+".", old.Field(i), new.Field(i))...)
+			continue
+		}
+		if !reflect.DeepEqual(old.Field(i).Interface(), new.Field(i).Interface()) {
+			changes = append(changes, name)
+		}
+	}
+	return changes
+}
+
+// settingsString returns a human-readable list of settings.
+func settingsString(settings []string) string {
+	if len(settings) == 0 {
+		return "none"
+	}
+	return strings.Join(settings, ", ")
+}
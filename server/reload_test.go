@@ -0,0 +1,183 @@
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	lift "github.com/liftbridge-io/go-liftbridge"
+	natsdTest "github.com/nats-io/nats-server/v2/test"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/liftbridge-io/liftbridge/server/proto"
+)
+
+const reloadTestConfig = `
+listen: localhost:5050
+data.dir: %s
+log.level: %s
+
+nats {
+    servers: ["nats://localhost:4222"]
+}
+
+log {
+    segment.max.bytes: 1
+    retention.max.messages: %d
+}
+
+clustering {
+    server.id: a
+    raft.bootstrap.seed: true
+    raft.snapshot.retain: 1
+    replica.max.lag.time: "%s"
+    min.insync.replicas: %d
+}
+`
+
+// writeReloadTestConfig writes a configuration file used for reload tests.
+func writeReloadTestConfig(t *testing.T, file, level string, maxMessages int64,
+	maxLagTime time.Duration, minISR int) {
+
+	data := fmt.Sprintf(reloadTestConfig, filepath.Join(storagePath, "a"), level,
+		maxMessages, maxLagTime, minISR)
+	require.NoError(t, ioutil.WriteFile(file, []byte(data), 0666))
+}
+
+// getReloadTestConfig writes a configuration file and loads a Config from it.
+func getReloadTestConfig(t *testing.T) *Config {
+	require.NoError(t, os.MkdirAll(storagePath, os.ModePerm))
+	file := filepath.Join(storagePath, "liftbridge.conf")
+	writeReloadTestConfig(t, file, "info", 0, 10*time.Second, 1)
+	config, err := NewConfig(file)
+	require.NoError(t, err)
+	config.ReloadOverrides = func(c *Config) error {
+		c.LogSilent = true
+		return nil
+	}
+	require.NoError(t, config.ReloadOverrides(config))
+	return config
+}
+
+func reloadConfig(t *testing.T, addr string) (*proto.ReloadConfigResponse, error) {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	return proto.NewAdminAPIClient(conn).ReloadConfig(
+		context.Background(), &proto.ReloadConfigRequest{})
+}
+
+// Ensure reloading the configuration applies reloadable settings, including to
+// existing streams, and reports changed settings which require a restart.
+func TestReloadConfig(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getReloadTestConfig(t)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	// Wait for server to elect itself leader.
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	client, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Create stream.
+	name := "foo"
+	subject := "foo"
+	err = client.CreateStream(context.Background(), subject, name)
+	require.NoError(t, err)
+
+	// Publish some messages.
+	num := 10
+	for i := 0; i < num; i++ {
+		ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err = client.Publish(ctx, subject, []byte("hello"))
+		require.NoError(t, err)
+	}
+
+	// Change reloadable and non-reloadable settings.
+	writeReloadTestConfig(t, s1Config.ConfigFile, "debug", 5, 5*time.Second, 2)
+
+	resp, err := reloadConfig(t, "localhost:5050")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		"LogLevel", "Log.RetentionMaxMessages", "Clustering.ReplicaMaxLagTime"},
+		resp.Applied)
+	require.Equal(t, []string{"Clustering.MinISR"}, resp.Rejected)
+
+	require.Equal(t, int64(5), s1.getLogConfig().RetentionMaxMessages)
+	require.Equal(t, 5*time.Second, s1.getReplicaMaxLagTime())
+	require.Equal(t, 1, s1.config.Clustering.MinISR)
+
+	// The new retention policy applies to the existing stream.
+	forceLogClean(t, subject, name, s1)
+	require.Equal(t, int64(5), s1.metadata.GetStream(subject, name).log.OldestOffset())
+
+	// Reloading again without changes applies nothing.
+	resp, err = reloadConfig(t, "localhost:5050")
+	require.NoError(t, err)
+	require.Empty(t, resp.Applied)
+	require.Equal(t, []string{"Clustering.MinISR"}, resp.Rejected)
+}
+
+// Ensure reloading an invalid configuration file returns an error and leaves
+// the configuration unchanged.
+func TestReloadConfigInvalid(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getReloadTestConfig(t)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	// Wait for server to elect itself leader.
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	require.NoError(t, ioutil.WriteFile(s1Config.ConfigFile,
+		[]byte("retention.max.messages: 5\n"), 0666))
+
+	_, err := reloadConfig(t, "localhost:5050")
+	require.Error(t, err)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Equal(t, int64(0), s1.getLogConfig().RetentionMaxMessages)
+}
+
+// Ensure reloading the configuration returns an error when the server was not
+// started with a configuration file.
+func TestReloadConfigNoConfigFile(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	// Wait for server to elect itself leader.
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	_, err := reloadConfig(t, "localhost:5050")
+	require.Error(t, err)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
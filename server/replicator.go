@@ -10,6 +10,7 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/liftbridge-io/liftbridge/server/commitlog"
+	"github.com/liftbridge-io/liftbridge/server/logger"
 	"github.com/liftbridge-io/liftbridge/server/proto"
 )
 
@@ -46,6 +47,7 @@ type replicator struct {
 	mu           sync.RWMutex
 	leader       string
 	epoch        uint64
+	logger       logger.Logger
 	headersBuf   [28]byte // scratch buffer for reading message headers
 }
 
@@ -104,7 +106,7 @@ func (r *replicator) start(epoch uint64, stop chan struct{}) {
 
 		reader, err := r.stream.log.NewReader(req.Offset+1, true)
 		if err != nil {
-			r.stream.srv.logger.Errorf(
+			r.logger.WithFields(logger.Fields{logger.FieldOffset: req.Offset + 1}).Errorf(
 				"Failed to create replication reader for stream %s "+
 					"and replica %s (requested offset %d, earliest %d, latest %d): %v",
 				r.stream, r.replica, req.Offset+1, earliest, latest, err)
@@ -126,7 +128,7 @@ func (r *replicator) request(req replicationRequest) {
 	select {
 	case r.requests <- req:
 	default:
-		r.stream.srv.logger.Warnf("Dropped replication request for stream %s from replica %s",
+		r.logger.Warnf("Dropped replication request for stream %s from replica %s",
 			r.stream, req.ReplicaID)
 	}
 }
@@ -136,8 +138,11 @@ func (r *replicator) request(req replicationRequest) {
 // offset for the lag-time duration. If this is the case, the follower is
 // removed from the ISR until it catches back up.
 func (r *replicator) tick(stop chan struct{}) {
-	ticker := time.NewTicker(r.maxLagTime)
-	defer ticker.Stop()
+	r.mu.RLock()
+	maxLagTime := r.maxLagTime
+	r.mu.RUnlock()
+	ticker := time.NewTicker(maxLagTime)
+	defer func() { ticker.Stop() }()
 	var now time.Time
 	for {
 		select {
@@ -150,25 +155,41 @@ func (r *replicator) tick(stop chan struct{}) {
 			lastSeenElapsed     = now.Sub(r.lastSeen)
 			lastCaughtUpElapsed = now.Sub(r.lastCaughtUp)
 		)
+		if r.maxLagTime != maxLagTime {
+			// The max lag time was changed by a configuration reload, so
+			// reset the ticker.
+			maxLagTime = r.maxLagTime
+			ticker.Stop()
+			ticker = time.NewTicker(maxLagTime)
+		}
 		r.mu.RUnlock()
-		outOfSync := lastSeenElapsed > r.maxLagTime || lastCaughtUpElapsed > r.maxLagTime
+		outOfSync := lastSeenElapsed > maxLagTime || lastCaughtUpElapsed > maxLagTime
 		if outOfSync && r.stream.inISR(r.replica) {
 			// Follower has not sent a request or has not caught up in
 			// maxLagTime, so remove it from the ISR.
-			r.stream.srv.logger.Errorf("Replica %s for stream %s exceeded max lag time "+
+			r.logger.Errorf("Replica %s for stream %s exceeded max lag time "+
 				"(last seen: %s, last caught up: %s), removing from ISR",
 				r.replica, r.stream, lastSeenElapsed, lastCaughtUpElapsed)
 
 			r.shrinkISR()
 		} else if !outOfSync && !r.stream.inISR(r.replica) {
 			// Add replica back into ISR.
-			r.stream.srv.logger.Infof("Replica %s for stream %s caught back up with leader, "+
+			r.logger.Infof("Replica %s for stream %s caught back up with leader, "+
 				"rejoining ISR", r.replica, r.stream)
 			r.expandISR()
 		}
 	}
 }
 
+// setMaxLagTime updates the amount of time the replica can go without sending
+// replication requests or catching up to the leader's log before it's removed
+// from the ISR.
+func (r *replicator) setMaxLagTime(maxLagTime time.Duration) {
+	r.mu.Lock()
+	r.maxLagTime = maxLagTime
+	r.mu.Unlock()
+}
+
 // shrinkISR sends a ShrinkISR request to the controller to remove the replica
 // from the ISR.
 func (r *replicator) shrinkISR() {
@@ -180,7 +201,7 @@ func (r *replicator) shrinkISR() {
 		LeaderEpoch:     r.epoch,
 	}
 	if err := r.stream.srv.metadata.ShrinkISR(context.Background(), req); err != nil {
-		r.stream.srv.logger.Errorf(
+		r.logger.Errorf(
 			"Failed to remove replica %s for stream %s from ISR: %v",
 			r.replica, r.stream, err.Err())
 	}
@@ -197,7 +218,7 @@ func (r *replicator) expandISR() {
 		LeaderEpoch:  r.epoch,
 	}
 	if err := r.stream.srv.metadata.ExpandISR(context.Background(), req); err != nil {
-		r.stream.srv.logger.Errorf(
+		r.logger.Errorf(
 			"Failed to add replica %s for stream %s to ISR: %v",
 			r.replica, r.stream, err.Err())
 	}
@@ -211,13 +232,13 @@ func (r *replicator) replicate(
 	buf := new(bytes.Buffer)
 	// Write the leader epoch to the buffer.
 	if err := binary.Write(buf, proto.Encoding, r.epoch); err != nil {
-		r.stream.srv.logger.Errorf("Failed to write leader epoch to buffer while replicating: %v", err)
+		r.logger.Errorf("Failed to write leader epoch to buffer while replicating: %v", err)
 		return err
 	}
 	// Reserve space for the HW. This will be replaced with the HW at the time
 	// of flush.
 	if err := binary.Write(buf, proto.Encoding, int64(0)); err != nil {
-		r.stream.srv.logger.Errorf("Failed to write HW to buffer while replicating: %v", err)
+		r.logger.Errorf("Failed to write HW to buffer while replicating: %v", err)
 		return err
 	}
 
@@ -229,7 +250,7 @@ func (r *replicator) replicate(
 	for offset < newestOffset && buf.Len() < replicationMaxSize {
 		message, offset, _, _, err = reader.ReadMessage(ctx, r.headersBuf[:])
 		if err != nil {
-			r.stream.srv.logger.Errorf("Failed to read message while replicating: %v", err)
+			r.logger.Errorf("Failed to read message while replicating: %v", err)
 			return err
 		}
 
@@ -241,7 +262,7 @@ func (r *replicator) replicate(
 
 		// Write the message to the buffer.
 		if err := writeMessageToBuffer(buf, r.headersBuf[:], message); err != nil {
-			r.stream.srv.logger.Errorf("Failed to write message to buffer while replicating: %v", err)
+			r.logger.Errorf("Failed to write message to buffer while replicating: %v", err)
 			return err
 		}
 	}
@@ -8,10 +8,15 @@ import (
 	lift "github.com/liftbridge-io/go-liftbridge"
 	"github.com/liftbridge-io/go-liftbridge/liftbridge-grpc"
 	natsdTest "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/liftbridge-io/liftbridge/server/commitlog"
+	serverProto "github.com/liftbridge-io/liftbridge/server/proto"
 )
 
 func waitForHW(t *testing.T, timeout time.Duration, subject, name string, hw int64, servers ...*Server) {
@@ -338,6 +343,110 @@ func TestAckPolicyLeader(t *testing.T) {
 	require.Equal(t, cid, ack.CorrelationId)
 }
 
+// Ensure messages with AckPolicy_ALL are rejected with a ResourceExhausted status
+// code instead of waiting for the ack to time out while the ISR is below the
+// minimum ISR size.
+func TestAckPolicyAllNotEnoughReplicas(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure servers.
+	configs := []*Config{
+		getTestConfig("a", true, 5050),
+		getTestConfig("b", false, 5051),
+		getTestConfig("c", false, 5052),
+	}
+	servers := make([]*Server, len(configs))
+	for i, config := range configs {
+		config.Clustering.MinISR = 3
+		config.Clustering.ReplicaMaxLagTime = time.Second
+		servers[i] = runServerWithConfig(t, config)
+		defer servers[i].Stop()
+	}
+	getMetadataLeader(t, 10*time.Second, servers...)
+
+	client, err := lift.Connect([]string{"localhost:5050", "localhost:5051", "localhost:5052"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Create stream.
+	name := "foo"
+	subject := "foo"
+	err = client.CreateStream(context.Background(), subject, name,
+		lift.ReplicationFactor(3))
+	require.NoError(t, err)
+
+	// Publish a message while the ISR is fully replicated.
+	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
+	_, err = client.Publish(ctx, subject, []byte("hello"), lift.AckPolicyAll())
+	require.NoError(t, err)
+
+	// Kill a stream follower and wait for the ISR to shrink.
+	leader := getStreamLeader(t, 10*time.Second, subject, name, servers...)
+	for i, server := range servers {
+		if server != leader {
+			server.Stop()
+			servers = append(servers[:i], servers[i+1:]...)
+			break
+		}
+	}
+	waitForISR(t, 10*time.Second, subject, name, 2, servers...)
+
+	// Publish a message with AckPolicy_ALL. This should be rejected
+	// immediately since the ISR is below the minimum size.
+	ctx, _ = context.WithTimeout(context.Background(), 5*time.Second)
+	start := time.Now()
+	_, err = client.Publish(ctx, subject, []byte("world"), lift.AckPolicyAll())
+	require.Error(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.True(t, time.Since(start) < 2*time.Second)
+
+	// Messages published over NATS are rejected with a nack which decodes as a
+	// normal ack extended with the error.
+	nc, err := nats.Connect(nats.DefaultURL)
+	require.NoError(t, err)
+	defer nc.Close()
+	inbox := nats.NewInbox()
+	sub, err := nc.SubscribeSync(inbox)
+	require.NoError(t, err)
+	data, err := marshalEnvelope(&proto.Message{
+		Subject:   subject,
+		Value:     []byte("world"),
+		AckInbox:  inbox,
+		AckPolicy: proto.AckPolicy_ALL,
+	})
+	require.NoError(t, err)
+	require.NoError(t, nc.Publish(subject, data))
+	nackMsg, err := sub.NextMsg(5 * time.Second)
+	require.NoError(t, err)
+	nack := new(proto.Ack)
+	require.NoError(t, nack.Unmarshal(nackMsg.Data))
+	require.Equal(t, int64(-1), nack.Offset)
+	require.Equal(t, inbox, nack.AckInbox)
+	ext := new(serverProto.AckExtension)
+	require.NoError(t, ext.Unmarshal(nackMsg.Data))
+	require.NotNil(t, ext.Error)
+	require.Equal(t, serverProto.AckErrorCode_NOT_ENOUGH_REPLICAS, ext.Error.Code)
+	_, st, err := decodeAck(nackMsg.Data)
+	require.NoError(t, err)
+	require.NotNil(t, st)
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+
+	// The rejected messages aren't written to the log.
+	stream := leader.metadata.GetStream(subject, name)
+	require.NotNil(t, stream)
+	require.Equal(t, int64(0), stream.log.NewestOffset())
+
+	// Messages with AckPolicy_LEADER are still accepted.
+	ctx, _ = context.WithTimeout(context.Background(), 5*time.Second)
+	ack, err := client.Publish(ctx, subject, []byte("world"))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), ack.Offset)
+}
+
 // Ensure messages in the log still get committed after the leader is
 // restarted.
 func TestCommitOnRestart(t *testing.T) {
@@ -765,3 +874,120 @@ func TestTruncatePreventReplicaDivergence(t *testing.T) {
 		require.Equal(t, []byte("moon"), msg.Value())
 	}
 }
+
+// Ensure an out-of-sync replica is elected leader once a stream which allows
+// unclean leader elections has had no ISR candidates for the timeout and that
+// the old leader truncates the committed messages the new leader doesn't have.
+func TestUncleanLeaderElection(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure servers so that the ISR shrinks before the stream follower
+	// reports the leader.
+	configs := []*Config{
+		getTestConfig("a", true, 5050),
+		getTestConfig("b", false, 5051),
+		getTestConfig("c", false, 5052),
+	}
+	servers := make([]*Server, len(configs))
+	for i, config := range configs {
+		config.Clustering.MinISR = 1
+		config.Clustering.ReplicaMaxLagTime = time.Second
+		config.Clustering.ReplicaMaxLeaderTimeout = 3 * time.Second
+		config.Clustering.ReplicaFetchTimeout = 500 * time.Millisecond
+		servers[i] = runServerWithConfig(t, config)
+		defer servers[i].Stop()
+	}
+	metadataLeader := getMetadataLeader(t, 10*time.Second, servers...)
+
+	// Wait for every server to know the metadata leader so that requests can
+	// be forwarded to it.
+	deadline := time.Now().Add(10 * time.Second)
+	for _, s := range servers {
+		for s.getRaft().Leader() == "" {
+			require.True(t, time.Now().Before(deadline), "Metadata leader not known")
+			time.Sleep(15 * time.Millisecond)
+		}
+	}
+
+	client, err := lift.Connect([]string{"localhost:5050", "localhost:5051", "localhost:5052"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Create stream which allows unclean leader elections.
+	name := "foo"
+	subject := "foo"
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		uncleanLeaderElectionTimeoutMetadataKey, "2s")
+	err = client.CreateStream(ctx, subject, name, lift.ReplicationFactor(2))
+	require.NoError(t, err)
+
+	// Publish a message.
+	ctx, _ = context.WithTimeout(context.Background(), 5*time.Second)
+	_, err = client.Publish(ctx, subject, []byte("hello"), lift.AckPolicyAll())
+	require.NoError(t, err)
+
+	// Find the stream follower.
+	leader := getStreamLeader(t, 10*time.Second, subject, name, servers...)
+	stream := leader.metadata.GetStream(subject, name)
+	require.NotNil(t, stream)
+	var follower *Server
+	for _, replica := range stream.GetReplicas() {
+		for _, s := range servers {
+			if s != leader && s.config.Clustering.ServerID == replica {
+				follower = s
+			}
+		}
+	}
+	require.NotNil(t, follower)
+	waitForHW(t, 5*time.Second, subject, name, 0, leader, follower)
+
+	// Stop replication on the leader so the follower falls out of the ISR.
+	stream.pauseReplication()
+	waitForISR(t, 10*time.Second, subject, name, 1, leader, follower)
+
+	// Publish a message which is committed by the leader alone.
+	ctx, _ = context.WithTimeout(context.Background(), 5*time.Second)
+	_, err = client.Publish(ctx, subject, []byte("lost"), lift.AckPolicyAll())
+	require.NoError(t, err)
+	waitForHW(t, 5*time.Second, subject, name, 1, leader)
+
+	// The follower is elected leader once it reports the leader and there
+	// have been no ISR candidates for the timeout.
+	getStreamLeader(t, 15*time.Second, subject, name, follower)
+
+	// The old leader truncates the message the new leader doesn't have and
+	// rejoins the ISR.
+	waitForISR(t, 10*time.Second, subject, name, 2, leader, follower)
+	ctx, _ = context.WithTimeout(context.Background(), 5*time.Second)
+	_, err = client.Publish(ctx, subject, []byte("world"), lift.AckPolicyAll())
+	require.NoError(t, err)
+
+	for _, s := range []*Server{leader, follower} {
+		stream := s.metadata.GetStream(subject, name)
+		require.NotNil(t, stream)
+		require.Equal(t, int64(1), stream.log.NewestOffset())
+
+		reader, err := stream.log.NewReader(1, true)
+		require.NoError(t, err)
+		headersBuf := make([]byte, 28)
+		msg, offset, _, _, err := reader.ReadMessage(context.Background(), headersBuf)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), offset)
+		require.Equal(t, []byte("world"), msg.Value())
+	}
+
+	// The unclean leader election is counted.
+	elections := 0
+	for _, interval := range metadataLeader.metricsSink.Data() {
+		interval.RLock()
+		if counter, ok := interval.Counters["liftbridge.stream.unclean.leader.elections;subject=foo;name=foo"]; ok {
+			elections += counter.Count
+		}
+		interval.RUnlock()
+	}
+	require.Equal(t, 1, elections)
+}
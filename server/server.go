@@ -1,11 +1,13 @@
 package server
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"math/rand"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -13,16 +15,21 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/armon/go-metrics"
+	"github.com/dustin/go-humanize/english"
 	"github.com/hashicorp/raft"
 	client "github.com/liftbridge-io/go-liftbridge/liftbridge-grpc"
+	natsd "github.com/nats-io/nats-server/v2/server"
 	"github.com/nats-io/nats.go"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/liftbridge-io/liftbridge/server/commitlog"
 	"github.com/liftbridge-io/liftbridge/server/logger"
 	"github.com/liftbridge-io/liftbridge/server/proto"
+	"github.com/liftbridge-io/liftbridge/server/tracing"
 )
 
 const (
@@ -35,16 +42,26 @@ const (
 // RunServerWithConfig.
 type Server struct {
 	config             *Config
+	configMu           sync.RWMutex
+	reloadMu           sync.Mutex
+	tlsCert            atomic.Value
 	listener           net.Listener
 	nc                 *nats.Conn
 	ncRaft             *nats.Conn
 	ncRepl             *nats.Conn
 	ncAcks             *nats.Conn
 	ncPublishes        *nats.Conn
+	natsServer         *natsd.Server
 	logger             logger.Logger
 	loggerOut          io.Writer
+	logFile            *logger.FileWriter
+	tracer             *tracing.Tracer
+	metrics            *metrics.Metrics
+	metricsSink        *metrics.InmemSink
+	metricsServer      *http.Server
 	api                *grpc.Server
 	metadata           *metadataAPI
+	cleanerPool        *commitlog.CleanerPool
 	shutdownCh         chan struct{}
 	raft               atomic.Value
 	leaderSub          *nats.Subscription
@@ -69,17 +86,14 @@ func RunServerWithConfig(config *Config) (*Server, error) {
 func New(config *Config) *Server {
 	// Default data path to /tmp/liftbridge/<namespace> if not set.
 	if config.DataDir == "" {
-		config.DataDir = filepath.Join("/tmp", "liftbridge", config.Clustering.Namespace)
-	}
-	logger := logger.NewLogger(config.LogLevel)
-	if config.LogSilent {
-		logger.SetWriter(ioutil.Discard)
+		config.DataDir = defaultDataDir(config.Clustering.Namespace)
 	}
 	s := &Server{
 		config:     config,
-		logger:     logger,
 		shutdownCh: make(chan struct{}),
 	}
+	s.setupLogger()
+	s.setupMetrics()
 	s.metadata = newMetadataAPI(s)
 	return s
 }
@@ -96,26 +110,33 @@ func (s *Server) Start() (err error) {
 	rand.Seed(time.Now().UnixNano())
 
 	// Remove server's ID from the cluster peers list if present.
-	if len(s.config.Clustering.RaftBootstrapPeers) > 0 {
-		peers := make([]string, 0, len(s.config.Clustering.RaftBootstrapPeers))
-		for _, peer := range s.config.Clustering.RaftBootstrapPeers {
-			if peer != s.config.Clustering.ServerID {
-				peers = append(peers, peer)
-			}
-		}
-		s.config.Clustering.RaftBootstrapPeers = peers
-	}
+	s.config.Clustering.RaftBootstrapPeers = removePeer(
+		s.config.Clustering.RaftBootstrapPeers, s.config.Clustering.ServerID)
 
 	// Create the data directory if it doesn't exist.
 	if err := os.MkdirAll(s.config.DataDir, os.ModePerm); err != nil {
 		return errors.Wrap(err, "failed to create data path directories")
 	}
 
+	if err := s.setupTracer(); err != nil {
+		return errors.Wrap(err, "failed to setup tracing")
+	}
+
+	if err := s.startMetricsServer(); err != nil {
+		return errors.Wrap(err, "failed to start metrics server")
+	}
+
 	// Recover and persist metadata state.
 	if err := s.recoverAndPersistState(); err != nil {
 		return errors.Wrap(err, "failed to recover or persist metadata state")
 	}
 
+	if s.config.EmbeddedNATS.Enabled {
+		if err := s.startEmbeddedNATS(); err != nil {
+			return err
+		}
+	}
+
 	if err := s.createNATSConns(); err != nil {
 		return errors.Wrap(err, "failed to connect to NATS")
 	}
@@ -142,6 +163,12 @@ func (s *Server) Start() (err error) {
 		s.config.Log.LogRollTime = time.Second
 	}
 
+	s.cleanerPool = commitlog.NewCleanerPool(commitlog.CleanerPoolOptions{
+		Logger:              s.logger,
+		Threads:             s.config.Log.CleanerThreads,
+		IOMaxBytesPerSecond: s.config.Log.CleanerIOMaxBytes,
+	})
+
 	if err := s.startMetadataRaft(); err != nil {
 		return errors.Wrap(err, "failed to start Raft node")
 	}
@@ -154,6 +181,8 @@ func (s *Server) Start() (err error) {
 		return errors.Wrap(err, "failed to subscribe to stream status subject")
 	}
 
+	s.startGoroutine(s.monitorConsumerLag)
+
 	s.handleSignals()
 
 	return errors.Wrap(s.startAPIServer(), "failed to start API server")
@@ -162,6 +191,10 @@ func (s *Server) Start() (err error) {
 // Stop will attempt to gracefully shut the Server down by signaling the stop
 // and waiting for all goroutines to return.
 func (s *Server) Stop() error {
+	if s.config.Clustering.ControlledShutdown && s.IsRunning() {
+		s.controlledShutdown()
+	}
+
 	s.mu.Lock()
 	if s.shutdown {
 		s.mu.Unlock()
@@ -178,6 +211,10 @@ func (s *Server) Stop() error {
 		s.listener.Close()
 	}
 
+	if s.metricsServer != nil {
+		s.metricsServer.Close()
+	}
+
 	if s.metadata != nil {
 		if err := s.metadata.Reset(); err != nil {
 			s.mu.Unlock()
@@ -192,7 +229,15 @@ func (s *Server) Stop() error {
 		}
 	}
 
+	// Stop the cleaner pool once the stream logs are closed.
+	if s.cleanerPool != nil {
+		s.cleanerPool.Close()
+	}
+
 	s.closeNATSConns()
+	if s.natsServer != nil {
+		s.natsServer.Shutdown()
+	}
 	s.running = false
 	s.shutdown = true
 	s.mu.Unlock()
@@ -200,6 +245,164 @@ func (s *Server) Stop() error {
 	// Wait for goroutines to stop.
 	s.goroutineWait.Wait()
 
+	if err := s.tracer.Close(); err != nil {
+		return err
+	}
+	if s.logFile != nil {
+		return s.logFile.Close()
+	}
+	return nil
+}
+
+// controlledShutdown hands off leadership of the streams this server leads to
+// other ISR members and waits for the handoffs to complete so that publishers
+// don't have to wait for followers to detect the leader failure. It then
+// leaves the ISR of the streams it follows and, if it's the metadata leader,
+// transfers metadata leadership to another voter. This gives up once the
+// controlled shutdown timeout elapses.
+func (s *Server) controlledShutdown() {
+	s.logger.Info("Starting controlled shutdown...")
+	ctx, cancel := context.WithTimeout(context.Background(),
+		s.config.Clustering.ControlledShutdownTimeout)
+	defer cancel()
+
+	handedOff := []*stream{}
+	for _, stream := range s.metadata.GetStreams() {
+		leader, epoch := stream.GetLeader()
+		if leader != s.config.Clustering.ServerID {
+			continue
+		}
+		req := &proto.HandOffLeaderOp{
+			Subject:     stream.Subject,
+			Name:        stream.Name,
+			Leader:      leader,
+			LeaderEpoch: epoch,
+		}
+		if err := s.metadata.HandOffLeader(ctx, req); err != nil {
+			s.logger.Warnf("Failed to hand off leadership of stream %s: %v", stream, err.Message())
+			continue
+		}
+		handedOff = append(handedOff, stream)
+	}
+
+	// Wait for the leadership changes to be applied to this server's FSM.
+	for _, stream := range handedOff {
+		for {
+			if leader, _ := stream.GetLeader(); leader != s.config.Clustering.ServerID {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				s.logger.Warn("Controlled shutdown timed out waiting for stream leadership handoff")
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}
+	s.logger.Infof("Handed off leadership of %s", english.Plural(len(handedOff), "stream", ""))
+
+	// Leave the ISR of the streams this server follows so their leaders can
+	// commit messages without waiting for it to fall behind. Replication is
+	// stopped first so the leaders don't add it back to the ISR.
+	left := 0
+	for _, stream := range s.metadata.GetStreams() {
+		leader, epoch := stream.GetLeader()
+		if leader == s.config.Clustering.ServerID || !stream.inISR(s.config.Clustering.ServerID) {
+			continue
+		}
+		if err := stream.StopFollowing(); err != nil {
+			s.logger.Warnf("Failed to stop following stream %s: %v", stream, err)
+			continue
+		}
+		req := &proto.ShrinkISROp{
+			Subject:         stream.Subject,
+			Name:            stream.Name,
+			ReplicaToRemove: s.config.Clustering.ServerID,
+			Leader:          leader,
+			LeaderEpoch:     epoch,
+		}
+		if err := s.metadata.ShrinkISR(ctx, req); err != nil {
+			s.logger.Warnf("Failed to leave ISR for stream %s: %v", stream, err.Message())
+			continue
+		}
+		left++
+	}
+	s.logger.Infof("Left ISR for %s", english.Plural(left, "stream", ""))
+
+	if s.IsLeader() && s.hasOtherVoters() {
+		if err := s.getRaft().LeadershipTransfer().Error(); err != nil {
+			s.logger.Warnf("Failed to transfer metadata leadership: %v", err)
+		} else {
+			s.logger.Info("Transferred metadata leadership")
+		}
+	}
+}
+
+// hasOtherVoters indicates if there are voters in the metadata Raft group
+// other than this server.
+func (s *Server) hasOtherVoters() bool {
+	servers, _, err := s.metadata.GetPeers()
+	if err != nil {
+		return false
+	}
+	for _, server := range servers {
+		if server.Suffrage == raft.Voter && string(server.ID) != s.config.Clustering.ServerID {
+			return true
+		}
+	}
+	return false
+}
+
+// setupLogger creates the server's Logger using the configured format and
+// output. If a log file is configured, logs are written to it instead of
+// stdout and it's rotated based on the configured size and time limits.
+func (s *Server) setupLogger() {
+	if s.config.LogFormat == logFormatJSON {
+		s.logger = logger.NewJSONLogger(s.config.LogLevel)
+	} else {
+		s.logger = logger.NewLogger(s.config.LogLevel)
+	}
+	switch {
+	case s.config.LogSilent:
+		s.logger.SetWriter(ioutil.Discard)
+	case s.config.LogFile != "":
+		s.logFile = logger.NewFileWriter(logger.FileWriterOptions{
+			Path:       s.config.LogFile,
+			MaxSize:    s.config.LogFileMaxSize,
+			RollTime:   s.config.LogFileRollTime,
+			MaxBackups: s.config.LogFileMaxBackups,
+		})
+		s.logger.SetWriter(s.logFile)
+	}
+}
+
+// setupTracer creates the Tracer used to trace messages through the server if
+// a trace exporter is configured. Otherwise tracing is disabled.
+func (s *Server) setupTracer() error {
+	var exporter tracing.Exporter
+	switch name := s.config.Tracing.Exporter; name {
+	case "":
+		return nil
+	case traceExporterStdout:
+		exporter = tracing.NewWriterExporter(os.Stdout)
+	case traceExporterFile:
+		if s.config.Tracing.File == "" {
+			return errors.New("tracing.file must be set to use the file exporter")
+		}
+		fileExporter, err := tracing.NewFileExporter(s.config.Tracing.File)
+		if err != nil {
+			return err
+		}
+		exporter = fileExporter
+	default:
+		exporter = tracing.GetExporter(name)
+		if exporter == nil {
+			return fmt.Errorf("unknown trace exporter %q", name)
+		}
+	}
+	s.tracer = tracing.NewTracer(exporter, func(err error) {
+		s.logger.Warnf("Failed to export trace span: %v", err)
+	})
 	return nil
 }
 
@@ -308,18 +511,23 @@ func (s *Server) closeNATSConns() {
 func (s *Server) startAPIServer() error {
 	opts := []grpc.ServerOption{}
 
-	// Setup TLS if key/cert is set.
+	// Setup TLS if key/cert is set. The certificate is looked up on each
+	// handshake so that it can be swapped out by a configuration reload.
 	if s.config.TLSKey != "" && s.config.TLSCert != "" {
-		creds, err := credentials.NewServerTLSFromFile(s.config.TLSCert, s.config.TLSKey)
+		cert, err := tls.LoadX509KeyPair(s.config.TLSCert, s.config.TLSKey)
 		if err != nil {
 			return errors.Wrap(err, "failed to setup TLS credentials")
 		}
+		s.tlsCert.Store(&cert)
+		creds := credentials.NewTLS(&tls.Config{GetCertificate: s.getTLSCertificate})
 		opts = append(opts, grpc.Creds(creds))
 	}
 
 	api := grpc.NewServer(opts...)
 	s.api = api
 	client.RegisterAPIServer(api, &apiServer{s})
+	proto.RegisterAdminAPIServer(api, &adminServer{s})
+	proto.RegisterStreamingAPIServer(api, &streamingServer{s})
 	s.mu.Lock()
 	s.running = true
 	s.mu.Unlock()
@@ -341,12 +549,43 @@ func (s *Server) startAPIServer() error {
 	return nil
 }
 
+// getTLSCertificate returns the certificate used by the API server for TLS
+// connections.
+func (s *Server) getTLSCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return s.tlsCert.Load().(*tls.Certificate), nil
+}
+
+// getLogConfig returns the stream log settings. Retention settings can be
+// changed by a configuration reload, so this should be used rather than
+// accessing the config directly.
+func (s *Server) getLogConfig() LogConfig {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	return s.config.Log
+}
+
+// getReplicaMaxLagTime returns the amount of time a replica can lag behind the
+// leader before being removed from the ISR. This can be changed by a
+// configuration reload, so this should be used rather than accessing the
+// config directly.
+func (s *Server) getReplicaMaxLagTime() time.Duration {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	return s.config.Clustering.ReplicaMaxLagTime
+}
+
 // createNATSConn creates a new NATS connection with the given name.
 func (s *Server) createNATSConn(name string) (*nats.Conn, error) {
 	var err error
 	opts := s.config.NATS
 	opts.Name = fmt.Sprintf("LIFT.%s.%s.%s", s.config.Clustering.Namespace, s.config.Clustering.ServerID, name)
 
+	// Connect to the embedded NATS server if there is one. When clustered,
+	// it routes messages to and from the rest of the NATS cluster.
+	if s.natsServer != nil {
+		opts.Servers = []string{s.embeddedNATSURL()}
+	}
+
 	// Shorten the time we wait to reconnect. Don't make it too short because
 	// it may exhaust the number of available FDs.
 	opts.ReconnectWait = 250 * time.Millisecond
@@ -497,7 +736,16 @@ func (s *Server) handlePropagatedRequest(m *nats.Msg) {
 			Op:               req.Op,
 			CreateStreamResp: &client.CreateStreamResponse{},
 		}
-		if err := s.metadata.CreateStream(context.Background(), req.CreateStreamOp); err != nil {
+		stream := req.CreateStream
+		if stream == nil {
+			stream = &proto.Stream{
+				Subject:           req.CreateStreamOp.Subject,
+				Name:              req.CreateStreamOp.Name,
+				Group:             req.CreateStreamOp.Group,
+				ReplicationFactor: req.CreateStreamOp.ReplicationFactor,
+			}
+		}
+		if err := s.metadata.CreateStream(context.Background(), stream); err != nil {
 			resp.Error = &proto.Error{Code: uint32(err.Code()), Msg: err.Message()}
 		}
 		data, err = resp.Marshal()
@@ -537,6 +785,74 @@ func (s *Server) handlePropagatedRequest(m *nats.Msg) {
 		if err != nil {
 			panic(err)
 		}
+	case proto.Op_PURGE_STREAM:
+		resp := &proto.PropagatedResponse{
+			Op: req.Op,
+		}
+		if err := s.metadata.PurgeStream(context.Background(), req.PurgeStreamOp); err != nil {
+			resp.Error = &proto.Error{Code: uint32(err.Code()), Msg: err.Message()}
+		}
+		data, err = resp.Marshal()
+		if err != nil {
+			panic(err)
+		}
+	case proto.Op_HAND_OFF_LEADER:
+		resp := &proto.PropagatedResponse{
+			Op: req.Op,
+		}
+		if err := s.metadata.HandOffLeader(context.Background(), req.HandOffLeaderOp); err != nil {
+			resp.Error = &proto.Error{Code: uint32(err.Code()), Msg: err.Message()}
+		}
+		data, err = resp.Marshal()
+		if err != nil {
+			panic(err)
+		}
+	case proto.Op_CORDON_SERVER:
+		resp := &proto.PropagatedResponse{
+			Op: req.Op,
+		}
+		if err := s.metadata.CordonServer(context.Background(), req.CordonServerOp); err != nil {
+			resp.Error = &proto.Error{Code: uint32(err.Code()), Msg: err.Message()}
+		}
+		data, err = resp.Marshal()
+		if err != nil {
+			panic(err)
+		}
+	case proto.Op_REMOVE_SERVER:
+		resp := &proto.PropagatedResponse{
+			Op: req.Op,
+		}
+		removeResp, st := s.metadata.RemoveServer(context.Background(), req.RemoveServerOp)
+		if st != nil {
+			resp.Error = &proto.Error{Code: uint32(st.Code()), Msg: st.Message()}
+		}
+		resp.RemoveServerResp = removeResp
+		data, err = resp.Marshal()
+		if err != nil {
+			panic(err)
+		}
+	case proto.Op_ADD_NON_VOTER:
+		resp := &proto.PropagatedResponse{
+			Op: req.Op,
+		}
+		if err := s.metadata.AddNonVoter(context.Background(), req.AddNonVoterOp); err != nil {
+			resp.Error = &proto.Error{Code: uint32(err.Code()), Msg: err.Message()}
+		}
+		data, err = resp.Marshal()
+		if err != nil {
+			panic(err)
+		}
+	case proto.Op_PROMOTE_SERVER:
+		resp := &proto.PropagatedResponse{
+			Op: req.Op,
+		}
+		if err := s.metadata.PromoteServer(context.Background(), req.PromoteServerOp); err != nil {
+			resp.Error = &proto.Error{Code: uint32(err.Code()), Msg: err.Message()}
+		}
+		data, err = resp.Marshal()
+		if err != nil {
+			panic(err)
+		}
 	default:
 		s.logger.Warnf("Unknown propagated request operation: %s", req.Op)
 		return
@@ -678,3 +994,23 @@ func (s *Server) startGoroutine(f func()) {
 		s.goroutineWait.Done()
 	}()
 }
+
+// defaultDataDir returns the path data is stored in if one is not set, which
+// is /tmp/liftbridge/<namespace>.
+func defaultDataDir(namespace string) string {
+	return filepath.Join("/tmp", "liftbridge", namespace)
+}
+
+// removePeer returns the given list of peers with the given server ID removed.
+func removePeer(peers []string, id string) []string {
+	if len(peers) == 0 {
+		return peers
+	}
+	filtered := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		if peer != id {
+			filtered = append(filtered, peer)
+		}
+	}
+	return filtered
+}
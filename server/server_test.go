@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -15,6 +16,9 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/liftbridge-io/liftbridge/server/commitlog"
 )
 
 var storagePath string
@@ -408,6 +412,130 @@ func TestBootstrapMisconfiguration(t *testing.T) {
 	}
 }
 
+// Ensure servers configured as observers join the metadata Raft group as
+// non-voters which host stream replicas but don't count toward the quorum.
+func TestRaftObserver(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// An observer cannot bootstrap the cluster.
+	config := getTestConfig("z", true, 0)
+	config.Clustering.RaftObserver = true
+	_, err := RunServerWithConfig(config)
+	require.Error(t, err)
+
+	// Configure servers.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+	getMetadataLeader(t, 10*time.Second, s1)
+	s2Config := getTestConfig("b", false, 5051)
+	s2Config.Clustering.RaftObserver = true
+	s2 := runServerWithConfig(t, s2Config)
+	defer s2.Stop()
+	s3Config := getTestConfig("c", false, 5052)
+	s3Config.Clustering.RaftObserver = true
+	s3 := runServerWithConfig(t, s3Config)
+	defer s3.Stop()
+
+	servers := []*Server{s1, s2, s3}
+	require.Equal(t, s1, getMetadataLeader(t, 10*time.Second, servers...))
+	addr := fmt.Sprintf("localhost:%d", s1.config.Port)
+
+	peers := listPeers(t, addr)
+	require.Len(t, peers, 3)
+	require.True(t, peers[0].Voter)
+	require.False(t, peers[1].Voter)
+	require.False(t, peers[2].Voter)
+
+	// Observers host stream replicas.
+	client, err := lift.Connect([]string{addr})
+	require.NoError(t, err)
+	defer client.Close()
+
+	name := "foo"
+	subject := "foo"
+	err = client.CreateStream(context.Background(), subject, name,
+		lift.ReplicationFactor(3))
+	require.NoError(t, err)
+
+	_, err = client.Publish(context.Background(), subject, []byte("hello"), lift.AckPolicyAll())
+	require.NoError(t, err)
+	waitForHW(t, 5*time.Second, subject, name, 0, servers...)
+
+	// The metadata leader still commits changes without the observers.
+	s2.Stop()
+	s3.Stop()
+	require.NoError(t, s1.getRaft().Barrier(5*time.Second).Error())
+	require.True(t, s1.IsLeader())
+}
+
+// Ensure a controlled shutdown hands off leadership of the server's streams
+// and leaves their ISRs so that publishes continue without waiting for the
+// followers to detect the failure.
+func TestControlledShutdown(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure servers with failure detection slower than the test timeouts.
+	var servers []*Server
+	for i, id := range []string{"a", "b", "c"} {
+		config := getTestConfig(id, i == 0, 5050+i)
+		config.Clustering.ReplicaMaxLagTime = time.Minute
+		config.Clustering.ReplicaMaxLeaderTimeout = time.Minute
+		config.Clustering.ControlledShutdown = true
+		s := runServerWithConfig(t, config)
+		defer s.Stop()
+		servers = append(servers, s)
+	}
+	getMetadataLeader(t, 10*time.Second, servers...)
+
+	// Wait for every server to know the metadata leader so that stream
+	// leaders can reach it.
+	deadline := time.Now().Add(10 * time.Second)
+	for _, s := range servers {
+		for s.getRaft().Leader() == "" {
+			require.True(t, time.Now().Before(deadline), "Metadata leader not known")
+			time.Sleep(15 * time.Millisecond)
+		}
+	}
+
+	name := "foo"
+	subject := "foo"
+	client, err := lift.Connect([]string{"localhost:5050", "localhost:5051", "localhost:5052"})
+	require.NoError(t, err)
+	defer client.Close()
+	err = client.CreateStream(context.Background(), subject, name,
+		lift.ReplicationFactor(3))
+	require.NoError(t, err)
+	leader := getStreamLeader(t, 10*time.Second, subject, name, servers...)
+
+	// Stop the stream leader.
+	require.NoError(t, leader.Stop())
+	var remaining []*Server
+	for _, s := range servers {
+		if s != leader {
+			remaining = append(remaining, s)
+		}
+	}
+
+	// Another server takes over right away and commits without the stopped
+	// server.
+	getStreamLeader(t, 2*time.Second, subject, name, remaining...)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = client.Publish(ctx, subject, []byte("hello"), lift.AckPolicyAll())
+	require.NoError(t, err)
+	waitForHW(t, 5*time.Second, subject, name, 0, remaining...)
+	getMetadataLeader(t, 5*time.Second, remaining...)
+}
+
 // Ensure when the metadata leader fails, a new one is elected.
 func TestMetadataLeaderFailover(t *testing.T) {
 	defer cleanupStorage(t)
@@ -1074,6 +1202,93 @@ func TestSubscribeStartTime(t *testing.T) {
 	}
 }
 
+// Ensure subscribers skip expired messages and don't receive messages before
+// their deliver-after time.
+func TestSubscribeExpirationAndDeliverAfter(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	// Wait for server to elect itself leader.
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	client, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Create stream.
+	name := "foo"
+	subject := "foo"
+	err = client.CreateStream(context.Background(), subject, name)
+	require.NoError(t, err)
+
+	conn, err := grpc.Dial("localhost:5050", grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	apiClient := proto.NewAPIClient(conn)
+
+	// Publish an expired message, an unexpired message, a delayed message,
+	// and a message with neither header.
+	var (
+		now          = time.Now()
+		deliverAfter = now.Add(2 * time.Second)
+		headers      = []map[string][]byte{
+			{commitlog.ExpirationHeader: []byte(strconv.FormatInt(now.Add(-time.Second).UnixNano(), 10))},
+			{commitlog.ExpirationHeader: []byte(strconv.FormatInt(now.Add(time.Hour).UnixNano(), 10))},
+			{commitlog.DeliverAfterHeader: []byte(strconv.FormatInt(deliverAfter.UnixNano(), 10))},
+			nil,
+		}
+	)
+	for i, h := range headers {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err = apiClient.Publish(ctx, &proto.PublishRequest{
+			Message: &proto.Message{
+				Subject:   subject,
+				Value:     []byte(strconv.Itoa(i)),
+				Headers:   h,
+				AckPolicy: proto.AckPolicy_ALL,
+			},
+		})
+		cancel()
+		require.NoError(t, err)
+	}
+
+	msgs := make(chan *proto.Message, len(headers))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = client.Subscribe(ctx, subject, name, func(msg *proto.Message, err error) {
+		if err != nil {
+			return
+		}
+		msgs <- msg
+	}, lift.StartAtEarliestReceived())
+	require.NoError(t, err)
+
+	// The expired message is skipped and the delayed message isn't received
+	// before its deliver-after time. The message following the delayed one
+	// isn't held back with it.
+	for _, offset := range []int64{1, 3, 2} {
+		select {
+		case msg := <-msgs:
+			require.Equal(t, offset, msg.Offset)
+			if offset == 2 {
+				require.False(t, time.Now().Before(deliverAfter))
+			} else {
+				require.True(t, time.Now().Before(deliverAfter))
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Did not receive expected message")
+		}
+	}
+}
+
 // Ensure clients can connect with TLS when enabled.
 func TestTLS(t *testing.T) {
 	defer cleanupStorage(t)
@@ -1100,3 +1315,81 @@ func TestTLS(t *testing.T) {
 	_, err = lift.Connect([]string{"localhost:5050"})
 	require.Error(t, err)
 }
+
+// Ensure servers can run with clustered embedded NATS servers rather than an
+// external NATS cluster.
+func TestEmbeddedNATS(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Configure first server with an embedded NATS server.
+	s1Config, err := NewConfig("./configs/embedded-nats.conf")
+	require.NoError(t, err)
+	require.Equal(t, EmbeddedNATSConfig{
+		Enabled:     true,
+		Host:        "localhost",
+		Port:        4223,
+		ClusterHost: "localhost",
+		ClusterPort: 6223,
+		Routes:      []string{"nats://localhost:6224"},
+	}, s1Config.EmbeddedNATS)
+	s1Config.DataDir = filepath.Join(storagePath, "a")
+	s1Config.LogSilent = true
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	// Configure second server with an embedded NATS server routed to the
+	// first.
+	s2Config := getTestConfig("b", false, 5051)
+	s2Config.EmbeddedNATS = EmbeddedNATSConfig{
+		Enabled:     true,
+		Host:        "localhost",
+		Port:        4224,
+		ClusterHost: "localhost",
+		ClusterPort: 6224,
+		Routes:      []string{"nats://localhost:6223"},
+	}
+	s2 := runServerWithConfig(t, s2Config)
+	defer s2.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1, s2)
+
+	client, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	name := "foo"
+	subject := "foo"
+	err = client.CreateStream(context.Background(), subject, name,
+		lift.ReplicationFactor(2))
+	require.NoError(t, err)
+
+	// Publish a message and wait for both replicas to commit it.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = client.Publish(ctx, subject, []byte("hello"), lift.AckPolicyAll())
+	require.NoError(t, err)
+	waitForHW(t, 5*time.Second, subject, name, 0, s1, s2)
+}
+
+// Ensure compact.min.dirty.ratio accepts integer as well as floating-point
+// values and rejects values outside of [0, 1].
+func TestConfigCompactMinDirtyRatio(t *testing.T) {
+	defer cleanupStorage(t)
+	require.NoError(t, os.MkdirAll(storagePath, os.ModePerm))
+	file := filepath.Join(storagePath, "liftbridge.conf")
+
+	for value, expected := range map[string]float64{"0": 0, "1": 1, "0.5": 0.5} {
+		data := fmt.Sprintf("log {\n    compact.min.dirty.ratio: %s\n}\n", value)
+		require.NoError(t, ioutil.WriteFile(file, []byte(data), 0666))
+		config, err := NewConfig(file)
+		require.NoError(t, err)
+		require.Equal(t, expected, config.Log.CompactMinDirtyRatio)
+	}
+
+	for _, value := range []string{"2", "1.5", "-1", `"half"`} {
+		data := fmt.Sprintf("log {\n    compact.min.dirty.ratio: %s\n}\n", value)
+		require.NoError(t, ioutil.WriteFile(file, []byte(data), 0666))
+		_, err := NewConfig(file)
+		require.Error(t, err)
+	}
+}
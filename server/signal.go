@@ -8,16 +8,21 @@ import (
 	"syscall"
 )
 
-// handleSignals sets up a handler for SIGINT to do a graceful shutdown.
+// handleSignals sets up a handler for SIGINT to do a graceful shutdown and
+// SIGHUP to reload the configuration file.
 func (s *Server) handleSignals() {
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, syscall.SIGINT)
+	signal.Notify(c, syscall.SIGINT, syscall.SIGHUP)
 	go func() {
 		for sig := range c {
 			switch sig {
 			case syscall.SIGINT:
 				s.Stop()
 				os.Exit(0)
+			case syscall.SIGHUP:
+				if _, _, err := s.reloadConfig(); err != nil {
+					s.logger.Errorf("Failed to reload configuration: %v", err)
+				}
 			}
 		}
 	}()
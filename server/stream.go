@@ -6,16 +6,20 @@ import (
 	"fmt"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Workiva/go-datastructures/queue"
+	"github.com/dustin/go-humanize/english"
 	client "github.com/liftbridge-io/go-liftbridge/liftbridge-grpc"
 	"github.com/nats-io/nats.go"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 
 	"github.com/liftbridge-io/liftbridge/server/commitlog"
+	"github.com/liftbridge-io/liftbridge/server/logger"
 	"github.com/liftbridge-io/liftbridge/server/proto"
+	"github.com/liftbridge-io/liftbridge/server/tracing"
 )
 
 // recvChannelSize specifies the size of the channel that feeds the leader
@@ -73,21 +77,33 @@ type stream struct {
 	sub             *nats.Subscription // Subscription to stream NATS subject
 	leaderReplSub   *nats.Subscription // Subscription for replication requests from followers
 	leaderOffsetSub *nats.Subscription // Subscription for leader epoch offset requests from followers
+	publishSub      *nats.Subscription // Subscription for messages forwarded to the leader
 	recvChan        chan *nats.Msg     // Channel leader places received messages on
 	log             CommitLog
+	logger          logger.Logger
 	srv             *Server
 	subjectHash     string
 	isLeading       bool
 	isFollowing     bool
 	replicas        map[string]struct{}
 	isr             map[string]*replica
+	isrSize         int32 // Size of the ISR, read atomically without holding mu
 	replicators     map[string]*replicator
 	commitQueue     *queue.Queue
+	commitSpans     map[int64]*tracing.Span // Traced messages pending commit
+	commitSpansMu   sync.Mutex
 	commitCheck     chan struct{}
 	recovered       bool
 	stopFollower    chan struct{}
 	stopLeader      chan struct{}
 	belowMinISR     bool
+	consumers       map[string]*consumer // Named consumers subscribed on this server
+	consumersMu     sync.Mutex
+	ackWaiters      map[string]chan *ackResult // Publishers waiting in-process for acks by token
+	ackWaitersMu    sync.Mutex
+	purging         bool // The log is being trimmed up to StartOffset
+	purgeMu         sync.Mutex
+	purgeWait       sync.WaitGroup
 	pause           bool // Pause replication on the leader (for unit testing)
 	shutdown        sync.WaitGroup
 }
@@ -98,20 +114,34 @@ type stream struct {
 // backing commit log or return an error if it fails to do so.
 func (s *Server) newStream(protoStream *proto.Stream, recovered bool) (*stream, error) {
 	var (
-		file     = filepath.Join(s.config.DataDir, "streams", protoStream.Subject, protoStream.Name)
-		name     = fmt.Sprintf("[subject=%s, name=%s]", protoStream.Subject, protoStream.Name)
-		log, err = commitlog.New(commitlog.Options{
-			Stream:               name,
-			Path:                 file,
-			MaxSegmentBytes:      s.config.Log.SegmentMaxBytes,
-			MaxLogBytes:          s.config.Log.RetentionMaxBytes,
-			MaxLogMessages:       s.config.Log.RetentionMaxMessages,
-			MaxLogAge:            s.config.Log.RetentionMaxAge,
-			LogRollTime:          s.config.Log.LogRollTime,
-			CleanerInterval:      s.config.Log.CleanerInterval,
-			Compact:              s.config.Log.Compact,
-			CompactMaxGoroutines: s.config.Log.CompactMaxGoroutines,
-			Logger:               s.logger,
+		file      = filepath.Join(s.config.DataDir, "streams", protoStream.Subject, protoStream.Name)
+		name      = fmt.Sprintf("[subject=%s, name=%s]", protoStream.Subject, protoStream.Name)
+		logConfig = s.getLogConfig()
+		logger    = s.logger.WithFields(logger.Fields{
+			logger.FieldStreamSubject: protoStream.Subject,
+			logger.FieldStreamName:    protoStream.Name,
+		})
+		flushMessages, flushInterval = streamFlushPolicy(logConfig, protoStream.FlushPolicy)
+		log, err                     = commitlog.New(commitlog.Options{
+			Stream:                 name,
+			Path:                   file,
+			MaxSegmentBytes:        logConfig.SegmentMaxBytes,
+			MaxLogBytes:            logConfig.RetentionMaxBytes,
+			MaxLogMessages:         logConfig.RetentionMaxMessages,
+			MaxLogAge:              logConfig.RetentionMaxAge,
+			LogRollTime:            logConfig.LogRollTime,
+			CleanerInterval:        logConfig.CleanerInterval,
+			Compact:                logConfig.Compact,
+			CompactMaxGoroutines:   logConfig.CompactMaxGoroutines,
+			CompactDeleteRetention: logConfig.CompactDeleteRetention,
+			CompactMinLag:          logConfig.CompactMinLag,
+			CompactMaxLag:          logConfig.CompactMaxLag,
+			CompactMinDirtyRatio:   logConfig.CompactMinDirtyRatio,
+			CleanerPool:            s.cleanerPool,
+			FlushMessages:          flushMessages,
+			FlushInterval:          flushInterval,
+			OnFlush:                s.flushObserver(protoStream),
+			Logger:                 logger,
 		})
 	)
 	if err != nil {
@@ -140,17 +170,31 @@ func (s *Server) newStream(protoStream *proto.Stream, recovered bool) (*stream,
 	st := &stream{
 		Stream:      protoStream,
 		log:         log,
+		logger:      logger,
 		srv:         s,
 		subjectHash: subjectHash,
 		replicas:    replicas,
 		isr:         isr,
+		isrSize:     int32(len(isr)),
 		commitCheck: make(chan struct{}, len(protoStream.Replicas)),
 		recovered:   recovered,
+		consumers:   make(map[string]*consumer),
+		ackWaiters:  make(map[string]chan *ackResult),
 	}
 
 	return st, nil
 }
 
+// streamFlushPolicy returns the number of messages and the interval after
+// which a stream's log is flushed to disk. The stream's own flush policy, if
+// it has one, overrides the server's.
+func streamFlushPolicy(config LogConfig, policy *proto.FlushPolicy) (int64, time.Duration) {
+	if policy == nil {
+		return config.FlushMessages, config.FlushInterval
+	}
+	return policy.Messages, time.Duration(policy.Interval)
+}
+
 // String returns a human-readable string representation of the stream.
 func (s *stream) String() string {
 	return fmt.Sprintf("[subject=%s, name=%s]", s.Subject, s.Name)
@@ -158,6 +202,9 @@ func (s *stream) String() string {
 
 // Close stops the stream if it is running and closes the commit log.
 func (s *stream) Close() error {
+	// Wait for the log to finish being trimmed before closing it.
+	s.purgeWait.Wait()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -198,6 +245,48 @@ func (s *stream) SetLeader(leader string, epoch uint64) error {
 	return s.startLeadingOrFollowing()
 }
 
+// SetUncleanLeader sets the leader for the stream to the given out-of-sync
+// replica and leader epoch following an unclean leader election. Since the
+// rest of the ISR is gone, the in-sync replicas set is reset to just the new
+// leader and the other replicas rejoin it once they catch up. Otherwise, this
+// behaves like SetLeader.
+func (s *stream) SetUncleanLeader(leader string, epoch uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if epoch < s.LeaderEpoch {
+		return fmt.Errorf("proposed leader epoch %d is less than current epoch %d",
+			epoch, s.LeaderEpoch)
+	}
+	if !s.inReplicas(leader) {
+		return fmt.Errorf("%s not a replica", leader)
+	}
+	offset := int64(-1)
+	// If this server is the new leader, initialize its offset to the newest
+	// offset so that the messages it has can be committed.
+	if leader == s.srv.config.Clustering.ServerID {
+		offset = s.log.NewestOffset()
+	}
+	s.isr = map[string]*replica{leader: {offset: offset}}
+	s.Isr = []string{leader}
+	s.updateISRSize()
+	if minISR := s.srv.config.Clustering.MinISR; !s.belowMinISR && len(s.isr) < minISR {
+		s.logger.Errorf("ISR for stream %s has shrunk below minimum size %d, currently %d",
+			s, minISR, len(s.isr))
+		s.belowMinISR = true
+	}
+	s.Leader = leader
+	s.LeaderEpoch = epoch
+
+	if s.recovered {
+		// If this stream is being recovered, we will start the leader/follower
+		// loop later.
+		return nil
+	}
+
+	return s.startLeadingOrFollowing()
+}
+
 // StartRecovered starts the stream as a leader or follower, if applicable, if
 // it's in recovery mode. This should be called for each stream after the
 // recovery process completes.
@@ -218,15 +307,17 @@ func (s *stream) StartRecovered() (bool, error) {
 // applicable.
 func (s *stream) startLeadingOrFollowing() error {
 	if s.Leader == s.srv.config.Clustering.ServerID {
-		s.srv.logger.Debugf("Server becoming leader for stream %s, epoch: %d", s, s.LeaderEpoch)
+		s.logger.WithFields(logger.Fields{logger.FieldEpoch: s.LeaderEpoch}).Debugf(
+			"Server becoming leader for stream %s, epoch: %d", s, s.LeaderEpoch)
 		if err := s.becomeLeader(s.LeaderEpoch); err != nil {
-			s.srv.logger.Errorf("Server failed becoming leader for stream %s: %v", s, err)
+			s.logger.Errorf("Server failed becoming leader for stream %s: %v", s, err)
 			return err
 		}
 	} else if s.inReplicas(s.srv.config.Clustering.ServerID) {
-		s.srv.logger.Debugf("Server becoming follower for stream %s, epoch: %d", s, s.LeaderEpoch)
+		s.logger.WithFields(logger.Fields{logger.FieldEpoch: s.LeaderEpoch}).Debugf(
+			"Server becoming follower for stream %s, epoch: %d", s, s.LeaderEpoch)
 		if err := s.becomeFollower(); err != nil {
-			s.srv.logger.Errorf("Server failed becoming follower for stream %s: %v", s, err)
+			s.logger.Errorf("Server failed becoming follower for stream %s: %v", s, err)
 			return err
 		}
 	}
@@ -283,20 +374,28 @@ func (s *stream) becomeLeader(epoch uint64) error {
 	// Start replicating to followers.
 	s.startReplicating(epoch, s.stopLeader)
 
-	// Subscribe to the NATS subject and begin sequencing messages.
-	// TODO: This should be drained on shutdown.
-	sub, err := s.srv.nc.QueueSubscribe(s.Subject, s.Group, func(m *nats.Msg) {
-		s.recvChan <- m
-	})
-	if err != nil {
-		return errors.Wrap(err, "failed to subscribe to NATS")
+	if s.Mirror != nil {
+		// Mirror streams sequence messages pulled from the source stream
+		// rather than the NATS subject.
+		if err := s.startMirroring(s.recvChan, s.stopLeader); err != nil {
+			return err
+		}
+	} else {
+		// Subscribe to the NATS subject and begin sequencing messages.
+		// TODO: This should be drained on shutdown.
+		sub, err := s.srv.nc.QueueSubscribe(s.Subject, s.Group, func(m *nats.Msg) {
+			s.recvChan <- m
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to subscribe to NATS")
+		}
+		sub.SetPendingLimits(-1, -1)
+		s.sub = sub
+		s.srv.nc.Flush()
 	}
-	sub.SetPendingLimits(-1, -1)
-	s.sub = sub
-	s.srv.nc.Flush()
 
 	// Subscribe to the stream replication subject.
-	sub, err = s.srv.ncRepl.Subscribe(s.getReplicationRequestInbox(), s.handleReplicationRequest)
+	sub, err := s.srv.ncRepl.Subscribe(s.getReplicationRequestInbox(), s.handleReplicationRequest)
 	if err != nil {
 		return errors.Wrap(err, "failed to subscribe to replication inbox")
 	}
@@ -310,6 +409,14 @@ func (s *stream) becomeLeader(epoch uint64) error {
 	}
 	sub.SetPendingLimits(-1, -1)
 	s.leaderOffsetSub = sub
+
+	// Also subscribe to messages forwarded by other servers.
+	sub, err = s.srv.ncRepl.Subscribe(s.getPublishInbox(), s.handlePublishRequest)
+	if err != nil {
+		return errors.Wrap(err, "failed to subscribe to publish inbox")
+	}
+	sub.SetPendingLimits(-1, -1)
+	s.publishSub = sub
 	s.srv.ncRepl.Flush()
 
 	s.isLeading = true
@@ -323,8 +430,11 @@ func (s *stream) becomeLeader(epoch uint64) error {
 // replication, and disposing the commit queue.
 func (s *stream) stopLeading() error {
 	// Unsubscribe from NATS subject.
-	if err := s.sub.Unsubscribe(); err != nil {
-		return err
+	if s.sub != nil {
+		if err := s.sub.Unsubscribe(); err != nil {
+			return err
+		}
+		s.sub = nil
 	}
 
 	// Unsubscribe from replication subject.
@@ -332,9 +442,22 @@ func (s *stream) stopLeading() error {
 		return err
 	}
 
+	// Unsubscribe from leader epoch offset subject.
+	if err := s.leaderOffsetSub.Unsubscribe(); err != nil {
+		return err
+	}
+
+	// Unsubscribe from publish subject.
+	if err := s.publishSub.Unsubscribe(); err != nil {
+		return err
+	}
+
 	// Stop processing messages and replicating.
 	s.shutdown.Add(1) // Message processing loop
 	s.shutdown.Add(1) // Commit loop
+	if s.Mirror != nil {
+		s.shutdown.Add(1) // Mirror loop
+	}
 	if replicas := len(s.replicas); replicas > 1 {
 		s.shutdown.Add(replicas - 1) // Replicator loops (minus one to exclude self)
 	}
@@ -344,6 +467,7 @@ func (s *stream) stopLeading() error {
 	s.shutdown.Wait()
 
 	s.commitQueue.Dispose()
+	s.abortCommitSpans("stream leadership lost")
 	s.isLeading = false
 
 	return nil
@@ -371,7 +495,7 @@ func (s *stream) becomeFollower() error {
 
 	// Start fetching messages from the leader's log starting at the HW.
 	s.stopFollower = make(chan struct{})
-	s.srv.logger.Debugf("Replicating stream %s from leader %s", s, s.Leader)
+	s.logger.Debugf("Replicating stream %s from leader %s", s, s.Leader)
 	s.srv.startGoroutine(func() {
 		s.replicationRequestLoop(s.Leader, s.LeaderEpoch, s.stopFollower)
 	})
@@ -392,6 +516,18 @@ func (s *stream) stopFollowing() error {
 	return nil
 }
 
+// StopFollowing stops replicating the stream from its leader if this server is
+// a follower. This is used during a controlled shutdown so that the server can
+// leave the ISR without the leader adding it back.
+func (s *stream) StopFollowing() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.isFollowing {
+		return nil
+	}
+	return s.stopFollowing()
+}
+
 // handleLeaderOffsetRequest is a NATS handler that's invoked when the leader
 // receives a leader epoch offset request from a follower. The request will
 // contain the latest leader epoch in the follower's leader epoch sequence.
@@ -402,7 +538,7 @@ func (s *stream) stopFollowing() error {
 func (s *stream) handleLeaderOffsetRequest(msg *nats.Msg) {
 	req := &proto.LeaderEpochOffsetRequest{}
 	if err := req.Unmarshal(msg.Data); err != nil {
-		s.srv.logger.Errorf("Invalid leader epoch offset request for stream %s: %v", s, err)
+		s.logger.Errorf("Invalid leader epoch offset request for stream %s: %v", s, err)
 		return
 	}
 	resp, err := (&proto.LeaderEpochOffsetResponse{
@@ -412,7 +548,7 @@ func (s *stream) handleLeaderOffsetRequest(msg *nats.Msg) {
 		panic(err)
 	}
 	if err := msg.Respond(resp); err != nil {
-		s.srv.logger.Errorf("Failed to respond to leader offset request: %v", err)
+		s.logger.Errorf("Failed to respond to leader offset request: %v", err)
 	}
 }
 
@@ -422,7 +558,7 @@ func (s *stream) handleLeaderOffsetRequest(msg *nats.Msg) {
 func (s *stream) handleReplicationRequest(msg *nats.Msg) {
 	req := &proto.ReplicationRequest{}
 	if err := req.Unmarshal(msg.Data); err != nil {
-		s.srv.logger.Errorf("Invalid replication request for stream %s: %v", s, err)
+		s.logger.Errorf("Invalid replication request for stream %s: %v", s, err)
 		return
 	}
 	s.mu.Lock()
@@ -431,7 +567,7 @@ func (s *stream) handleReplicationRequest(msg *nats.Msg) {
 		return
 	}
 	if _, ok := s.replicas[req.ReplicaID]; !ok {
-		s.srv.logger.Warnf("Received replication request for stream %s from non-replica %s",
+		s.logger.Warnf("Received replication request for stream %s from non-replica %s",
 			s, req.ReplicaID)
 		s.mu.Unlock()
 		return
@@ -450,7 +586,7 @@ func (s *stream) handleReplicationRequest(msg *nats.Msg) {
 func (s *stream) handleReplicationResponse(msg *nats.Msg) int {
 	// We should have at least 16 bytes, 8 for leader epoch and 8 for HW.
 	if len(msg.Data) < 16 {
-		s.srv.logger.Warnf("Invalid replication response for stream %s", s)
+		s.logger.Warnf("Invalid replication response for stream %s", s)
 		return 0
 	}
 
@@ -479,7 +615,7 @@ func (s *stream) handleReplicationResponse(msg *nats.Msg) int {
 
 	// We should have at least 28 bytes for headers.
 	if len(data) <= 28 {
-		s.srv.logger.Warnf("Invalid replication response for stream %s", s)
+		s.logger.Warnf("Invalid replication response for stream %s", s)
 		return 0
 	}
 	offset := int64(proto.Encoding.Uint64(data[:8]))
@@ -522,17 +658,20 @@ func (s *stream) messageProcessingLoop(recvChan <-chan *nats.Msg, stop <-chan st
 		batchSize = s.srv.config.BatchMaxMessages
 		batchWait = s.srv.config.BatchWaitTime
 		msgBatch  = make([]*proto.Message, 0, batchSize)
+		spans     = make([]*tracing.Span, 0, batchSize)
 	)
 	for {
 		msgBatch = msgBatch[:0]
+		spans = spans[:0]
 		select {
 		case <-stop:
 			return
 		case msg = <-recvChan:
 		}
 
-		m := natsToProtoMessage(msg, leaderEpoch)
+		m, span := natsToProtoMessage(msg, leaderEpoch, s.srv.tracer)
 		msgBatch = append(msgBatch, m)
+		spans = append(spans, span)
 		remaining := batchSize - 1
 
 		// Fill the batch up to the max batch size or until the channel is
@@ -557,19 +696,27 @@ func (s *stream) messageProcessingLoop(recvChan <-chan *nats.Msg, stop <-chan st
 
 			for i := 0; i < chanLen; i++ {
 				msg = <-recvChan
-				m := natsToProtoMessage(msg, leaderEpoch)
+				m, span := natsToProtoMessage(msg, leaderEpoch, s.srv.tracer)
 				msgBatch = append(msgBatch, m)
+				spans = append(spans, span)
 			}
 			remaining -= chanLen
 		}
 
+		msgBatch, spans = s.rejectUnderReplicated(msgBatch, spans)
+		if len(msgBatch) == 0 {
+			continue
+		}
+
 		// Write uncommitted messages to log.
 		offsets, err := s.log.Append(msgBatch)
 		if err != nil {
-			s.srv.logger.Errorf("Failed to append to log %s: %v", s, err)
+			s.logger.Errorf("Failed to append to log %s: %v", s, err)
 			return
 		}
 
+		s.endAppendSpans(spans, offsets)
+
 		for i, msg := range msgBatch {
 			s.processPendingMessage(offsets[i], msg)
 		}
@@ -582,11 +729,103 @@ func (s *stream) messageProcessingLoop(recvChan <-chan *nats.Msg, stop <-chan st
 	}
 }
 
-// processPendingMessage sends an ack if the message's AckPolicy is LEADER and
-// adds the pending message to the commit queue. Messages are removed from the
-// queue and committed when the entire ISR has replicated them.
-func (s *stream) processPendingMessage(offset int64, msg *proto.Message) {
-	ack := &client.Ack{
+// endAppendSpans ends the append spans for traced messages which were written
+// to the log and starts their commit spans, which end once the messages are
+// committed.
+func (s *stream) endAppendSpans(spans []*tracing.Span, offsets []int64) {
+	if s.srv.tracer == nil {
+		return
+	}
+	s.commitSpansMu.Lock()
+	defer s.commitSpansMu.Unlock()
+	for i, span := range spans {
+		if span == nil {
+			continue
+		}
+		span.SetAttribute(logger.FieldStreamSubject, s.Subject)
+		span.SetAttribute(logger.FieldStreamName, s.Name)
+		span.SetAttribute(logger.FieldOffset, offsets[i])
+		span.End()
+		commitSpan := s.srv.tracer.StartSpan("commit", span.Context)
+		commitSpan.SetAttribute(logger.FieldStreamSubject, s.Subject)
+		commitSpan.SetAttribute(logger.FieldStreamName, s.Name)
+		commitSpan.SetAttribute(logger.FieldOffset, offsets[i])
+		s.commitSpans[offsets[i]] = commitSpan
+	}
+}
+
+// endCommitSpans ends the commit spans for any of the given committed messages
+// which are traced.
+func (s *stream) endCommitSpans(committed []interface{}) {
+	if s.srv.tracer == nil {
+		return
+	}
+	s.commitSpansMu.Lock()
+	defer s.commitSpansMu.Unlock()
+	for _, ackIface := range committed {
+		offset := ackIface.(*client.Ack).Offset
+		if span, ok := s.commitSpans[offset]; ok {
+			span.End()
+			delete(s.commitSpans, offset)
+		}
+	}
+}
+
+// abortCommitSpans ends the commit spans for any traced messages still pending
+// commit with the given reason, since they won't be committed by this leader.
+func (s *stream) abortCommitSpans(reason string) {
+	s.commitSpansMu.Lock()
+	defer s.commitSpansMu.Unlock()
+	for _, span := range s.commitSpans {
+		span.SetAttribute("error", reason)
+		span.End()
+	}
+	s.commitSpans = make(map[int64]*tracing.Span)
+}
+
+// rejectUnderReplicated nacks the messages in the batch whose AckPolicy is ALL
+// if the ISR is below the minimum ISR size, since they can't be committed
+// until it recovers, and returns the remaining messages and their spans.
+func (s *stream) rejectUnderReplicated(msgs []*proto.Message, spans []*tracing.Span) (
+	[]*proto.Message, []*tracing.Span) {
+
+	var (
+		minISR  = s.srv.config.Clustering.MinISR
+		isrSize = s.ISRSize()
+	)
+	if isrSize >= minISR {
+		return msgs, spans
+	}
+
+	var (
+		reason = fmt.Sprintf("Not enough replicas, ISR size (%d) below minimum (%d)",
+			isrSize, minISR)
+		accepted      = msgs[:0]
+		acceptedSpans = spans[:0]
+	)
+	for i, msg := range msgs {
+		if msg.AckPolicy != client.AckPolicy_ALL {
+			accepted = append(accepted, msg)
+			acceptedSpans = append(acceptedSpans, spans[i])
+			continue
+		}
+		spans[i].SetAttribute("error", reason)
+		spans[i].End()
+		s.sendNack(s.newAck(-1, msg), &proto.AckError{
+			Code: proto.AckErrorCode_NOT_ENOUGH_REPLICAS,
+			Msg:  reason,
+		})
+	}
+	if rejected := len(msgs) - len(accepted); rejected > 0 {
+		s.logger.Warnf("Rejected %s for stream %s, ISR size (%d) below minimum (%d)",
+			english.Plural(rejected, "message", ""), s, isrSize, minISR)
+	}
+	return accepted, acceptedSpans
+}
+
+// newAck returns an ack for the given message written at the given offset.
+func (s *stream) newAck(offset int64, msg *proto.Message) *client.Ack {
+	return &client.Ack{
 		StreamSubject: s.Subject,
 		StreamName:    s.Name,
 		MsgSubject:    string(msg.Headers["subject"]),
@@ -595,6 +834,13 @@ func (s *stream) processPendingMessage(offset int64, msg *proto.Message) {
 		CorrelationId: msg.CorrelationID,
 		AckPolicy:     msg.AckPolicy,
 	}
+}
+
+// processPendingMessage sends an ack if the message's AckPolicy is LEADER and
+// adds the pending message to the commit queue. Messages are removed from the
+// queue and committed when the entire ISR has replicated them.
+func (s *stream) processPendingMessage(offset int64, msg *proto.Message) {
+	ack := s.newAck(offset, msg)
 	if msg.AckPolicy == client.AckPolicy_LEADER {
 		// Send the ack now since AckPolicy_LEADER means we ack as soon as the
 		// leader has written the message to its WAL.
@@ -610,9 +856,12 @@ func (s *stream) processPendingMessage(offset int64, msg *proto.Message) {
 // messages in the commit queue and a replication goroutine for each replica.
 func (s *stream) startReplicating(epoch uint64, stop chan struct{}) {
 	if s.ReplicationFactor > 1 {
-		s.srv.logger.Debugf("Replicating stream %s to followers", s)
+		s.logger.Debugf("Replicating stream %s to followers", s)
 	}
 	s.commitQueue = queue.New(100)
+	s.commitSpansMu.Lock()
+	s.commitSpans = make(map[int64]*tracing.Span)
+	s.commitSpansMu.Unlock()
 	s.srv.startGoroutine(func() {
 		s.commitLoop(stop)
 		s.shutdown.Done()
@@ -628,8 +877,12 @@ func (s *stream) startReplicating(epoch uint64, stop chan struct{}) {
 			replica:    replica,
 			stream:     s,
 			requests:   make(chan replicationRequest, 1),
-			maxLagTime: s.srv.config.Clustering.ReplicaMaxLagTime,
+			maxLagTime: s.srv.getReplicaMaxLagTime(),
 			leader:     s.srv.config.Clustering.ServerID,
+			logger: s.logger.WithFields(logger.Fields{
+				logger.FieldReplica: replica,
+				logger.FieldEpoch:   epoch,
+			}),
 		}
 		s.replicators[replica] = r
 		s.srv.startGoroutine(func() {
@@ -639,6 +892,17 @@ func (s *stream) startReplicating(epoch uint64, stop chan struct{}) {
 	}
 }
 
+// setReplicaMaxLagTime updates the max lag time used by the stream's
+// replicators, if it's currently the leader, to determine when replicas should
+// be removed from the ISR.
+func (s *stream) setReplicaMaxLagTime(maxLagTime time.Duration) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, r := range s.replicators {
+		r.setMaxLagTime(maxLagTime)
+	}
+}
+
 // commitLoop is a long-running loop which checks to see if messages in the
 // commit queue can be committed and, if so, removes them from the queue and
 // sends client acks. It runs until the stop channel is closed.
@@ -660,7 +924,7 @@ func (s *stream) commitLoop(stop chan struct{}) {
 		)
 		if isrSize < minISR {
 			s.mu.RUnlock()
-			s.srv.logger.Errorf(
+			s.logger.Errorf(
 				"Unable to commit messages for stream %s, ISR size (%d) below minimum (%d)",
 				s, isrSize, minISR)
 			continue
@@ -697,6 +961,8 @@ func (s *stream) commitLoop(stop chan struct{}) {
 			continue
 		}
 
+		s.endCommitSpans(committed)
+
 		// Ack any committed entries (if applicable).
 		for _, ackIface := range committed {
 			ack := ackIface.(*client.Ack)
@@ -708,16 +974,40 @@ func (s *stream) commitLoop(stop chan struct{}) {
 	}
 }
 
-// sendAck publishes an ack to the specified AckInbox. If no AckInbox is set,
-// this does nothing.
+// sendAck publishes an ack to the specified AckInbox or, if the message was
+// published directly to the stream, delivers it in-process. If no AckInbox is
+// set, this does nothing.
 func (s *stream) sendAck(ack *client.Ack) {
-	if ack.AckInbox == "" {
+	if ack.AckInbox == "" || s.deliverAck(ack, nil) {
 		return
 	}
+	s.publishAck(ack, nil)
+}
+
+// sendNack sends an ack which rejects the message with the given error the
+// same way as sendAck. The error is added to the ack as an AckExtension. If no
+// AckInbox is set, this does nothing.
+func (s *stream) sendNack(ack *client.Ack, ackErr *proto.AckError) {
+	if ack.AckInbox == "" || s.deliverAck(ack, ackErrorStatus(ackErr)) {
+		return
+	}
+	s.publishAck(ack, &proto.AckExtension{Error: ackErr})
+}
+
+// publishAck publishes the ack, followed by the given extension fields if
+// set, to the ack's AckInbox.
+func (s *stream) publishAck(ack *client.Ack, ext *proto.AckExtension) {
 	data, err := ack.Marshal()
 	if err != nil {
 		panic(err)
 	}
+	if ext != nil {
+		extData, err := ext.Marshal()
+		if err != nil {
+			panic(err)
+		}
+		data = append(data, extData...)
+	}
 	s.srv.ncAcks.Publish(ack.AckInbox, data)
 }
 
@@ -738,7 +1028,7 @@ func (s *stream) replicationRequestLoop(leader string, epoch uint64, stop chan s
 
 		replicated, err := s.sendReplicationRequest()
 		if err != nil {
-			s.srv.logger.Errorf(
+			s.logger.Errorf(
 				"Error sending replication request for stream %s: %v", s, err)
 		} else {
 			leaderLastSeen = time.Now()
@@ -771,7 +1061,7 @@ func (s *stream) checkLeaderHealth(leader string, epoch uint64, leaderLastSeen t
 	if lastSeenElapsed > s.srv.config.Clustering.ReplicaMaxLeaderTimeout {
 		// Leader has not sent a response in ReplicaMaxLeaderTimeout, so report
 		// it to controller.
-		s.srv.logger.Errorf("Leader %s for stream %s exceeded max leader timeout "+
+		s.logger.Errorf("Leader %s for stream %s exceeded max leader timeout "+
 			"(last seen: %s), reporting leader to controller",
 			leader, s, lastSeenElapsed)
 		req := &proto.ReportLeaderOp{
@@ -782,7 +1072,7 @@ func (s *stream) checkLeaderHealth(leader string, epoch uint64, leaderLastSeen t
 			LeaderEpoch: epoch,
 		}
 		if err := s.srv.metadata.ReportLeader(context.Background(), req); err != nil {
-			s.srv.logger.Errorf("Failed to report leader %s for stream %s: %s",
+			s.logger.Errorf("Failed to report leader %s for stream %s: %s",
 				leader, s, err.Err())
 		}
 	}
@@ -824,6 +1114,8 @@ func (s *stream) sendReplicationRequest() (int, error) {
 // leader epoch larger than the current epoch. This removes any potentially
 // uncommitted messages in the log.
 func (s *stream) truncateUncommitted() error {
+	s.abortCommitSpans("log truncated")
+
 	// Request the last offset for the epoch from the leader.
 	var (
 		lastOffset  int64
@@ -840,7 +1132,7 @@ func (s *stream) truncateUncommitted() error {
 		break
 	}
 	if err != nil {
-		s.srv.logger.Errorf(
+		s.logger.Errorf(
 			"Failed to fetch last offset for leader epoch for stream %s: %v",
 			s, err)
 		// Fall back to HW truncation if we fail to fetch last offset for
@@ -850,7 +1142,16 @@ func (s *stream) truncateUncommitted() error {
 		return s.truncateToHW()
 	}
 
-	s.srv.logger.Debugf("Truncating log for stream %s to %d", s, lastOffset)
+	if hw := s.log.HighWatermark(); lastOffset < hw {
+		// This replica committed messages the leader doesn't have, which
+		// only happens if the leader was elected uncleanly.
+		s.logger.WithFields(logger.Fields{logger.FieldOffset: lastOffset}).Errorf(
+			"Truncating committed messages after offset %d, up to HW %d, from stream %s "+
+				"since they were lost by an unclean leader election", lastOffset, hw, s)
+	}
+
+	s.logger.WithFields(logger.Fields{logger.FieldOffset: lastOffset}).Debugf(
+		"Truncating log for stream %s to %d", s, lastOffset)
 	// Add 1 because we don't want to truncate the last offset itself.
 	return s.log.Truncate(lastOffset + 1)
 }
@@ -890,7 +1191,8 @@ func (s *stream) truncateToHW() error {
 	if newestOffset == hw {
 		return nil
 	}
-	s.srv.logger.Debugf("Truncating log for stream %s to HW %d", s, hw)
+	s.logger.WithFields(logger.Fields{logger.FieldOffset: hw}).Debugf(
+		"Truncating log for stream %s to HW %d", s, hw)
 	// Add 1 because we don't want to truncate the HW itself.
 	return s.log.Truncate(hw + 1)
 }
@@ -909,6 +1211,75 @@ func (s *stream) inReplicas(id string) bool {
 	return ok
 }
 
+// Purge records that all messages before the given offset are removed from
+// the stream and starts trimming the stream's log in the background. Trimming
+// happens outside of Raft apply since it waits for any cleaning of the log in
+// progress. It returns false if messages were already purged before a later
+// offset, in which case this does nothing.
+func (s *stream) Purge(offset int64) bool {
+	s.mu.Lock()
+	if offset <= s.StartOffset {
+		s.mu.Unlock()
+		return false
+	}
+	s.StartOffset = offset
+	s.mu.Unlock()
+	s.purgeLog()
+	return true
+}
+
+// GetStartOffset returns the offset messages were last purged before.
+func (s *stream) GetStartOffset() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.StartOffset
+}
+
+// purgeLog trims the stream's log up to its start offset in the background
+// unless it's already being trimmed, in which case the running trim picks up
+// the latest start offset once it's done. Since the purge was already
+// committed, failures are logged rather than returned.
+func (s *stream) purgeLog() {
+	s.purgeMu.Lock()
+	defer s.purgeMu.Unlock()
+	if s.purging {
+		return
+	}
+	s.purging = true
+	s.purgeWait.Add(1)
+	go func() {
+		defer s.purgeWait.Done()
+		for {
+			offset := s.GetStartOffset()
+			if err := s.log.Purge(offset); err != nil {
+				s.logger.Errorf("Failed to purge messages before offset %d from stream %s: %v",
+					offset, s, err)
+			} else {
+				s.logger.Infof("Purged messages before offset %d from stream %s", offset, s)
+			}
+			s.purgeMu.Lock()
+			if s.GetStartOffset() == offset {
+				s.purging = false
+				s.purgeMu.Unlock()
+				return
+			}
+			s.purgeMu.Unlock()
+		}
+	}()
+}
+
+// OffsetForTimestamp returns the offset of the first message in the stream's
+// log whose timestamp is greater than or equal to the given timestamp. If
+// there is no such message, the log's next offset is returned.
+func (s *stream) OffsetForTimestamp(timestamp int64) (int64, error) {
+	if s.log.OldestOffset() == -1 {
+		// The log is empty.
+		return s.log.NewestOffset() + 1, nil
+	}
+	offset, err := s.log.OffsetForTimestamp(timestamp)
+	return offset, errors.Wrap(err, "failed to find offset for timestamp")
+}
+
 // RemoveFromISR removes the given replica from the in-sync replicas set. It
 // returns an error if the broker is not a stream replica. This will also
 // insert a check to see if pending messages need to be committed since the ISR
@@ -920,6 +1291,7 @@ func (s *stream) RemoveFromISR(replica string) error {
 		return fmt.Errorf("%s not a replica", replica)
 	}
 	delete(s.isr, replica)
+	s.updateISRSize()
 
 	// Check if ISR went below minimum ISR size. This is important for
 	// operators to be aware of.
@@ -928,7 +1300,7 @@ func (s *stream) RemoveFromISR(replica string) error {
 		isrSize = len(s.isr)
 	)
 	if !s.belowMinISR && isrSize < minISR {
-		s.srv.logger.Errorf("ISR for stream %s has shrunk below minimum size %d, currently %d",
+		s.logger.Errorf("ISR for stream %s has shrunk below minimum size %d, currently %d",
 			s, minISR, isrSize)
 		s.belowMinISR = true
 	}
@@ -953,6 +1325,7 @@ func (s *stream) AddToISR(rep string) error {
 		return fmt.Errorf("%s not a replica", rep)
 	}
 	s.isr[rep] = &replica{offset: -1}
+	s.updateISRSize()
 
 	// Check if ISR recovered from being below the minimum ISR size.
 	var (
@@ -960,7 +1333,7 @@ func (s *stream) AddToISR(rep string) error {
 		isrSize = len(s.isr)
 	)
 	if s.belowMinISR && isrSize >= minISR {
-		s.srv.logger.Infof("ISR for stream %s has recovered from being below minimum size %d, currently %d",
+		s.logger.Infof("ISR for stream %s has recovered from being below minimum size %d, currently %d",
 			s, minISR, isrSize)
 		s.belowMinISR = false
 	}
@@ -968,6 +1341,60 @@ func (s *stream) AddToISR(rep string) error {
 	return nil
 }
 
+// Reassign sets the brokers which are replicas for the stream, removes any
+// which are no longer replicas from the in-sync replicas set, and sets the
+// leader to the given replica and leader epoch. If the stream's current leader
+// epoch is greater than the given epoch, this returns an error. Since the
+// replica set changes, this restarts the stream as a leader or follower, if
+// applicable, unless the stream is in recovery mode.
+func (s *stream) Reassign(replicas []string, leader string, epoch uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if epoch < s.LeaderEpoch {
+		return fmt.Errorf("proposed leader epoch %d is less than current epoch %d",
+			epoch, s.LeaderEpoch)
+	}
+
+	// Stop leading or following before changing the replicas since the
+	// leader's replicators are started for the current replica set.
+	if s.isLeading {
+		if err := s.stopLeading(); err != nil {
+			return err
+		}
+	} else if s.isFollowing {
+		if err := s.stopFollowing(); err != nil {
+			return err
+		}
+	}
+
+	s.replicas = make(map[string]struct{}, len(replicas))
+	for _, replica := range replicas {
+		s.replicas[replica] = struct{}{}
+	}
+	for replica := range s.isr {
+		if !s.inReplicas(replica) {
+			delete(s.isr, replica)
+		}
+	}
+	s.Replicas = replicas
+	s.Isr = make([]string, 0, len(s.isr))
+	for replica := range s.isr {
+		s.Isr = append(s.Isr, replica)
+	}
+	s.updateISRSize()
+	s.Leader = leader
+	s.LeaderEpoch = epoch
+
+	if s.recovered {
+		// If this stream is being recovered, we will start the leader/follower
+		// loop later.
+		return nil
+	}
+
+	return s.startLeadingOrFollowing()
+}
+
 // GetEpoch returns the current stream epoch. The epoch is a monotonically
 // increasing number which increases when a change is made to the stream. This
 // is used to determine if an operation is outdated.
@@ -997,11 +1424,16 @@ func (s *stream) Marshal() []byte {
 }
 
 // ISRSize returns the current number of replicas in the in-sync replicas set.
+// This doesn't take the mu, so it's safe to call from the message processing
+// loop while the stream is being stopped with the mu held.
 func (s *stream) ISRSize() int {
-	s.mu.RLock()
-	size := len(s.isr)
-	s.mu.RUnlock()
-	return size
+	return int(atomic.LoadInt32(&s.isrSize))
+}
+
+// updateISRSize records the current size of the in-sync replicas set for
+// ISRSize. This must be called with the mu held whenever the ISR changes.
+func (s *stream) updateISRSize() {
+	atomic.StoreInt32(&s.isrSize, int32(len(s.isr)))
 }
 
 // GetISR returns the in-sync replicas set.
@@ -1072,8 +1504,14 @@ func getMessage(data []byte) *client.Message {
 	return msg
 }
 
-// natsToProtoMessage converts the given NATS message to a proto Message.
-func natsToProtoMessage(msg *nats.Msg, leaderEpoch uint64) *proto.Message {
+// natsToProtoMessage converts the given NATS message to a proto Message. If the
+// message carries a sampled trace context and tracing is enabled, this starts
+// an append span as a child of it and returns the span. The message's trace
+// context is replaced with the span's so that spans for later operations on
+// the message are children of it.
+func natsToProtoMessage(msg *nats.Msg, leaderEpoch uint64, tracer *tracing.Tracer) (
+	*proto.Message, *tracing.Span) {
+
 	message := getMessage(msg.Data)
 	m := &proto.Message{
 		MagicByte:   1,
@@ -1095,7 +1533,12 @@ func natsToProtoMessage(msg *nats.Msg, leaderEpoch uint64) *proto.Message {
 	}
 	m.Headers["subject"] = []byte(msg.Subject)
 	m.Headers["reply"] = []byte(msg.Reply)
-	return m
+
+	span := tracer.StartSpanFromTraceParent("append", string(m.Headers[tracing.TraceParentHeader]))
+	if span != nil {
+		m.Headers[tracing.TraceParentHeader] = []byte(span.TraceParent())
+	}
+	return m, span
 }
 
 // min returns the minimum int64 contained in the slice.
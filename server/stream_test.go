@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/liftbridge-io/liftbridge/server/proto"
+	"github.com/liftbridge-io/liftbridge/server/tracing"
 )
 
 func createServer(leader bool) *Server {
@@ -412,6 +413,9 @@ func TestStreamRemoveFromISRLeader(t *testing.T) {
 	require.NoError(t, err)
 	defer s.Close()
 	s.isLeading = true
+	// The stream never started leading, so there's nothing to stop when it's
+	// closed. Otherwise, Close fails before closing the log, which leaks.
+	defer func() { s.isLeading = false }()
 	require.NoError(t, s.RemoveFromISR("b"))
 
 	// Verify there is a commit check.
@@ -446,6 +450,45 @@ func TestStreamRemoveFromISRBelowMin(t *testing.T) {
 	require.True(t, s.belowMinISR)
 }
 
+// Ensure messages with AckPolicy_ALL are rejected while the ISR is below the
+// minimum without taking the stream's mu, which is held while the stream
+// stops leading and waits for the message processing loop to exit.
+func TestStreamRejectUnderReplicatedWithoutLock(t *testing.T) {
+	defer cleanupStorage(t)
+	server := createServer(false)
+	server.config.Clustering.MinISR = 3
+	s, err := server.newStream(&proto.Stream{
+		Subject:  "foo",
+		Name:     "foo",
+		Replicas: []string{"a", "b", "c"},
+		Leader:   "b",
+		Isr:      []string{"a", "b", "c"},
+	}, false)
+	require.NoError(t, err)
+	defer s.Close()
+	require.NoError(t, s.RemoveFromISR("b"))
+	require.Equal(t, 2, s.ISRSize())
+
+	msgs := []*proto.Message{
+		{AckPolicy: client.AckPolicy_ALL},
+		{AckPolicy: client.AckPolicy_LEADER},
+	}
+	accepted := make(chan []*proto.Message, 1)
+	s.mu.Lock()
+	go func() {
+		msgs, _ := s.rejectUnderReplicated(msgs, make([]*tracing.Span, len(msgs)))
+		accepted <- msgs
+	}()
+	select {
+	case msgs := <-accepted:
+		require.Len(t, msgs, 1)
+		require.Equal(t, client.AckPolicy_LEADER, msgs[0].AckPolicy)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Rejecting messages blocked on the stream's mu")
+	}
+	s.mu.Unlock()
+}
+
 // Ensure AddToISR returns an error if the replica is not a stream replica.
 func TestStreamAddToISRNotReplica(t *testing.T) {
 	defer cleanupStorage(t)
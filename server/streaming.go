@@ -0,0 +1,411 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	client "github.com/liftbridge-io/go-liftbridge/liftbridge-grpc"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nuid"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/liftbridge-io/liftbridge/server/proto"
+)
+
+// defaultAsyncAckTimeout is how long a message published on a PublishAsync
+// stream waits for its ack if the ack-timeout metadata key isn't set.
+const defaultAsyncAckTimeout = 5 * time.Second
+
+// streamingServer implements the gRPC server interface for long-lived client
+// streams, complementing the client API.
+type streamingServer struct {
+	*Server
+}
+
+// PublishAsync publishes the messages sent on the stream and sends their acks
+// back on the stream as they're received, so clients can pipeline messages
+// rather than waiting for each ack in turn. Acks for every message go to a
+// single AckInbox, avoiding a subscription per message. Each message whose
+// AckPolicy is not NONE must have a CorrelationId, which its acks and errors
+// carry. Messages whose AckPolicy is ALL which the stream leader rejects are
+// reported with the status code it rejected them with. A message whose ack
+// isn't received within the ack timeout, set on the ack-timeout metadata key,
+// is reported with a DeadlineExceeded status code. Once the client closes its
+// side of the stream, this waits for the acks of the messages still in flight,
+// which is bounded by the ack timeout.
+func (s *streamingServer) PublishAsync(stream proto.StreamingAPI_PublishAsyncServer) error {
+	s.logger.Debugf("api: PublishAsync")
+
+	ackTimeout, err := publishAckTimeout(stream.Context())
+	if err != nil {
+		s.logger.Errorf("api: Failed to publish messages: %v", err)
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var (
+		publisher = newAsyncPublisher(stream, ackTimeout)
+		ackInbox  = nuid.Next()
+	)
+	defer publisher.close()
+
+	sub, err := s.ncPublishes.Subscribe(ackInbox, func(m *nats.Msg) {
+		ack, st, err := decodeAck(m.Data)
+		if err != nil {
+			s.logger.Errorf("api: Invalid ack for async publish: %v", err)
+			return
+		}
+		if err := publisher.ack(ack, st); err != nil {
+			s.logger.Warnf("api: Failed to send ack for async publish: %v", err)
+		}
+	})
+	if err != nil {
+		s.logger.Errorf("api: Failed to subscribe to ack inbox: %v", err)
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return publisher.wait(stream.Context())
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.publishAsync(stream.Context(), publisher, ackInbox, req.Message); err != nil {
+			return err
+		}
+	}
+}
+
+// publishAsync publishes a message received on a PublishAsync stream with the
+// given AckInbox. Errors publishing the message are sent on the stream, while
+// invalid messages end it with an InvalidArgument status.
+func (s *streamingServer) publishAsync(ctx context.Context, publisher *asyncPublisher,
+	ackInbox string, msg *client.Message) error {
+
+	if msg == nil {
+		s.logger.Errorf("api: Failed to publish message: message is nil")
+		return status.Error(codes.InvalidArgument, "Message is nil")
+	}
+	waitForAck := msg.AckPolicy != client.AckPolicy_NONE
+	if waitForAck && msg.CorrelationId == "" {
+		s.logger.Errorf("api: Failed to publish message: no correlation ID")
+		return status.Error(codes.InvalidArgument, "Message with AckPolicy requires a correlation ID")
+	}
+
+	span := s.startPublishSpan(ctx, msg)
+	defer span.End()
+
+	msg.AckInbox = ""
+	if waitForAck {
+		msg.AckInbox = ackInbox
+	}
+
+	buf, err := marshalEnvelope(msg)
+	if err != nil {
+		s.logger.Errorf("api: Failed to publish message: %v", err)
+		return publisher.fail(msg.CorrelationId, status.New(codes.Internal, err.Error()))
+	}
+
+	if waitForAck {
+		publisher.add(msg.CorrelationId)
+	}
+	if err := s.ncPublishes.Publish(msg.Subject, buf); err != nil {
+		s.logger.Errorf("api: Failed to publish message: %v", err)
+		span.SetAttribute("error", err.Error())
+		return publisher.fail(msg.CorrelationId, status.New(codes.Internal, err.Error()))
+	}
+	return nil
+}
+
+// Subscribe creates an ephemeral subscription for the stream in the first
+// request, like the client API's Subscribe, but only sends messages while the
+// client has credits left. Each request grants the number of credits it
+// carries, and each message sent uses one. Once out of credits, the next
+// message is held back until more are granted. If the client doesn't grant any
+// within the subscriber stall timeout, it's evicted with a ResourceExhausted
+// status so that it doesn't pin the subscription indefinitely.
+func (s *streamingServer) Subscribe(out proto.StreamingAPI_SubscribeServer) error {
+	first, err := out.Recv()
+	if err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+	req := first.Subscribe
+	if req == nil {
+		s.logger.Errorf("api: Failed to subscribe: no subscribe request")
+		return status.Error(codes.InvalidArgument, "First request must start the subscription")
+	}
+	s.logger.Debugf("api: Subscribe [subject=%s, name=%s, start=%s, offset=%d, timestamp=%d, credits=%d]",
+		req.Subject, req.Name, req.StartPosition, req.StartOffset, req.StartTimestamp, first.Credits)
+
+	api := &apiServer{s.Server}
+	stream, err := api.getSubscribeStream(req)
+	if err != nil {
+		return err
+	}
+
+	cancel := make(chan struct{})
+	defer close(cancel)
+	ch, errCh, startOffset, st := api.subscribe(out.Context(), stream, req, cancel)
+	if st != nil {
+		if st.Code() != codes.OutOfRange {
+			s.logger.Errorf("api: Failed to subscribe to stream %s: %v", stream, st.Err())
+		}
+		return st.Err()
+	}
+
+	header := metadata.Pairs(startOffsetMetadataKey, strconv.FormatInt(startOffset, 10))
+	if err := out.SendHeader(header); err != nil {
+		return err
+	}
+
+	// Send an empty message which signals the subscription was successfully
+	// created.
+	if err := out.Send(&client.Message{}); err != nil {
+		return err
+	}
+
+	// Receive credits granted after the first request in the background.
+	var (
+		creditCh = make(chan uint32)
+		recvCh   = make(chan error, 1)
+	)
+	s.startGoroutine(func() {
+		for {
+			req, err := out.Recv()
+			if err != nil {
+				recvCh <- err
+				return
+			}
+			select {
+			case creditCh <- req.Credits:
+			case <-cancel:
+				return
+			}
+		}
+	})
+
+	var (
+		credits      = int64(first.Credits)
+		stallTimeout = s.config.SubscriberStallTimeout
+		held         *client.Message
+		stallTimer   *time.Timer
+		stalled      <-chan time.Time
+	)
+	defer func() {
+		if stallTimer != nil {
+			stallTimer.Stop()
+		}
+	}()
+	for {
+		// Only read the next message if none is being held back.
+		msgCh := ch
+		if held != nil {
+			msgCh = nil
+		}
+		select {
+		case <-out.Context().Done():
+			return nil
+		case m := <-msgCh:
+			if credits > 0 {
+				if err := out.Send(m); err != nil {
+					return err
+				}
+				credits--
+				continue
+			}
+			held = m
+			if stallTimeout > 0 {
+				stallTimer = time.NewTimer(stallTimeout)
+				stalled = stallTimer.C
+			}
+		case n := <-creditCh:
+			credits += int64(n)
+			if held == nil || credits == 0 {
+				continue
+			}
+			if err := out.Send(held); err != nil {
+				return err
+			}
+			credits--
+			held = nil
+			if stallTimer != nil {
+				stallTimer.Stop()
+				stallTimer, stalled = nil, nil
+			}
+		case err := <-recvCh:
+			// The client closing its side of the stream only means it won't
+			// grant more credits.
+			if err != io.EOF {
+				return err
+			}
+			recvCh = nil
+		case err := <-errCh:
+			return err.Err()
+		case <-stalled:
+			s.logger.Warnf("api: Evicting subscriber of stream %s which granted no credits for %s",
+				stream, stallTimeout)
+			s.metrics.IncrCounterWithLabels([]string{"subscriber", "evictions"}, 1, []metrics.Label{
+				{Name: "subject", Value: stream.Subject},
+				{Name: "name", Value: stream.Name},
+			})
+			return status.Error(codes.ResourceExhausted,
+				fmt.Sprintf("Subscriber stalled without granting credits for %s", stallTimeout))
+		}
+	}
+}
+
+// asyncPublisher sends the responses for a PublishAsync stream and tracks the
+// correlation IDs of the messages published on it which are waiting for acks.
+type asyncPublisher struct {
+	mu         sync.Mutex
+	stream     proto.StreamingAPI_PublishAsyncServer
+	ackTimeout time.Duration
+	pending    map[string]*pendingAck // Messages waiting for acks by correlation ID
+	drained    chan struct{}
+	closed     bool
+}
+
+func newAsyncPublisher(stream proto.StreamingAPI_PublishAsyncServer,
+	ackTimeout time.Duration) *asyncPublisher {
+
+	return &asyncPublisher{
+		stream:     stream,
+		ackTimeout: ackTimeout,
+		pending:    make(map[string]*pendingAck),
+	}
+}
+
+// pendingAck is a message published on a PublishAsync stream which is waiting
+// for its ack.
+type pendingAck struct {
+	timeout *time.Timer
+}
+
+// add tracks a message published with the given correlation ID as waiting for
+// its ack. If the ack isn't received within the ack timeout, a
+// DeadlineExceeded error is sent for the message.
+func (p *asyncPublisher) add(correlationID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pending, ok := p.pending[correlationID]; ok {
+		pending.timeout.Stop()
+	}
+	pending := &pendingAck{}
+	pending.timeout = time.AfterFunc(p.ackTimeout, func() {
+		p.expire(correlationID, pending)
+	})
+	p.pending[correlationID] = pending
+}
+
+// expire sends a DeadlineExceeded error for the message with the given
+// correlation ID if it's still the given pending message waiting for its ack.
+func (p *asyncPublisher) expire(correlationID string, pending *pendingAck) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if current, ok := p.pending[correlationID]; !ok || current != pending {
+		return
+	}
+	// The stream is broken if this fails, which ends the RPC.
+	p.sendLocked(correlationID, &proto.PublishAsyncResponse{
+		Error: &proto.PublishAsyncError{
+			Code: uint32(codes.DeadlineExceeded),
+			Msg:  fmt.Sprintf("Ack not received within %s", p.ackTimeout),
+		},
+		CorrelationId: correlationID,
+	})
+}
+
+// ack sends an ack received for a published message, along with the status
+// the stream leader rejected the message with if it did. The ack is dropped if
+// the message is no longer waiting for it, i.e. a DeadlineExceeded error was
+// already sent for it, so that each message gets a single response.
+func (p *asyncPublisher) ack(ack *client.Ack, st *status.Status) error {
+	resp := &proto.PublishAsyncResponse{Ack: ack, CorrelationId: ack.CorrelationId}
+	if st != nil {
+		resp.Error = &proto.PublishAsyncError{Code: uint32(st.Code()), Msg: st.Message()}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.pending[ack.CorrelationId]; !ok {
+		return nil
+	}
+	return p.sendLocked(ack.CorrelationId, resp)
+}
+
+// fail sends an error for a message which couldn't be published.
+func (p *asyncPublisher) fail(correlationID string, st *status.Status) error {
+	return p.send(correlationID, &proto.PublishAsyncResponse{
+		Error:         &proto.PublishAsyncError{Code: uint32(st.Code()), Msg: st.Message()},
+		CorrelationId: correlationID,
+	})
+}
+
+// send sends a response on the stream for the message with the given
+// correlation ID, which is no longer waiting for an ack. Responses are
+// dropped once the stream is closed.
+func (p *asyncPublisher) send(correlationID string, resp *proto.PublishAsyncResponse) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sendLocked(correlationID, resp)
+}
+
+// sendLocked sends a response like send. It must be called while holding the
+// lock.
+func (p *asyncPublisher) sendLocked(correlationID string, resp *proto.PublishAsyncResponse) error {
+	if p.closed {
+		return nil
+	}
+	if pending, ok := p.pending[correlationID]; ok {
+		pending.timeout.Stop()
+		delete(p.pending, correlationID)
+	}
+	err := p.stream.Send(resp)
+	if p.drained != nil && len(p.pending) == 0 {
+		close(p.drained)
+		p.drained = nil
+	}
+	return err
+}
+
+// wait blocks until every published message has been acked or has timed out
+// waiting for its ack, or the context is done.
+func (p *asyncPublisher) wait(ctx context.Context) error {
+	p.mu.Lock()
+	if len(p.pending) == 0 {
+		p.mu.Unlock()
+		return nil
+	}
+	p.drained = make(chan struct{})
+	drained := p.drained
+	p.mu.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close stops any further responses from being sent on the stream, which must
+// not be used once the RPC returns, and stops the pending ack timeouts.
+func (p *asyncPublisher) close() {
+	p.mu.Lock()
+	p.closed = true
+	for _, pending := range p.pending {
+		pending.timeout.Stop()
+	}
+	p.mu.Unlock()
+}
@@ -0,0 +1,393 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	lift "github.com/liftbridge-io/go-liftbridge"
+	client "github.com/liftbridge-io/go-liftbridge/liftbridge-grpc"
+	natsdTest "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/liftbridge-io/liftbridge/server/proto"
+)
+
+// Ensure messages published on a PublishAsync stream are acked on the stream
+// with their correlation IDs and that the stream ends once every ack has been
+// sent after the client stops sending.
+func TestPublishAsync(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	c, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.CreateStream(context.Background(), "foo", "foo"))
+
+	conn, err := grpc.Dial("localhost:5050", grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := proto.NewStreamingAPIClient(conn).PublishAsync(ctx)
+	require.NoError(t, err)
+
+	// Send every message before reading any acks.
+	num := 10
+	for i := 0; i < num; i++ {
+		require.NoError(t, stream.Send(&proto.PublishAsyncRequest{
+			Message: &client.Message{
+				Subject:       "foo",
+				Value:         []byte("hello"),
+				CorrelationId: fmt.Sprintf("%d", i),
+				AckPolicy:     client.AckPolicy_LEADER,
+			},
+		}))
+	}
+	require.NoError(t, stream.CloseSend())
+
+	offsets := make(map[string]int64)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.Nil(t, resp.Error)
+		require.NotNil(t, resp.Ack)
+		require.Equal(t, "foo", resp.Ack.StreamName)
+		require.Equal(t, resp.CorrelationId, resp.Ack.CorrelationId)
+		offsets[resp.CorrelationId] = resp.Ack.Offset
+	}
+	require.Len(t, offsets, num)
+	for i := 0; i < num; i++ {
+		require.Equal(t, int64(i), offsets[fmt.Sprintf("%d", i)])
+	}
+}
+
+// Ensure a message published on a PublishAsync stream whose ack isn't received
+// within the ack timeout is reported with a DeadlineExceeded error and that the
+// stream ends once it has been reported after the client stops sending.
+func TestPublishAsyncAckTimeout(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	conn, err := grpc.Dial("localhost:5050", grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		ackTimeoutMetadataKey, "100ms")
+	stream, err := proto.NewStreamingAPIClient(conn).PublishAsync(ctx)
+	require.NoError(t, err)
+
+	// No stream is attached to the subject, so the message is never acked.
+	require.NoError(t, stream.Send(&proto.PublishAsyncRequest{
+		Message: &client.Message{
+			Subject:       "foo",
+			Value:         []byte("hello"),
+			CorrelationId: "cid",
+			AckPolicy:     client.AckPolicy_LEADER,
+		},
+	}))
+	require.NoError(t, stream.CloseSend())
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "cid", resp.CorrelationId)
+	require.Nil(t, resp.Ack)
+	require.NotNil(t, resp.Error)
+	require.Equal(t, uint32(codes.DeadlineExceeded), resp.Error.Code)
+
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+}
+
+// Ensure an ack received after a PublishAsync message has timed out waiting for
+// it is dropped, so that the message is only reported once.
+func TestPublishAsyncLateAck(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	// No stream is attached to the subject, so capture the published
+	// messages to ack them late.
+	nc, err := nats.Connect(nats.DefaultURL)
+	require.NoError(t, err)
+	defer nc.Close()
+	published, err := nc.SubscribeSync("foo")
+	require.NoError(t, err)
+	require.NoError(t, nc.Flush())
+
+	conn, err := grpc.Dial("localhost:5050", grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		ackTimeoutMetadataKey, "100ms")
+	stream, err := proto.NewStreamingAPIClient(conn).PublishAsync(ctx)
+	require.NoError(t, err)
+
+	publish := func(correlationID string) *nats.Msg {
+		require.NoError(t, stream.Send(&proto.PublishAsyncRequest{
+			Message: &client.Message{
+				Subject:       "foo",
+				Value:         []byte("hello"),
+				CorrelationId: correlationID,
+				AckPolicy:     client.AckPolicy_LEADER,
+			},
+		}))
+		m, err := published.NextMsg(5 * time.Second)
+		require.NoError(t, err)
+		return m
+	}
+	expectTimeout := func(correlationID string) {
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, correlationID, resp.CorrelationId)
+		require.Nil(t, resp.Ack)
+		require.NotNil(t, resp.Error)
+		require.Equal(t, uint32(codes.DeadlineExceeded), resp.Error.Code)
+	}
+
+	m := publish("cid1")
+	expectTimeout("cid1")
+
+	// Ack the message after it has timed out.
+	msg := new(client.Message)
+	require.NoError(t, msg.Unmarshal(m.Data[envelopeCookieLen:]))
+	ack, err := (&client.Ack{
+		StreamSubject: "foo",
+		StreamName:    "foo",
+		MsgSubject:    "foo",
+		AckInbox:      msg.AckInbox,
+		CorrelationId: "cid1",
+	}).Marshal()
+	require.NoError(t, err)
+	require.NoError(t, nc.Publish(msg.AckInbox, ack))
+	require.NoError(t, nc.Flush())
+
+	// The next response is for the next message rather than the late ack.
+	publish("cid2")
+	expectTimeout("cid2")
+
+	require.NoError(t, stream.CloseSend())
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+}
+
+// Ensure a PublishAsync stream ends with an InvalidArgument status if a message
+// which needs an ack has no correlation ID.
+func TestPublishAsyncNoCorrelationID(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	conn, err := grpc.Dial("localhost:5050", grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := proto.NewStreamingAPIClient(conn).PublishAsync(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&proto.PublishAsyncRequest{
+		Message: &client.Message{
+			Subject:   "foo",
+			Value:     []byte("hello"),
+			AckPolicy: client.AckPolicy_LEADER,
+		},
+	}))
+
+	_, err = stream.Recv()
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// subscribeFlow starts a flow-controlled subscription to the earliest offset of
+// the given stream with the given initial credits and receives the empty
+// message signaling it was created.
+func subscribeFlow(t *testing.T, ctx context.Context, conn *grpc.ClientConn, subject, name string,
+	credits uint32) proto.StreamingAPI_SubscribeClient {
+
+	stream, err := proto.NewStreamingAPIClient(conn).Subscribe(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&proto.SubscribeFlowRequest{
+		Subscribe: &client.SubscribeRequest{
+			Subject:       subject,
+			Name:          name,
+			StartPosition: client.StartPosition_EARLIEST,
+		},
+		Credits: credits,
+	}))
+	_, err = stream.Recv()
+	require.NoError(t, err)
+	return stream
+}
+
+// Ensure a flow-controlled subscription only sends as many messages as the
+// client has granted credits for.
+func TestSubscribeFlowControl(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	c, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.CreateStream(context.Background(), "foo", "foo"))
+	for i := 0; i < 5; i++ {
+		_, err := c.Publish(context.Background(), "foo", []byte("hello"), lift.AckPolicyLeader())
+		require.NoError(t, err)
+	}
+
+	conn, err := grpc.Dial("localhost:5050", grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream := subscribeFlow(t, ctx, conn, "foo", "foo", 2)
+
+	msgs := make(chan *client.Message, 5)
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			msgs <- msg
+		}
+	}()
+
+	// Only the messages there are credits for are sent.
+	for i := 0; i < 2; i++ {
+		select {
+		case msg := <-msgs:
+			require.Equal(t, int64(i), msg.Offset)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Did not receive expected message")
+		}
+	}
+	select {
+	case msg := <-msgs:
+		t.Fatalf("Received unexpected message at offset %d", msg.Offset)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// Granting more credits sends the rest.
+	require.NoError(t, stream.Send(&proto.SubscribeFlowRequest{Credits: 3}))
+	for i := 2; i < 5; i++ {
+		select {
+		case msg := <-msgs:
+			require.Equal(t, int64(i), msg.Offset)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Did not receive expected message")
+		}
+	}
+}
+
+// Ensure a flow-controlled subscriber which doesn't grant credits for a
+// message waiting to be sent within the stall timeout is evicted.
+func TestSubscribeFlowControlEvictStalled(t *testing.T) {
+	defer cleanupStorage(t)
+
+	// Use a central NATS server.
+	ns := natsdTest.RunDefaultServer()
+	defer ns.Shutdown()
+
+	// Configure server.
+	s1Config := getTestConfig("a", true, 5050)
+	s1Config.SubscriberStallTimeout = 100 * time.Millisecond
+	s1 := runServerWithConfig(t, s1Config)
+	defer s1.Stop()
+
+	getMetadataLeader(t, 10*time.Second, s1)
+
+	c, err := lift.Connect([]string{"localhost:5050"})
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.CreateStream(context.Background(), "foo", "foo"))
+
+	conn, err := grpc.Dial("localhost:5050", grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream := subscribeFlow(t, ctx, conn, "foo", "foo", 0)
+
+	// An idle subscriber isn't stalled, only one holding back messages.
+	time.Sleep(300 * time.Millisecond)
+	_, err = c.Publish(context.Background(), "foo", []byte("hello"), lift.AckPolicyLeader())
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	require.Error(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// The eviction is counted.
+	evictions := 0
+	for _, interval := range s1.metricsSink.Data() {
+		interval.RLock()
+		if counter, ok := interval.Counters["liftbridge.subscriber.evictions;subject=foo;name=foo"]; ok {
+			evictions += counter.Count
+		}
+		interval.RUnlock()
+	}
+	require.Equal(t, 1, evictions)
+}
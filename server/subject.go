@@ -0,0 +1,64 @@
+package server
+
+import (
+	"errors"
+	"strings"
+
+	natsd "github.com/nats-io/nats-server/v2/server"
+)
+
+const (
+	subjectTokenSep     = "."
+	subjectWildcard     = "*"
+	subjectFullWildcard = ">"
+)
+
+// subjectHasWildcard indicates if the given subject contains a NATS wildcard
+// token.
+func subjectHasWildcard(subject string) bool {
+	for _, token := range strings.Split(subject, subjectTokenSep) {
+		if token == subjectWildcard || token == subjectFullWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// validateStreamSubject returns an error if the given subject can't be used
+// for a stream. Streams may use wildcard subjects, in which case the stream
+// captures every matching subject, but a wildcard subject must not match the
+// internal subjects used by servers in the given namespace.
+func validateStreamSubject(subject, namespace string) error {
+	if !natsd.IsValidSubject(subject) {
+		return errors.New("invalid subject")
+	}
+	if !subjectHasWildcard(subject) {
+		return nil
+	}
+	first := strings.Split(subject, subjectTokenSep)[0]
+	if first == subjectWildcard || first == subjectFullWildcard || first == namespace {
+		return errors.New("wildcard subject overlaps internal subjects")
+	}
+	return nil
+}
+
+// subjectMatches indicates if the given literal subject matches the subject
+// pattern, which may contain wildcards.
+func subjectMatches(subject, pattern string) bool {
+	var (
+		tokens        = strings.Split(subject, subjectTokenSep)
+		patternTokens = strings.Split(pattern, subjectTokenSep)
+	)
+	for i, patternToken := range patternTokens {
+		if patternToken == subjectFullWildcard {
+			return len(tokens) > i
+		}
+		if i >= len(tokens) {
+			return false
+		}
+		if patternToken != subjectWildcard && patternToken != tokens[i] {
+			return false
+		}
+	}
+	return len(tokens) == len(patternTokens)
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Ensure subjectMatches matches literal subjects against subject patterns
+// using NATS wildcard semantics.
+func TestSubjectMatches(t *testing.T) {
+	tests := []struct {
+		subject string
+		pattern string
+		matches bool
+	}{
+		{"foo", "foo", true},
+		{"foo", "bar", false},
+		{"foo.bar", "foo", false},
+		{"foo", "foo.bar", false},
+		{"foo.bar", "foo.*", true},
+		{"foo.bar.baz", "foo.*", false},
+		{"foo.bar.baz", "foo.*.baz", true},
+		{"foo.bar.qux", "foo.*.baz", false},
+		{"foo.bar", "foo.>", true},
+		{"foo.bar.baz", "foo.>", true},
+		{"foo", "foo.>", false},
+		{"foo.bar", ">", true},
+		{"foo.bar.baz", "*.bar.>", true},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.matches, subjectMatches(test.subject, test.pattern),
+			"subject: %s, pattern: %s", test.subject, test.pattern)
+	}
+}
@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Exporter receives spans once they have ended. Implementations must be safe
+// for concurrent use.
+type Exporter interface {
+	// ExportSpan exports the given span. The span must not be modified.
+	ExportSpan(*Span) error
+
+	// Close flushes any buffered spans and releases resources held by the
+	// Exporter.
+	Close() error
+}
+
+var (
+	exportersMu sync.RWMutex
+	exporters   = make(map[string]Exporter)
+)
+
+// RegisterExporter makes an Exporter available by the given name so that it
+// can be selected in the server configuration. This allows programs embedding
+// the server to plug in their own exporters. The names "stdout" and "file" are
+// reserved for the built-in exporters.
+func RegisterExporter(name string, exporter Exporter) {
+	exportersMu.Lock()
+	defer exportersMu.Unlock()
+	exporters[name] = exporter
+}
+
+// GetExporter returns the Exporter registered with the given name or nil if
+// there is none.
+func GetExporter(name string) Exporter {
+	exportersMu.RLock()
+	defer exportersMu.RUnlock()
+	return exporters[name]
+}
+
+// writerExporter is an Exporter which writes each span as a line of JSON.
+type writerExporter struct {
+	mu  sync.Mutex
+	w   io.Writer
+	enc *json.Encoder
+}
+
+// NewWriterExporter returns an Exporter which writes each span as a line of
+// JSON to the given io.Writer. If the io.Writer is also an io.Closer, it's
+// closed when the Exporter is closed.
+func NewWriterExporter(w io.Writer) Exporter {
+	return &writerExporter{w: w, enc: json.NewEncoder(w)}
+}
+
+// NewFileExporter returns an Exporter which appends each span as a line of
+// JSON to the file at the given path, creating it if necessary.
+func NewFileExporter(path string) (Exporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open trace file")
+	}
+	return NewWriterExporter(file), nil
+}
+
+// jsonSpan is the JSON representation of a span.
+type jsonSpan struct {
+	Name         string                 `json:"name"`
+	TraceID      string                 `json:"trace_id"`
+	SpanID       string                 `json:"span_id"`
+	ParentSpanID string                 `json:"parent_span_id"`
+	Start        time.Time              `json:"start"`
+	End          time.Time              `json:"end"`
+	Duration     time.Duration          `json:"duration"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+}
+
+func (e *writerExporter) ExportSpan(span *Span) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.enc.Encode(&jsonSpan{
+		Name:         span.Name,
+		TraceID:      span.Context.TraceID.String(),
+		SpanID:       span.Context.SpanID.String(),
+		ParentSpanID: span.ParentSpanID.String(),
+		Start:        span.StartTime,
+		End:          span.EndTime,
+		Duration:     span.EndTime.Sub(span.StartTime),
+		Attributes:   span.Attributes,
+	})
+}
+
+func (e *writerExporter) Close() error {
+	if closer, ok := e.w.(io.Closer); ok && e.w != os.Stdout {
+		return closer.Close()
+	}
+	return nil
+}
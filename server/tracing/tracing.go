@@ -0,0 +1,196 @@
+// Package tracing provides lightweight distributed tracing for messages as
+// they move through the server. Trace context is propagated in message headers
+// using the W3C Trace Context traceparent format, and finished spans are handed
+// to a pluggable Exporter.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TraceParentHeader is the message header and gRPC metadata key used to
+// propagate trace context.
+const TraceParentHeader = "traceparent"
+
+const (
+	traceParentVersion = "00"
+	traceParentLen     = 55
+	flagSampled        = 0x01
+)
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// String returns the hex encoding of the TraceID.
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// String returns the hex encoding of the SpanID.
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext is the trace context propagated between processes.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Flags   byte
+}
+
+// IsSampled indicates if the trace should be recorded.
+func (c SpanContext) IsSampled() bool {
+	return c.Flags&flagSampled != 0
+}
+
+// TraceParent returns the SpanContext encoded as a traceparent header value.
+func (c SpanContext) TraceParent() string {
+	return fmt.Sprintf("%s-%s-%s-%02x", traceParentVersion, c.TraceID, c.SpanID, c.Flags)
+}
+
+// ParseTraceParent decodes a traceparent header value. It returns false if the
+// value is not a valid traceparent.
+func ParseTraceParent(value string) (SpanContext, bool) {
+	var ctx SpanContext
+	if len(value) != traceParentLen {
+		return ctx, false
+	}
+	parts := strings.Split(value, "-")
+	if len(parts) != 4 || parts[0] != traceParentVersion {
+		return ctx, false
+	}
+	if _, err := hex.Decode(ctx.TraceID[:], []byte(parts[1])); err != nil {
+		return ctx, false
+	}
+	if _, err := hex.Decode(ctx.SpanID[:], []byte(parts[2])); err != nil {
+		return ctx, false
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil || len(flags) != 1 {
+		return ctx, false
+	}
+	ctx.Flags = flags[0]
+	// All-zero IDs are invalid.
+	if ctx.TraceID == (TraceID{}) || ctx.SpanID == (SpanID{}) {
+		return ctx, false
+	}
+	return ctx, true
+}
+
+// Span is a timed operation within a trace. Spans are started with a Tracer
+// and exported when End is called. All Span methods are safe to call on a nil
+// Span, which is returned when tracing is disabled or the trace is not
+// sampled.
+type Span struct {
+	Name         string
+	Context      SpanContext
+	ParentSpanID SpanID
+	StartTime    time.Time
+	EndTime      time.Time
+	Attributes   map[string]interface{}
+	tracer       *Tracer
+}
+
+// SetAttribute attaches a key-value attribute to the span.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	if s.Attributes == nil {
+		s.Attributes = make(map[string]interface{})
+	}
+	s.Attributes[key] = value
+}
+
+// TraceParent returns the span's context encoded as a traceparent header
+// value. This is used to propagate the span to downstream operations.
+func (s *Span) TraceParent() string {
+	if s == nil {
+		return ""
+	}
+	return s.Context.TraceParent()
+}
+
+// End finishes the span and hands it to the exporter.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.EndTime = time.Now()
+	s.tracer.export(s)
+}
+
+// Tracer starts spans and exports them once they have ended. All Tracer
+// methods are safe to call on a nil Tracer, in which case tracing is disabled.
+type Tracer struct {
+	exporter Exporter
+	onError  func(error)
+}
+
+// NewTracer creates a new Tracer which exports spans to the given Exporter.
+// The onError callback, if not nil, is invoked when exporting a span fails.
+func NewTracer(exporter Exporter, onError func(error)) *Tracer {
+	return &Tracer{exporter: exporter, onError: onError}
+}
+
+// StartSpan starts a new span as a child of the given parent context. It
+// returns nil if the Tracer is nil or the parent is not sampled.
+func (t *Tracer) StartSpan(name string, parent SpanContext) *Span {
+	if t == nil || !parent.IsSampled() {
+		return nil
+	}
+	span := &Span{
+		Name:         name,
+		ParentSpanID: parent.SpanID,
+		StartTime:    time.Now(),
+		tracer:       t,
+	}
+	span.Context.TraceID = parent.TraceID
+	span.Context.Flags = parent.Flags
+	if _, err := rand.Read(span.Context.SpanID[:]); err != nil {
+		t.handleError(err)
+		return nil
+	}
+	return span
+}
+
+// StartSpanFromTraceParent starts a new span as a child of the context encoded
+// in the given traceparent header value. It returns nil if the Tracer is nil
+// or the value is not a valid, sampled traceparent.
+func (t *Tracer) StartSpanFromTraceParent(name, traceParent string) *Span {
+	if t == nil || traceParent == "" {
+		return nil
+	}
+	parent, ok := ParseTraceParent(traceParent)
+	if !ok {
+		return nil
+	}
+	return t.StartSpan(name, parent)
+}
+
+// Close closes the Tracer's exporter.
+func (t *Tracer) Close() error {
+	if t == nil {
+		return nil
+	}
+	return t.exporter.Close()
+}
+
+func (t *Tracer) export(span *Span) {
+	if err := t.exporter.ExportSpan(span); err != nil {
+		t.handleError(err)
+	}
+}
+
+func (t *Tracer) handleError(err error) {
+	if t.onError != nil {
+		t.onError(err)
+	}
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// Ensure traceparent values are parsed and encoded.
+func TestParseTraceParent(t *testing.T) {
+	ctx, ok := ParseTraceParent(testTraceParent)
+	require.True(t, ok)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", ctx.TraceID.String())
+	require.Equal(t, "00f067aa0ba902b7", ctx.SpanID.String())
+	require.True(t, ctx.IsSampled())
+	require.Equal(t, testTraceParent, ctx.TraceParent())
+}
+
+// Ensure invalid traceparent values are rejected.
+func TestParseTraceParentInvalid(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"foo",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00_4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7_01",
+	} {
+		_, ok := ParseTraceParent(value)
+		require.False(t, ok, value)
+	}
+}
+
+// Ensure spans are started as children of their parent and exported when
+// they end.
+func TestTracerStartSpan(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := NewTracer(NewWriterExporter(&buf), nil)
+
+	span := tracer.StartSpanFromTraceParent("append", testTraceParent)
+	require.NotNil(t, span)
+	span.SetAttribute("offset", 5)
+	span.End()
+
+	var exported map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &exported))
+	require.Equal(t, "append", exported["name"])
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", exported["trace_id"])
+	require.Equal(t, "00f067aa0ba902b7", exported["parent_span_id"])
+	require.Equal(t, span.Context.SpanID.String(), exported["span_id"])
+	require.Equal(t, map[string]interface{}{"offset": float64(5)}, exported["attributes"])
+
+	child, ok := ParseTraceParent(span.TraceParent())
+	require.True(t, ok)
+	require.Equal(t, span.Context, child)
+}
+
+// Ensure spans are not started for unsampled traces or a nil Tracer and that
+// nil spans are safe to use.
+func TestTracerNoSpan(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := NewTracer(NewWriterExporter(&buf), nil)
+	span := tracer.StartSpanFromTraceParent("append",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	require.Nil(t, span)
+	require.Nil(t, tracer.StartSpanFromTraceParent("append", ""))
+
+	var nilTracer *Tracer
+	span = nilTracer.StartSpanFromTraceParent("append", testTraceParent)
+	require.Nil(t, span)
+	span.SetAttribute("offset", 5)
+	require.Equal(t, "", span.TraceParent())
+	span.End()
+	require.NoError(t, nilTracer.Close())
+	require.Equal(t, 0, buf.Len())
+}